@@ -2,7 +2,7 @@ package camodels
 
 // 代码画板包定义了 CodeCanvas 的核心数据结构
 // CodeCanvas 是一款轻量级的代码性能分析和框架检测引擎
-// 本版本仅专注于技术栈识别——不包含安全元数据。
+// 本版本专注于技术栈识别，安全公告匹配结果只在指定本地公告库时生成。
 // 常量定义
 const (
 	// 规则类型
@@ -14,6 +14,9 @@ const (
 	CategoryBackend  = "backend"
 	CategoryDesktop  = "desktop"
 	CategoryOther    = "other"
+
+	// LanguageAny 与语言无关的规则（如 Docker、Nginx），对任何项目都适用
+	LanguageAny = "any"
 )
 
 // AllCategory 代码类型的分类 前端 后端 桌面 其他
@@ -0,0 +1,32 @@
+package camodels
+
+import "sort"
+
+// Dependency 从依赖清单（pom.xml 等）解析出的单个依赖
+type Dependency struct {
+	Ecosystem string `json:"ecosystem"`         // 依赖生态，例如 "maven"
+	Name      string `json:"name"`              // 依赖名称，Maven 为 "groupId:artifactId"
+	Version   string `json:"version,omitempty"` // 解析后的版本号，无法解析时为空
+	Raw       string `json:"raw,omitempty"`     // 清单中声明的原始版本，例如 "${fastjson.version}"
+	Source    string `json:"source,omitempty"`  // 版本来源：range | pinned | resolved
+	Scope     string `json:"scope,omitempty"`   // 依赖范围，例如 "compile"、"test"
+	File      string `json:"file"`              // 声明该依赖的清单文件（相对路径）
+	License   string `json:"license,omitempty"` // 许可证（SPDX 表达式），锁文件或已安装的包中包含许可证信息时填充
+}
+
+// SortDependencies 按生态、名称、清单文件和范围排序，保证输出顺序稳定
+func SortDependencies(dependencies []Dependency) {
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		a, b := dependencies[i], dependencies[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Scope < b.Scope
+	})
+}
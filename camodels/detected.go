@@ -1,5 +1,7 @@
 package camodels
 
+import "slices"
+
 // DetectionInfo 框架与组件识别结果 包含已检测到的框架和组件的列表。
 type DetectionInfo struct {
 	Frameworks []DetectedItem `json:"frameworks"`
@@ -11,7 +13,44 @@ type DetectedItem struct {
 	Name     string `json:"name"`     // 例如: "gin", "log4j-core", "wails"
 	Type     string `json:"type"`     // "framework" 或 "component"
 	Language string `json:"language"` // 例如: "Go", "Java", "JavaScript"
-	Version  string `json:"version"`  // 版本字符串，可能为空
+	Version  string `json:"version"`  // 规范化后的版本字符串，可能为空
 	Category string `json:"category"` // "frontend" | "backend" | "desktop"
 	Evidence string `json:"evidence"` // 人类可读的检测原因
+
+	VersionInfo *VersionInfo   `json:"versionInfo,omitempty"` // 主版本详情：原始值、来源（范围/固定/已解析）和所在文件
+	Versions    []*VersionInfo `json:"versions,omitempty"`    // 检测到的所有不同版本及其所在文件，第一个与 VersionInfo 相同
+
+	// 生命周期信息，生命周期数据中包含该检测项时填充
+	Cycle          string `json:"cycle,omitempty"`          // 主版本所属的发布周期，例如 "1.5"
+	EOL            bool   `json:"eol,omitempty"`            // 所属周期是否已停止支持
+	SupportedUntil string `json:"supportedUntil,omitempty"` // 所属周期的支持截止日期（YYYY-MM-DD）
+	LatestInCycle  string `json:"latestInCycle,omitempty"`  // 所属周期已知的最新版本
+}
+
+// EndOfLifeItems 返回已停止支持的框架和组件，框架在前
+func (info *DetectionInfo) EndOfLifeItems() []DetectedItem {
+	var items []DetectedItem
+	for _, list := range [][]DetectedItem{info.Frameworks, info.Components} {
+		for _, item := range list {
+			if item.EOL {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// AllVersions 返回检测到的所有不同版本号，主版本在前
+func (item *DetectedItem) AllVersions() []string {
+	var versions []string
+	for _, info := range item.Versions {
+		// 同一版本可能以不同来源（范围、锁文件中的实际版本）出现多次
+		if info != nil && info.Version != "" && !slices.Contains(versions, info.Version) {
+			versions = append(versions, info.Version)
+		}
+	}
+	if len(versions) == 0 && item.Version != "" {
+		versions = append(versions, item.Version)
+	}
+	return versions
 }
@@ -0,0 +1,227 @@
+package camodels
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 版本变化方向
+const (
+	VersionUpgrade   = "upgrade"
+	VersionDowngrade = "downgrade"
+	VersionChanged   = "changed" // 无法比较大小，或只有一侧有版本
+)
+
+// DiffOptions 报告对比参数
+type DiffOptions struct {
+	MinLineChange  int     // 语言代码行数变化的最小绝对值
+	MinLinePercent float64 // 语言代码行数变化的最小百分比（相对旧报告）
+}
+
+// DefaultDiffOptions 默认对比参数：代码行数变化至少 100 行且至少 10% 才视为显著变化
+func DefaultDiffOptions() DiffOptions {
+	return DiffOptions{MinLineChange: 100, MinLinePercent: 10}
+}
+
+// ReportDiff 两次分析结果的差异
+type ReportDiff struct {
+	Old              DiffSide         `json:"old"`
+	New              DiffSide         `json:"new"`
+	AddedLanguages   []string         `json:"addedLanguages"`
+	RemovedLanguages []string         `json:"removedLanguages"`
+	LanguageChanges  []LanguageChange `json:"languageChanges"` // 两侧都存在且代码行数变化显著的语言
+	Frameworks       ItemsDiff        `json:"frameworks"`
+	Components       ItemsDiff        `json:"components"`
+}
+
+// DiffSide 参与对比的报告概要
+type DiffSide struct {
+	Path       string    `json:"path"`
+	Version    string    `json:"version,omitempty"` // 生成报告的 xcanvas 版本
+	Timestamp  time.Time `json:"timestamp"`
+	TotalFiles int       `json:"totalFiles"`
+	TotalLines int       `json:"totalLines"`
+}
+
+// ItemsDiff 框架或组件列表的差异
+type ItemsDiff struct {
+	Added   []DetectedItem  `json:"added"`
+	Removed []DetectedItem  `json:"removed"`
+	Changed []VersionChange `json:"changed"` // 主版本发生变化的检测项
+}
+
+// VersionChange 检测项主版本的变化
+type VersionChange struct {
+	Name       string `json:"name"`
+	Language   string `json:"language"`
+	OldVersion string `json:"oldVersion"`
+	NewVersion string `json:"newVersion"`
+	Direction  string `json:"direction"` // upgrade | downgrade | changed
+}
+
+// LanguageChange 语言统计数据的变化
+type LanguageChange struct {
+	Name          string  `json:"name"`
+	OldFiles      int     `json:"oldFiles"`
+	NewFiles      int     `json:"newFiles"`
+	OldCodeLines  int     `json:"oldCodeLines"`
+	NewCodeLines  int     `json:"newCodeLines"`
+	CodeLineDelta int     `json:"codeLineDelta"`
+	Percent       float64 `json:"percent"` // 代码行数变化百分比，旧报告没有代码行时为 100
+}
+
+// IsEmpty 判断两次分析结果是否没有差异
+func (diff *ReportDiff) IsEmpty() bool {
+	return len(diff.AddedLanguages) == 0 && len(diff.RemovedLanguages) == 0 && len(diff.LanguageChanges) == 0 &&
+		diff.Frameworks.IsEmpty() && diff.Components.IsEmpty()
+}
+
+// IsEmpty 判断框架或组件列表是否没有差异
+func (diff *ItemsDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// DiffReports 对比两次分析结果：语言按 LanguageInfos（及 Languages）中的名称比较，
+// 框架和组件按名称和语言（不区分大小写）匹配后比较主版本，结果按名称排序，没有差异的列表为空数组而不是 nil
+func DiffReports(oldReport, newReport *CanvasReport, opts DiffOptions) *ReportDiff {
+	diff := &ReportDiff{
+		Old:              diffSide(oldReport),
+		New:              diffSide(newReport),
+		AddedLanguages:   []string{},
+		RemovedLanguages: []string{},
+		LanguageChanges:  []LanguageChange{},
+	}
+
+	oldLanguages, newLanguages := languageStats(oldReport), languageStats(newReport)
+	for key, info := range newLanguages {
+		if _, ok := oldLanguages[key]; !ok {
+			diff.AddedLanguages = append(diff.AddedLanguages, info.Name)
+		}
+	}
+	for key, oldInfo := range oldLanguages {
+		newInfo, ok := newLanguages[key]
+		if !ok {
+			diff.RemovedLanguages = append(diff.RemovedLanguages, oldInfo.Name)
+			continue
+		}
+		if change, significant := languageChange(oldInfo, newInfo, opts); significant {
+			diff.LanguageChanges = append(diff.LanguageChanges, change)
+		}
+	}
+	sort.Strings(diff.AddedLanguages)
+	sort.Strings(diff.RemovedLanguages)
+	sort.Slice(diff.LanguageChanges, func(i, j int) bool {
+		return diff.LanguageChanges[i].Name < diff.LanguageChanges[j].Name
+	})
+
+	diff.Frameworks = diffItems(oldReport.Detection.Frameworks, newReport.Detection.Frameworks)
+	diff.Components = diffItems(oldReport.Detection.Components, newReport.Detection.Components)
+	return diff
+}
+
+func diffSide(report *CanvasReport) DiffSide {
+	return DiffSide{
+		Path:       report.CodeProfile.Path,
+		Version:    report.Version,
+		Timestamp:  report.Timestamp,
+		TotalFiles: report.CodeProfile.TotalFiles,
+		TotalLines: report.CodeProfile.TotalLines,
+	}
+}
+
+// languageStats 返回报告中的语言统计，键为小写语言名；只出现在 Languages 中的语言统计数据为空
+func languageStats(report *CanvasReport) map[string]LangInfo {
+	stats := make(map[string]LangInfo)
+	for _, info := range report.CodeProfile.LanguageInfos {
+		stats[strings.ToLower(info.Name)] = info
+	}
+	for _, name := range report.CodeProfile.Languages {
+		if _, ok := stats[strings.ToLower(name)]; !ok {
+			stats[strings.ToLower(name)] = LangInfo{Name: name}
+		}
+	}
+	return stats
+}
+
+// languageChange 计算语言代码行数的变化，变化同时达到绝对值和百分比阈值时视为显著
+func languageChange(oldInfo, newInfo LangInfo, opts DiffOptions) (LanguageChange, bool) {
+	change := LanguageChange{
+		Name:          newInfo.Name,
+		OldFiles:      oldInfo.Files,
+		NewFiles:      newInfo.Files,
+		OldCodeLines:  oldInfo.CodeLines,
+		NewCodeLines:  newInfo.CodeLines,
+		CodeLineDelta: newInfo.CodeLines - oldInfo.CodeLines,
+	}
+	if change.CodeLineDelta == 0 {
+		return change, false
+	}
+	if oldInfo.CodeLines == 0 {
+		change.Percent = 100
+	} else {
+		change.Percent = math.Round(float64(change.CodeLineDelta)/float64(oldInfo.CodeLines)*1000) / 10
+	}
+	delta := change.CodeLineDelta
+	if delta < 0 {
+		delta = -delta
+	}
+	return change, delta >= opts.MinLineChange && math.Abs(change.Percent) >= opts.MinLinePercent
+}
+
+// diffItems 对比两组检测项
+func diffItems(oldList, newList []DetectedItem) ItemsDiff {
+	diff := ItemsDiff{Added: []DetectedItem{}, Removed: []DetectedItem{}, Changed: []VersionChange{}}
+	oldItems := make(map[string]DetectedItem, len(oldList))
+	for _, item := range oldList {
+		oldItems[itemKey(item)] = item
+	}
+	newKeys := make(map[string]bool, len(newList))
+	for _, item := range newList {
+		key := itemKey(item)
+		newKeys[key] = true
+		oldItem, ok := oldItems[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, item)
+		case oldItem.Version != item.Version:
+			diff.Changed = append(diff.Changed, VersionChange{
+				Name:       item.Name,
+				Language:   item.Language,
+				OldVersion: oldItem.Version,
+				NewVersion: item.Version,
+				Direction:  versionDirection(oldItem.Version, item.Version, item.Language),
+			})
+		}
+	}
+	for _, item := range oldList {
+		if !newKeys[itemKey(item)] {
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
+}
+
+func itemKey(item DetectedItem) string {
+	return strings.ToLower(item.Name) + "\x00" + strings.ToLower(item.Language)
+}
+
+// versionDirection 按检测项语言对应生态的排序规则判断版本变化方向
+func versionDirection(oldVersion, newVersion, language string) string {
+	if oldVersion == "" || newVersion == "" {
+		return VersionChanged
+	}
+	scheme := SchemeForEcosystem(EcosystemForLanguage(language))
+	c, err := CompareVersions(strings.TrimPrefix(newVersion, "v"), strings.TrimPrefix(oldVersion, "v"), scheme)
+	switch {
+	case err != nil || c == 0:
+		return VersionChanged
+	case c > 0:
+		return VersionUpgrade
+	}
+	return VersionDowngrade
+}
@@ -0,0 +1,47 @@
+package camodels
+
+import "strings"
+
+// 依赖生态（包管理器），决定版本排序方式和版本范围语法
+const (
+	EcosystemNPM      = "npm"
+	EcosystemMaven    = "maven"
+	EcosystemPyPI     = "pypi"
+	EcosystemComposer = "composer"
+	EcosystemGo       = "golang"
+)
+
+// 版本排序方案
+const (
+	VersionSchemeSemver = "semver"
+	VersionSchemeMaven  = "maven"
+	VersionSchemePEP440 = "pep440"
+)
+
+// EcosystemForLanguage 根据规则语言推断依赖生态，无法推断时返回空字符串
+func EcosystemForLanguage(language string) string {
+	switch strings.ToLower(language) {
+	case "javascript", "typescript", "node.js", "jsx", "tsx", "vue":
+		return EcosystemNPM
+	case "java", "kotlin", "groovy", "scala":
+		return EcosystemMaven
+	case "python":
+		return EcosystemPyPI
+	case "php":
+		return EcosystemComposer
+	case "go":
+		return EcosystemGo
+	}
+	return ""
+}
+
+// SchemeForEcosystem 返回依赖生态使用的版本排序方案，未知生态使用 semver
+func SchemeForEcosystem(ecosystem string) string {
+	switch strings.ToLower(ecosystem) {
+	case EcosystemMaven:
+		return VersionSchemeMaven
+	case EcosystemPyPI:
+		return VersionSchemePEP440
+	}
+	return VersionSchemeSemver
+}
@@ -1,6 +1,9 @@
 package camodels
 
-import "strings"
+import (
+	"io/fs"
+	"strings"
+)
 
 // FileIndex 存储代码库的文件索引结构，用于加速查找。
 type FileIndex struct {
@@ -12,6 +15,10 @@ type FileIndex struct {
 	NameMap map[string][]int
 	// ExtensionMap 映射文件扩展名到 Files 切片中的索引列表 (例如: ".go" -> [1, 2, 3])
 	ExtensionMap map[string][]int
+	// Vendored 记录第三方（vendored）文件的相对路径，未记录的文件为项目自身代码
+	Vendored map[string]bool
+	// FS 文件内容来源，按 Files 中的相对路径读取；为 nil 时从 RootDir 所在的磁盘读取（例如分析 git 修订版本时为 git 对象）
+	FS fs.FS
 }
 
 // NewFileIndex 创建一个新的空索引
@@ -21,6 +28,7 @@ func NewFileIndex(rootDir string) *FileIndex {
 		Files:        make([]string, 0),
 		NameMap:      make(map[string][]int),
 		ExtensionMap: make(map[string][]int),
+		Vendored:     make(map[string]bool),
 	}
 }
 
@@ -33,3 +41,16 @@ func (fi *FileIndex) AddFile(relPath string, fileName string, ext string) {
 	fi.NameMap[strings.ToLower(fileName)] = append(fi.NameMap[strings.ToLower(fileName)], idx)
 	fi.ExtensionMap[strings.ToLower(ext)] = append(fi.ExtensionMap[strings.ToLower(ext)], idx)
 }
+
+// MarkVendored 将已添加的文件标记为第三方（vendored）代码
+func (fi *FileIndex) MarkVendored(relPath string) {
+	if fi.Vendored == nil {
+		fi.Vendored = make(map[string]bool)
+	}
+	fi.Vendored[relPath] = true
+}
+
+// IsVendored 判断文件是否为第三方（vendored）代码
+func (fi *FileIndex) IsVendored(relPath string) bool {
+	return fi.Vendored[relPath]
+}
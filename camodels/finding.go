@@ -0,0 +1,64 @@
+package camodels
+
+import "sort"
+
+// 漏洞严重程度，按从高到低排列
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+// Finding 依赖或检测项的版本命中安全公告（OSV 格式）的结果
+type Finding struct {
+	ID            string   `json:"id"`                      // 公告编号，例如 "GHSA-jfh8-c2jp-5v3q"
+	Aliases       []string `json:"aliases,omitempty"`       // 公告别名，例如 CVE 编号
+	Summary       string   `json:"summary,omitempty"`       // 公告摘要
+	Severity      string   `json:"severity"`                // 严重程度：CRITICAL | HIGH | MEDIUM | LOW | UNKNOWN
+	Score         float64  `json:"score,omitempty"`         // CVSS v3 基础分，公告未提供 CVSS v3 向量时为 0
+	Ecosystem     string   `json:"ecosystem"`               // 依赖生态，例如 "maven"
+	Package       string   `json:"package"`                 // 依赖名称，与依赖清单一致
+	Version       string   `json:"version"`                 // 命中的版本
+	Source        string   `json:"source,omitempty"`        // 版本来源：pinned | resolved，只知道声明范围（range）的版本不参与匹配
+	FixedVersions []string `json:"fixedVersions,omitempty"` // 修复版本
+	Detected      string   `json:"detected,omitempty"`      // 对应的规则检测项名称，只在依赖清单中出现时为空
+	Files         []string `json:"files,omitempty"`         // 声明或检测到该版本的文件
+	References    []string `json:"references,omitempty"`    // 公告参考链接
+}
+
+// SeverityRank 返回严重程度的排序权重，越严重值越大，未知为 0
+func SeverityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	}
+	return 0
+}
+
+// SortFindings 按严重程度从高到低排序，其次按生态、名称、版本和公告编号，保证输出顺序稳定
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if ra, rb := SeverityRank(a.Severity), SeverityRank(b.Severity); ra != rb {
+			return ra > rb
+		}
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.ID < b.ID
+	})
+}
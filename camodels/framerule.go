@@ -1,5 +1,7 @@
 package camodels
 
+import "strings"
+
 // FrameRule 匹配组件/框架的信息 判断组件或框架是否存在
 type FrameRule struct {
 	// Paths: 必须存在的路径（文件或目录），全部都要存在
@@ -8,22 +10,85 @@ type FrameRule struct {
 	// FileContents: 文件路径 -> 必须包含的关键字列表
 	// 每个文件必须存在，且内容包含所有对应的关键字
 	FileContents map[string][]string `yaml:"file_contents,omitempty"`
+
+	// MinFiles: 每个 FileContents 条件至少需要多少个文件同时包含全部关键字，0 视为 1
+	MinFiles int `yaml:"min_files,omitempty"`
+
+	// MinMatches: 每个 FileContents 条件在命中文件中关键字出现的最少总次数，0 视为 1
+	MinMatches int `yaml:"min_matches,omitempty"`
+
+	// Dependencies: 依赖清单（pom.xml 等）中必须声明的依赖，全部都要存在
+	// Maven 依赖名称为 groupId:artifactId，支持通配符，例如 "org.springframework:spring-*"
+	Dependencies []string `yaml:"dependencies,omitempty"`
+
+	// Vendored: 为 true 时 Paths 和 FileContents 也匹配第三方（vendored）文件，
+	// 例如通过复制的 angular.min.js 或 vendor/ 目录检测组件；默认只匹配项目自身的文件
+	Vendored bool `yaml:"vendored,omitempty"`
 }
 
+// 版本转换方式
+const (
+	// TransformStripV 去除版本号的 v/V 前缀，例如 "v1.2.3" -> "1.2.3"
+	TransformStripV = "strip_v"
+	// TransformProperty 将 ${name}、@name@ 形式的属性占位符替换为同一文件中定义的属性值
+	TransformProperty = "property"
+	// TransformLowerBound 从版本范围中取第一个版本号，例如 ">=2.0,<3" -> "2.0"
+	TransformLowerBound = "lower_bound"
+)
+
 // VersionExtractor 表示一条完整的版本提取规则
 type VersionExtractor struct {
 	// FilePattern: 匹配的文件模式
 	FilePattern string `yaml:"file_pattern"` // 匹配的文件模式
 	// Patterns: 版本提取正则表达式列表
+	// 优先使用命名捕获组 (?P<version>...)，否则使用第一个捕获组；可选命名捕获组 (?P<name>...) 记录匹配的名称
 	Patterns []string `yaml:"patterns"` // 版本提取正则表达式列表
+	// Transforms: 版本后处理方式，按顺序执行（strip_v / property / lower_bound），之后统一去除范围前缀和构建元数据
+	Transforms []string `yaml:"transforms,omitempty"`
+	// Source: 版本来源，resolved 表示实际安装版本；为空时根据原始值自动判断 range 或 pinned
+	Source string `yaml:"source,omitempty"`
+	// Dependency: 从解析后的依赖清单中按依赖名称（支持通配符）取版本，已解析属性、父 POM 和依赖管理
+	// 设置后忽略 FilePattern 和 Patterns
+	Dependency string `yaml:"dependency,omitempty"`
+	// Vendored: 为 true 时也从第三方（vendored）文件中提取版本；默认只使用项目自身的文件
+	Vendored bool `yaml:"vendored,omitempty"`
+	// Multiple: 为 true 时收集文件中的所有匹配（例如一个文件声明多个模块的版本）；默认只使用第一个匹配，
+	// 避免宽松的正则把文件中无关的版本号当作不同版本
+	Multiple bool `yaml:"multiple,omitempty"`
 }
 
 // Framework 内部规则模型（对应 YAML 规则文件）定义了如何检测框架或组件。在启动时从 YAML 规则文件中加载。
 type Framework struct {
-	Name     string             `yaml:"name"`
-	Type     string             `yaml:"type"` // "framework" or "component"
-	Language string             `yaml:"language"`
-	Category string             `yaml:"category"` // 针对框架: "frontend"/"backend"; 针对组件: "frontend"/"backend"
-	Rules    []FrameRule        `yaml:"rules"`    // 多条规则，OR 关系
-	Versions []VersionExtractor `yaml:"version"`  // 多条版本提取表达式，OR 关系
+	Name      string             `yaml:"name"`
+	Type      string             `yaml:"type"`                // "framework" or "component"
+	Language  string             `yaml:"language"`            // 主语言，"any" 表示与语言无关
+	Languages []string           `yaml:"languages,omitempty"` // 适用的语言列表，与 Language 合并，任一语言存在即适用
+	Category  string             `yaml:"category"`            // 针对框架: "frontend"/"backend"; 针对组件: "frontend"/"backend"
+	Rules     []FrameRule        `yaml:"rules"`               // 多条规则，OR 关系
+	Versions  []VersionExtractor `yaml:"version"`             // 多条版本提取表达式，OR 关系
+}
+
+// AllLanguages 返回规则适用的全部语言（Language 在前，去重）
+func (f *Framework) AllLanguages() []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, lang := range append([]string{f.Language}, f.Languages...) {
+		key := strings.ToLower(lang)
+		if lang == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, lang)
+	}
+	return result
+}
+
+// IsLanguageAgnostic 判断规则是否与语言无关（language: any）
+func (f *Framework) IsLanguageAgnostic() bool {
+	for _, lang := range f.AllLanguages() {
+		if strings.EqualFold(lang, LanguageAny) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,168 @@
+package camodels
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// History 多个 git 修订版本的技术栈时间线
+type History struct {
+	Path       string            `json:"path"`
+	Snapshots  []HistorySnapshot `json:"snapshots"`  // 按提交时间从早到晚排列
+	Frameworks []ItemTimeline    `json:"frameworks"` // 按首次出现的先后排列
+	Components []ItemTimeline    `json:"components"`
+}
+
+// HistorySnapshot 单个修订版本的概要和语言占比
+type HistorySnapshot struct {
+	Revision   Revision        `json:"revision"`
+	TotalFiles int             `json:"totalFiles"`
+	TotalLines int             `json:"totalLines"`
+	Languages  []LanguageShare `json:"languages"` // 按代码行数从多到少排列
+}
+
+// LanguageShare 语言在修订版本中的代码行数和占比
+type LanguageShare struct {
+	Name      string  `json:"name"`
+	Files     int     `json:"files"`
+	CodeLines int     `json:"codeLines"`
+	Percent   float64 `json:"percent"` // 占全部代码行数的百分比，保留一位小数
+}
+
+// ItemTimeline 单个框架或组件在各修订版本中的出现情况
+type ItemTimeline struct {
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	Language  string         `json:"language"`
+	FirstSeen Revision       `json:"firstSeen"`
+	LastSeen  Revision       `json:"lastSeen"`
+	Present   bool           `json:"present"`  // 最新的修订版本中是否仍然存在
+	Versions  []VersionEvent `json:"versions"` // 首次出现时的版本和之后的每次版本变化
+}
+
+// VersionEvent 检测项在某个修订版本中的版本
+type VersionEvent struct {
+	Revision  Revision `json:"revision"`
+	Version   string   `json:"version"`
+	Direction string   `json:"direction,omitempty"` // 相对上一次版本的变化：upgrade | downgrade | changed，首次出现时为空
+}
+
+// BuildHistory 由各修订版本的报告（需要包含 Revision）构建时间线，报告按提交时间排序后处理。
+// 框架和组件按名称和语言（不区分大小写）匹配，版本变化方向按检测项语言对应生态的排序规则判断
+func BuildHistory(path string, reports []*CanvasReport) *History {
+	reports = append([]*CanvasReport(nil), reports...)
+	sort.SliceStable(reports, func(i, j int) bool {
+		return revisionOf(reports[i]).CommitTime.Before(revisionOf(reports[j]).CommitTime)
+	})
+
+	history := &History{
+		Path:       path,
+		Snapshots:  make([]HistorySnapshot, 0, len(reports)),
+		Frameworks: []ItemTimeline{},
+		Components: []ItemTimeline{},
+	}
+	frameworks, components := newTimelineBuilder(), newTimelineBuilder()
+	for _, report := range reports {
+		revision := revisionOf(report)
+		history.Snapshots = append(history.Snapshots, HistorySnapshot{
+			Revision:   revision,
+			TotalFiles: report.CodeProfile.TotalFiles,
+			TotalLines: report.CodeProfile.TotalLines,
+			Languages:  languageShares(report.CodeProfile.LanguageInfos),
+		})
+		frameworks.add(revision, report.Detection.Frameworks)
+		components.add(revision, report.Detection.Components)
+	}
+	if len(reports) > 0 {
+		latest := revisionOf(reports[len(reports)-1]).Commit
+		history.Frameworks = frameworks.timelines(latest)
+		history.Components = components.timelines(latest)
+	}
+	return history
+}
+
+func revisionOf(report *CanvasReport) Revision {
+	if report.Revision == nil {
+		return Revision{}
+	}
+	return *report.Revision
+}
+
+// languageShares 计算各语言代码行数的占比
+func languageShares(infos []LangInfo) []LanguageShare {
+	total := 0
+	for _, info := range infos {
+		total += info.CodeLines
+	}
+	shares := make([]LanguageShare, 0, len(infos))
+	for _, info := range infos {
+		share := LanguageShare{Name: info.Name, Files: info.Files, CodeLines: info.CodeLines}
+		if total > 0 {
+			share.Percent = math.Round(float64(info.CodeLines)/float64(total)*1000) / 10
+		}
+		shares = append(shares, share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].CodeLines != shares[j].CodeLines {
+			return shares[i].CodeLines > shares[j].CodeLines
+		}
+		return shares[i].Name < shares[j].Name
+	})
+	return shares
+}
+
+// timelineBuilder 按修订版本顺序累积检测项的时间线
+type timelineBuilder struct {
+	order []string
+	items map[string]*ItemTimeline
+}
+
+func newTimelineBuilder() *timelineBuilder {
+	return &timelineBuilder{items: make(map[string]*ItemTimeline)}
+}
+
+func (b *timelineBuilder) add(revision Revision, items []DetectedItem) {
+	for _, item := range items {
+		key := itemKey(item)
+		timeline, ok := b.items[key]
+		if !ok {
+			timeline = &ItemTimeline{
+				Name:      item.Name,
+				Type:      item.Type,
+				Language:  item.Language,
+				FirstSeen: revision,
+				Versions:  []VersionEvent{},
+			}
+			b.items[key] = timeline
+			b.order = append(b.order, key)
+			if item.Version != "" {
+				timeline.Versions = append(timeline.Versions, VersionEvent{Revision: revision, Version: item.Version})
+			}
+		} else if item.Version != "" {
+			previous := ""
+			if n := len(timeline.Versions); n > 0 {
+				previous = timeline.Versions[n-1].Version
+			}
+			if item.Version != previous {
+				event := VersionEvent{Revision: revision, Version: item.Version}
+				if previous != "" {
+					event.Direction = versionDirection(previous, item.Version, item.Language)
+				}
+				timeline.Versions = append(timeline.Versions, event)
+			}
+		}
+		timeline.LastSeen = revision
+	}
+}
+
+// timelines 返回按首次出现顺序排列的时间线，latest 为最新修订版本的提交哈希
+func (b *timelineBuilder) timelines(latest string) []ItemTimeline {
+	timelines := make([]ItemTimeline, 0, len(b.order))
+	for _, key := range b.order {
+		timeline := *b.items[key]
+		timeline.Present = strings.EqualFold(timeline.LastSeen.Commit, latest)
+		timelines = append(timelines, timeline)
+	}
+	return timelines
+}
@@ -17,3 +17,10 @@ type LangSummary struct {
 	Blank   int64
 	Count   int64
 }
+
+// CodeStats 一组不计入主统计的文件（第三方代码、生成代码、压缩代码）的统计数据
+type CodeStats struct {
+	TotalFiles    int        `json:"totalFiles"`
+	TotalLines    int        `json:"totalLines"`
+	LanguageInfos []LangInfo `json:"languageInfos"`
+}
@@ -18,12 +18,14 @@ type DynamicCategory struct {
 // - Filenames: 特定文件名
 // - Category: 默认分类（frontend/backend/desktop/other）
 // - Dynamic: 动态分类规则列表
+// - Implies: 隐含语言列表（如 TypeScript 隐含 JavaScript），用于扩展规则的适用语言
 type Language struct {
 	Name         string            `json:"name"`
-	LineComments []string          `json:"lineComments"`
-	MultiLine    [][]string        `json:"multiLine"`
+	LineComments []string          `yaml:"line_comments" json:"lineComments"`
+	MultiLine    [][]string        `yaml:"multi_line" json:"multiLine"`
 	Extensions   []string          `json:"extensions"`
 	Filenames    []string          `json:"filenames"`
 	Category     string            `json:"category"`
 	Dynamic      []DynamicCategory `json:"dynamic"`
+	Implies      []string          `json:"implies"`
 }
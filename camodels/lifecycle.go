@@ -0,0 +1,14 @@
+package camodels
+
+// Lifecycle 技术项（按规则名称）的生命周期数据，包含各发布周期的停止支持日期
+type Lifecycle struct {
+	Name   string         `yaml:"name" json:"name"`     // 规则名称，不区分大小写，例如 "Spring Boot"
+	Cycles []ReleaseCycle `yaml:"cycles" json:"cycles"` // 发布周期
+}
+
+// ReleaseCycle 一个发布周期
+type ReleaseCycle struct {
+	Cycle  string `yaml:"cycle" json:"cycle"`                       // 周期版本前缀（主版本或主次版本），例如 "1"、"2.7"
+	EOL    string `yaml:"eol" json:"eol,omitempty"`                 // 停止支持（含安全更新）的日期 YYYY-MM-DD；"true" 表示已停止支持但日期未知，为空或 "false" 表示仍在支持
+	Latest string `yaml:"latest,omitempty" json:"latest,omitempty"` // 该周期已知的最新版本
+}
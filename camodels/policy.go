@@ -0,0 +1,39 @@
+package camodels
+
+// 策略规则动作
+const (
+	PolicyActionDeny = "deny"
+	PolicyActionWarn = "warn"
+)
+
+// Policy 策略文件，按顺序评估全部规则
+type Policy struct {
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule 单条策略规则，设置的条件之间为 AND 关系。
+// 设置了 name/type/category/version/eol 中任一条件时对检测到的框架和组件逐项评估（language 限定检测项语言）；
+// 只设置 language 时对项目中检测到的语言评估
+type PolicyRule struct {
+	ID       string `yaml:"id"`                 // 规则标识，为空时使用规则序号
+	Action   string `yaml:"action"`             // deny | warn，默认 deny
+	Message  string `yaml:"message,omitempty"`  // 违规时输出的说明
+	Name     string `yaml:"name,omitempty"`     // 检测项名称，支持通配符，不区分大小写，例如 "fastjson"、"Apache *"
+	Type     string `yaml:"type,omitempty"`     // framework | component
+	Language string `yaml:"language,omitempty"` // 语言，不区分大小写
+	Category string `yaml:"category,omitempty"` // frontend | backend | desktop | other
+	Version  string `yaml:"version,omitempty"`  // 版本范围，使用 npm 风格语法（例如 "<1.2.83"、">=2.0.0 <2.17.0"），按检测项生态的排序规则比较
+	EOL      *bool  `yaml:"eol,omitempty"`      // 是否已停止支持
+}
+
+// Violation 违反策略规则的结果
+type Violation struct {
+	RuleID   string `json:"ruleId"`             // 规则标识
+	Action   string `json:"action"`             // deny | warn
+	Message  string `json:"message,omitempty"`  // 规则说明
+	Target   string `json:"target"`             // 违规的检测项名称或语言
+	Type     string `json:"type"`               // framework | component | language
+	Version  string `json:"version,omitempty"`  // 命中的版本
+	Language string `json:"language,omitempty"` // 检测项语言
+	Category string `json:"category,omitempty"` // 检测项分类
+}
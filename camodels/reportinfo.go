@@ -1,6 +1,7 @@
 package camodels
 
 import (
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -19,14 +20,28 @@ type CodeProfile struct {
 	OtherLanguages    []string   `json:"otherLanguages"`    // 例如: ["JSON", "YAML"]
 	Languages         []string   `json:"languages"`
 	Expands           []string   `json:"expands"`
+	Vendored          *CodeStats `json:"vendored,omitempty"`  // 第三方（vendored）文件的统计，不计入上面的总数和语言统计
+	Generated         *CodeStats `json:"generated,omitempty"` // 生成代码（protobuf、代码生成器输出、锁文件等）的统计，不计入上面的总数和语言统计
+	Minified          *CodeStats `json:"minified,omitempty"`  // 压缩代码的统计，不计入上面的总数和语言统计
 }
 
 // CanvasReport 最终分析报告
 type CanvasReport struct {
-	CodeProfile CodeProfile   `json:"codeProfile"`
-	Detection   DetectionInfo `json:"detection"`
-	Timestamp   time.Time     `json:"timestamp"`
-	Version     string        `json:"version"`
+	CodeProfile  CodeProfile   `json:"codeProfile"`
+	Detection    DetectionInfo `json:"detection"`
+	Dependencies []Dependency  `json:"dependencies"`         // 依赖清单：所有支持的清单文件中声明的依赖，与规则检测结果无关
+	Findings     []Finding     `json:"findings,omitempty"`   // 安全公告匹配结果，只在指定本地公告库时生成
+	Violations   []Violation   `json:"violations,omitempty"` // 策略违规结果，只在指定策略文件时生成
+	Revision     *Revision     `json:"revision,omitempty"`   // 分析的 git 修订版本，只在分析 git 修订版本时生成
+	Timestamp    time.Time     `json:"timestamp"`
+	Version      string        `json:"version"`
+}
+
+// Revision 分析的 git 修订版本
+type Revision struct {
+	Ref        string    `json:"ref"`        // 指定的引用，例如 "v1.2.0"、"HEAD~3"
+	Commit     string    `json:"commit"`     // 完整的提交哈希
+	CommitTime time.Time `json:"commitTime"` // 提交时间
 }
 
 // CanvasSimple 包含了 CodeCanvas 分析的完整结果
@@ -47,10 +62,10 @@ type CanvasSimple struct {
 	MainBackendLanguages []string `json:"mainBackendLanguages"`
 	// 主要前端语言列表 (Top 3)
 	MainFrontendLanguages []string `json:"mainFrontendLanguages"`
-	// 框架信息列表，名称到版本的映射
-	Frameworks map[string]string `json:"frameworks"`
-	// 组件信息列表，名称到版本的映射
-	Components map[string]string `json:"components"`
+	// 框架信息列表，名称到所有检测到的版本的映射
+	Frameworks map[string][]string `json:"frameworks"`
+	// 组件信息列表，名称到所有检测到的版本的映射
+	Components map[string][]string `json:"components"`
 	// 记录分析文件数量
 	TotalFiles int `json:"totalFiles"`
 }
@@ -83,21 +98,26 @@ func languageInfosToMap(languageInfos []LangInfo) map[string]LangInfo {
 	return langStats
 }
 
-// getItemsWithVersions 提取去重后的 items (组件名或者框架名)及其版本，返回名称到版本的映射
-func getItemsWithVersions(items []DetectedItem) map[string]string {
-	result := make(map[string]string)
+// getItemsWithVersions 提取去重后的 items (组件名或者框架名)及其版本，返回名称到版本列表的映射
+// 同名项的所有不同版本都会保留，没有版本时为空列表
+func getItemsWithVersions(items []DetectedItem) map[string][]string {
+	result := make(map[string][]string)
 
 	for _, item := range items {
 		name := item.Name
 		if name == "" {
 			continue // 跳过空名称
 		}
-		// 如果版本为空，使用空字符串
-		version := item.Version
-		// 如果已经存在，不覆盖，保留第一个匹配的版本
-		if _, exists := result[name]; !exists {
-			result[name] = version
+		versions, exists := result[name]
+		if !exists {
+			versions = []string{}
+		}
+		for _, version := range item.AllVersions() {
+			if !slices.Contains(versions, version) {
+				versions = append(versions, version)
+			}
 		}
+		result[name] = versions
 	}
 
 	return result
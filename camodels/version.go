@@ -0,0 +1,492 @@
+package camodels
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version 解析后的版本号模型，支持 semver、Maven 和 PEP 440 三种排序方案
+type Version struct {
+	Original  string `json:"original"`            // 原始版本字符串
+	Scheme    string `json:"scheme"`              // 排序方案：semver | maven | pep440
+	Epoch     int    `json:"epoch,omitempty"`     // PEP 440 epoch，例如 "1!2.0" 中的 1
+	Release   []int  `json:"release"`             // 数字版本段，例如 [1, 2, 83]
+	Qualifier string `json:"qualifier,omitempty"` // 预发布标识或限定符，例如 "rc.1"、"Final"、"b2.post1"
+	Build     string `json:"build,omitempty"`     // 构建元数据或本地版本标识，例如 "+build.5"、"+ubuntu1"
+}
+
+// ParseVersion 按指定排序方案解析版本字符串，无法解析时返回错误
+func ParseVersion(raw string, scheme string) (*Version, error) {
+	switch scheme {
+	case VersionSchemeMaven:
+		return parseMavenVersion(raw)
+	case VersionSchemePEP440:
+		return parsePEP440Version(raw)
+	default:
+		return parseSemver(raw)
+	}
+}
+
+// CompareVersions 按指定排序方案比较两个版本字符串，a < b 返回 -1，a == b 返回 0，a > b 返回 1
+func CompareVersions(a, b string, scheme string) (int, error) {
+	switch scheme {
+	case VersionSchemeMaven:
+		return compareMaven(a, b)
+	case VersionSchemePEP440:
+		va, err := parsePEP440Key(a)
+		if err != nil {
+			return 0, err
+		}
+		vb, err := parsePEP440Key(b)
+		if err != nil {
+			return 0, err
+		}
+		return va.compare(vb), nil
+	default:
+		va, err := parseSemver(a)
+		if err != nil {
+			return 0, err
+		}
+		vb, err := parseSemver(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareSemver(va, vb), nil
+	}
+}
+
+// Compare 使用当前版本的排序方案与另一个版本比较，无法比较时返回 0
+func (v *Version) Compare(other *Version) int {
+	result, _ := CompareVersions(v.Original, other.Original, v.Scheme)
+	return result
+}
+
+// Major 返回主版本号
+func (v *Version) Major() int { return v.segment(0) }
+
+// Minor 返回次版本号
+func (v *Version) Minor() int { return v.segment(1) }
+
+// Patch 返回修订号
+func (v *Version) Patch() int { return v.segment(2) }
+
+// IsPrerelease 判断是否为预发布版本（alpha、beta、rc、snapshot、dev 等）
+func (v *Version) IsPrerelease() bool {
+	switch v.Scheme {
+	case VersionSchemeMaven:
+		return mavenQualifierRank(strings.ToLower(v.Qualifier)) < mavenQualifierRank("")
+	case VersionSchemePEP440:
+		key, err := parsePEP440Key(v.Original)
+		return err == nil && (key.preRank < pep440FinalRank || key.dev >= 0)
+	default:
+		return v.Qualifier != ""
+	}
+}
+
+func (v *Version) segment(i int) int {
+	if i < len(v.Release) {
+		return v.Release[i]
+	}
+	return 0
+}
+
+// cleanVersion 去除版本字符串两端空白、前导 = 和 v 前缀
+func cleanVersion(raw string) string {
+	version := strings.TrimSpace(raw)
+	version = strings.TrimSpace(strings.TrimLeft(version, "="))
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && isDigit(version[1]) {
+		version = version[1:]
+	}
+	return version
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// splitRelease 解析开头以点分隔的数字版本段，返回数字段和剩余部分
+func splitRelease(version string) ([]int, string) {
+	var release []int
+	for version != "" {
+		end := 0
+		for end < len(version) && isDigit(version[end]) {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, err := strconv.Atoi(version[:end])
+		if err != nil {
+			break
+		}
+		release = append(release, n)
+		version = version[end:]
+		if len(version) > 1 && version[0] == '.' && isDigit(version[1]) {
+			version = version[1:]
+			continue
+		}
+		break
+	}
+	return release, version
+}
+
+// --- semver ---
+
+// parseSemver 宽松解析 semver：允许任意数量的数字段，数字段之后的内容作为预发布标识
+func parseSemver(raw string) (*Version, error) {
+	version := &Version{Original: raw, Scheme: VersionSchemeSemver}
+	cleaned := cleanVersion(raw)
+	if idx := strings.Index(cleaned, "+"); idx >= 0 {
+		version.Build = cleaned[idx+1:]
+		cleaned = cleaned[:idx]
+	}
+	release, rest := splitRelease(cleaned)
+	if len(release) == 0 {
+		return nil, fmt.Errorf("invalid semver version: %q", raw)
+	}
+	version.Release = release
+	version.Qualifier = strings.TrimLeft(rest, "-.")
+	return version, nil
+}
+
+// compareSemver 按 semver 2.0 规则比较：先比较数字段，无预发布标识的版本大于有预发布标识的版本
+func compareSemver(a, b *Version) int {
+	if c := compareInts(a.Release, b.Release); c != 0 {
+		return c
+	}
+	switch {
+	case a.Qualifier == "" && b.Qualifier == "":
+		return 0
+	case a.Qualifier == "":
+		return 1
+	case b.Qualifier == "":
+		return -1
+	}
+
+	pa := strings.Split(a.Qualifier, ".")
+	pb := strings.Split(b.Qualifier, ".")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if c := compareInt(na, nb); c != 0 {
+				return c
+			}
+		case errA == nil:
+			return -1 // 数字标识小于字母标识
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(pa[i], pb[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(pa), len(pb))
+}
+
+// compareInts 逐段比较数字版本段，缺失的段视为 0
+func compareInts(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareInt(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// --- Maven ---
+
+// mavenItem Maven 版本中的单个数字或限定符片段
+type mavenItem struct {
+	isNum bool
+	num   int64
+	str   string
+}
+
+// mavenQualifiers 已知限定符的排序，未知限定符排在最后并按字典序比较
+var mavenQualifiers = map[string]int{"alpha": 0, "beta": 1, "milestone": 2, "rc": 3, "snapshot": 4, "": 5, "sp": 6}
+
+// mavenAliases 限定符别名
+var mavenAliases = map[string]string{"a": "alpha", "b": "beta", "m": "milestone", "cr": "rc", "ga": "", "final": "", "release": ""}
+
+func mavenQualifierRank(qualifier string) int {
+	if alias, ok := mavenAliases[qualifier]; ok {
+		qualifier = alias
+	}
+	if rank, ok := mavenQualifiers[qualifier]; ok {
+		return rank
+	}
+	return len(mavenQualifiers)
+}
+
+// parseMavenVersion 解析 Maven 版本，数字段之后的内容作为限定符，例如 "5.6.15.Final"
+func parseMavenVersion(raw string) (*Version, error) {
+	release, rest := splitRelease(cleanVersion(raw))
+	if len(release) == 0 {
+		return nil, fmt.Errorf("invalid maven version: %q", raw)
+	}
+	return &Version{
+		Original:  raw,
+		Scheme:    VersionSchemeMaven,
+		Release:   release,
+		Qualifier: strings.TrimLeft(rest, "-._"),
+	}, nil
+}
+
+// parseMavenItems 参照 Maven ComparableVersion 将版本拆分为数字和限定符片段，并去除末尾的 0 和空限定符
+func parseMavenItems(raw string) []mavenItem {
+	version := strings.ToLower(cleanVersion(raw))
+	var items []mavenItem
+	flush := func(token string) {
+		if token == "" {
+			return
+		}
+		if isDigit(token[0]) {
+			n, err := strconv.ParseInt(token, 10, 64)
+			if err == nil {
+				items = append(items, mavenItem{isNum: true, num: n})
+				return
+			}
+		}
+		if alias, ok := mavenAliases[token]; ok {
+			token = alias
+		}
+		items = append(items, mavenItem{str: token})
+	}
+
+	start := 0
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		if c == '.' || c == '-' || c == '_' {
+			flush(version[start:i])
+			start = i + 1
+			continue
+		}
+		// 数字和字母之间的转换也视为分隔
+		if i > start && isDigit(c) != isDigit(version[i-1]) {
+			flush(version[start:i])
+			start = i
+		}
+	}
+	flush(version[start:])
+
+	// 去除末尾的 0 和空限定符，使 "1.0.0" 与 "1" 相等
+	for len(items) > 0 {
+		last := items[len(items)-1]
+		if (last.isNum && last.num == 0) || (!last.isNum && last.str == "") {
+			items = items[:len(items)-1]
+			continue
+		}
+		break
+	}
+	return items
+}
+
+// compareMaven 按 Maven ComparableVersion 规则比较两个版本
+func compareMaven(a, b string) (int, error) {
+	if strings.TrimSpace(a) == "" || strings.TrimSpace(b) == "" {
+		return 0, fmt.Errorf("invalid maven version: %q vs %q", a, b)
+	}
+	ia, ib := parseMavenItems(a), parseMavenItems(b)
+	for i := 0; i < len(ia) || i < len(ib); i++ {
+		var c int
+		switch {
+		case i >= len(ia):
+			c = -compareMavenMissing(ib[i])
+		case i >= len(ib):
+			c = compareMavenMissing(ia[i])
+		default:
+			c = compareMavenItem(ia[i], ib[i])
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+// compareMavenMissing 比较存在的片段与缺失片段：数字与 0 比较，限定符与正式版本比较
+func compareMavenMissing(item mavenItem) int {
+	if item.isNum {
+		if item.num > 0 {
+			return 1
+		}
+		return 0
+	}
+	return compareInt(mavenQualifierRank(item.str), mavenQualifierRank(""))
+}
+
+func compareMavenItem(a, b mavenItem) int {
+	switch {
+	case a.isNum && b.isNum:
+		switch {
+		case a.num < b.num:
+			return -1
+		case a.num > b.num:
+			return 1
+		}
+		return 0
+	case a.isNum:
+		return 1 // 数字大于限定符
+	case b.isNum:
+		return -1
+	}
+	ra, rb := mavenQualifierRank(a.str), mavenQualifierRank(b.str)
+	if ra != rb {
+		return compareInt(ra, rb)
+	}
+	if ra == len(mavenQualifiers) {
+		return strings.Compare(a.str, b.str)
+	}
+	return 0
+}
+
+// --- PEP 440 ---
+
+var pep440Re = regexp.MustCompile(`(?i)^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440FinalRank 正式版本的预发布排序值（a=0, b=1, rc=2）
+const pep440FinalRank = 3
+
+// pep440Key PEP 440 版本的比较键
+type pep440Key struct {
+	epoch   int
+	release []int
+	preRank int // -1 仅有 dev 的版本；0 a；1 b；2 rc；3 正式版本
+	preNum  int
+	post    int // -1 表示无 post
+	dev     int // -1 表示无 dev
+	local   string
+}
+
+func parsePEP440Key(raw string) (*pep440Key, error) {
+	m := pep440Re.FindStringSubmatch(cleanVersion(raw))
+	if m == nil {
+		return nil, fmt.Errorf("invalid pep440 version: %q", raw)
+	}
+	key := &pep440Key{preRank: pep440FinalRank, post: -1, dev: -1, local: strings.ToLower(m[10])}
+	key.epoch, _ = strconv.Atoi(m[1])
+	key.release, _ = splitRelease(m[2])
+
+	if m[3] != "" {
+		switch strings.ToLower(m[3]) {
+		case "a", "alpha":
+			key.preRank = 0
+		case "b", "beta":
+			key.preRank = 1
+		default:
+			key.preRank = 2
+		}
+		key.preNum, _ = strconv.Atoi(m[4])
+	}
+	if m[5] != "" {
+		key.post, _ = strconv.Atoi(m[5])
+	} else if m[6] != "" {
+		key.post, _ = strconv.Atoi(m[7])
+	}
+	if m[8] != "" {
+		key.dev, _ = strconv.Atoi(m[9])
+		// 只有 dev 的版本排在所有预发布版本之前，例如 1.0.dev1 < 1.0a1
+		if m[3] == "" && key.post < 0 {
+			key.preRank = -1
+		}
+	}
+	return key, nil
+}
+
+func (k *pep440Key) compare(o *pep440Key) int {
+	if c := compareInt(k.epoch, o.epoch); c != 0 {
+		return c
+	}
+	if c := compareInts(k.release, o.release); c != 0 {
+		return c
+	}
+	if c := compareInt(k.preRank, o.preRank); c != 0 {
+		return c
+	}
+	if c := compareInt(k.preNum, o.preNum); c != 0 {
+		return c
+	}
+	if c := compareInt(k.post, o.post); c != 0 {
+		return c
+	}
+	// 无 dev 的版本大于有 dev 的版本
+	switch {
+	case k.dev >= 0 && o.dev < 0:
+		return -1
+	case k.dev < 0 && o.dev >= 0:
+		return 1
+	}
+	if c := compareInt(k.dev, o.dev); c != 0 {
+		return c
+	}
+	return strings.Compare(k.local, o.local)
+}
+
+// parsePEP440Version 解析 PEP 440 版本，例如 "1!2.0.1rc1.post2.dev3+local"
+func parsePEP440Version(raw string) (*Version, error) {
+	m := pep440Re.FindStringSubmatch(cleanVersion(raw))
+	if m == nil {
+		return nil, fmt.Errorf("invalid pep440 version: %q", raw)
+	}
+	version := &Version{Original: raw, Scheme: VersionSchemePEP440, Build: m[10]}
+	version.Epoch, _ = strconv.Atoi(m[1])
+	version.Release, _ = splitRelease(m[2])
+
+	// 规范化非数字部分，例如 "1.0-RC-1" -> "rc1"
+	var qualifier []string
+	if m[3] != "" {
+		label := strings.ToLower(m[3])
+		switch label {
+		case "alpha":
+			label = "a"
+		case "beta":
+			label = "b"
+		case "c", "pre", "preview":
+			label = "rc"
+		}
+		qualifier = append(qualifier, label+zeroIfEmpty(m[4]))
+	}
+	if m[5] != "" {
+		qualifier = append(qualifier, "post"+m[5])
+	} else if m[6] != "" {
+		qualifier = append(qualifier, "post"+zeroIfEmpty(m[7]))
+	}
+	if m[8] != "" {
+		qualifier = append(qualifier, "dev"+zeroIfEmpty(m[9]))
+	}
+	version.Qualifier = strings.Join(qualifier, ".")
+	return version, nil
+}
+
+func zeroIfEmpty(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package camodels
+
+import "strings"
+
+// 版本来源
+const (
+	// VersionSourceRange 声明的版本范围，例如 package.json 中的 "^4.17.0"
+	VersionSourceRange = "range"
+	// VersionSourcePinned 声明的固定版本，例如 requirements.txt 中的 "==2.0.1"
+	VersionSourcePinned = "pinned"
+	// VersionSourceResolved 解析或安装后的实际版本，例如锁文件、jar 文件名、框架源码中的版本常量
+	VersionSourceResolved = "resolved"
+)
+
+// VersionInfo 版本提取结果，保留原始值和来源，避免单一字符串丢失信息
+type VersionInfo struct {
+	Version string   `json:"version"`          // 规范化后的版本号，例如 "4.17.0"
+	Raw     string   `json:"raw,omitempty"`    // 原始提取值，例如 "^4.17.0"
+	Source  string   `json:"source,omitempty"` // 版本来源：range | pinned | resolved
+	Name    string   `json:"name,omitempty"`   // 命名捕获组 name 提取的名称，例如 "log4j-core"
+	File    string   `json:"file,omitempty"`   // 首次发现该版本的文件（相对路径）
+	Files   []string `json:"files,omitempty"`  // 出现该版本的所有文件（相对路径）
+
+	Parsed *Version      `json:"parsed,omitempty"` // 按生态版本规则解析后的版本
+	Range  *VersionRange `json:"range,omitempty"`  // 规范化后的版本范围，Source 为 range 或锁文件解析出的实际版本时填充
+}
+
+// ParseFor 按依赖生态解析版本号和版本范围，填充 Parsed 和 Range 字段，
+// 实际版本来自锁文件时 Range 为声明的版本范围
+// 无法解析时保持为空，不影响原始版本信息
+func (v *VersionInfo) ParseFor(ecosystem string) {
+	if v == nil || v.Version == "" {
+		return
+	}
+	if parsed, err := ParseVersion(v.Version, SchemeForEcosystem(ecosystem)); err == nil {
+		v.Parsed = parsed
+	}
+	if v.Source == VersionSourceRange && v.Raw != "" {
+		if versionRange, err := ParseVersionRange(v.Raw, ecosystem); err == nil {
+			v.Range = versionRange
+		}
+	}
+	// 实际版本来自锁文件时，原始声明（例如 "^18.2.0"）若为包含该版本的范围则一并保留
+	if v.Source == VersionSourceResolved && v.Raw != "" && v.Raw != v.Version && !strings.Contains(v.Raw, "$") {
+		if versionRange, err := ParseVersionRange(v.Raw, ecosystem); err == nil && versionRange.Contains(v.Version) {
+			v.Range = versionRange
+		}
+	}
+}
+
+// AddFile 记录出现该版本的文件，忽略空路径和重复路径
+func (v *VersionInfo) AddFile(file string) {
+	if file == "" {
+		return
+	}
+	for _, existing := range v.Files {
+		if existing == file {
+			return
+		}
+	}
+	v.Files = append(v.Files, file)
+}
@@ -0,0 +1,464 @@
+package camodels
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// VersionConstraint 单个版本约束，例如 ">=1.2.0"
+type VersionConstraint struct {
+	Operator string `json:"operator"` // = != > >= < <=
+	Version  string `json:"version"`
+}
+
+// VersionRange 规范化后的版本范围
+// Sets 之间为 OR 关系，单个 Set 内的约束为 AND 关系，空 Set 表示任意版本
+type VersionRange struct {
+	Original   string                `json:"original"`
+	Ecosystem  string                `json:"ecosystem,omitempty"`
+	Normalized string                `json:"normalized"`
+	Sets       [][]VersionConstraint `json:"sets"`
+}
+
+var (
+	// rangeOperatorSpaceRe 去除运算符与版本之间的空白，例如 ">= 1.2" -> ">=1.2"
+	rangeOperatorSpaceRe = regexp.MustCompile(`(>=|<=|==|!=|~=|~>|[<>=~^])\s+`)
+	// rangeHyphenRe 连字符范围，例如 "1.2.3 - 2.3.4"
+	rangeHyphenRe = regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+	// rangeStabilityRe composer 稳定性标记，例如 "@dev"、"@stable"
+	rangeStabilityRe = regexp.MustCompile(`@[a-zA-Z]+`)
+	// mavenRangeRe Maven 区间，例如 "[1.0,2.0)"
+	mavenRangeRe = regexp.MustCompile(`([\[(])([^\[\]()]*)([\])])`)
+)
+
+// ParseVersionRange 按依赖生态的语法解析版本范围
+// - npm: ^1.2.3、~1.2、1.x、>=1.0 <2.0、1.0 - 2.0、a || b
+// - composer: 与 npm 类似，~1.2 表示 >=1.2 <2.0，支持 | 、逗号和 @stability 标记
+// - pypi: >=1.0,<2.0、~=2.2、==1.2.*、!=1.3（同时兼容 Poetry 的 ^ 和 ~）
+// - maven: [1.0,2.0)、(,1.0]、[1.2]、1.0（软需求视为固定版本）
+// - golang: 仅支持固定版本
+func ParseVersionRange(raw string, ecosystem string) (*VersionRange, error) {
+	expr := strings.TrimSpace(raw)
+	var sets [][]VersionConstraint
+	var err error
+
+	switch strings.ToLower(ecosystem) {
+	case EcosystemMaven:
+		sets, err = parseMavenRange(expr)
+	case EcosystemPyPI:
+		sets, err = parsePipRange(expr)
+	case EcosystemComposer:
+		sets, err = parseNPMRange(rangeStabilityRe.ReplaceAllString(expr, ""), true)
+	case EcosystemGo:
+		if expr == "" {
+			return nil, fmt.Errorf("empty version range")
+		}
+		sets = [][]VersionConstraint{{{Operator: "=", Version: cleanVersion(expr)}}}
+	default:
+		sets, err = parseNPMRange(expr, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	versionRange := &VersionRange{Original: raw, Ecosystem: ecosystem, Sets: sets}
+	versionRange.Normalized = versionRange.String()
+	return versionRange, nil
+}
+
+// String 返回规范化的范围表达式，例如 ">=1.2.3, <2.0.0 || =3.0.0"
+func (r *VersionRange) String() string {
+	parts := make([]string, 0, len(r.Sets))
+	for _, set := range r.Sets {
+		if len(set) == 0 {
+			parts = append(parts, "*")
+			continue
+		}
+		constraints := make([]string, 0, len(set))
+		for _, c := range set {
+			constraints = append(constraints, c.Operator+c.Version)
+		}
+		parts = append(parts, strings.Join(constraints, ", "))
+	}
+	return strings.Join(parts, " || ")
+}
+
+// Contains 判断版本是否在范围内，版本无法解析时返回 false
+func (r *VersionRange) Contains(version string) bool {
+	scheme := SchemeForEcosystem(r.Ecosystem)
+	for _, set := range r.Sets {
+		if setContains(set, version, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func setContains(set []VersionConstraint, version string, scheme string) bool {
+	for _, c := range set {
+		cmp, err := CompareVersions(version, c.Version, scheme)
+		if err != nil {
+			return false
+		}
+		var ok bool
+		switch c.Operator {
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// andSets 计算两组 OR 约束集合的 AND（笛卡尔积）
+func andSets(a, b [][]VersionConstraint) [][]VersionConstraint {
+	var result [][]VersionConstraint
+	for _, x := range a {
+		for _, y := range b {
+			set := make([]VersionConstraint, 0, len(x)+len(y))
+			set = append(set, x...)
+			set = append(set, y...)
+			result = append(result, set)
+		}
+	}
+	return result
+}
+
+// partialVersion 可能不完整的版本，例如 "1"、"1.2"、"1.2.x"
+type partialVersion struct {
+	nums   []int  // 已指定的数字段
+	suffix string // 预发布或限定符后缀，例如 "-beta.1"
+}
+
+// parsePartialVersion 解析可能包含 x/* 通配或缺少部分段的版本
+func parsePartialVersion(raw string) (partialVersion, error) {
+	version := cleanVersion(raw)
+	if idx := strings.Index(version, "+"); idx >= 0 {
+		version = version[:idx]
+	}
+	var p partialVersion
+	if version == "" || version == "*" || version == "x" || version == "X" {
+		return p, nil
+	}
+	if idx := strings.IndexAny(version, "-"); idx > 0 {
+		p.suffix = version[idx:]
+		version = version[:idx]
+	}
+	for _, part := range strings.Split(version, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return p, fmt.Errorf("invalid version: %q", raw)
+		}
+		p.nums = append(p.nums, n)
+	}
+	return p, nil
+}
+
+// full 补齐到至少 3 段的版本字符串
+func (p partialVersion) full() string {
+	nums := append([]int{}, p.nums...)
+	for len(nums) < 3 {
+		nums = append(nums, 0)
+	}
+	return joinInts(nums) + p.suffix
+}
+
+// bump 将第 idx 段加一，之后的段归零，并补齐到 3 段
+func (p partialVersion) bump(idx int) string {
+	nums := make([]int, idx+1)
+	copy(nums, p.nums)
+	nums[idx]++
+	for len(nums) < 3 {
+		nums = append(nums, 0)
+	}
+	return joinInts(nums)
+}
+
+func joinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// --- npm / composer ---
+
+func parseNPMRange(expr string, composer bool) ([][]VersionConstraint, error) {
+	var alternatives []string
+	if composer {
+		alternatives = regexp.MustCompile(`\|\|?`).Split(expr, -1)
+	} else {
+		alternatives = strings.Split(expr, "||")
+	}
+
+	var sets [][]VersionConstraint
+	for _, alternative := range alternatives {
+		set, err := parseNPMComparatorSet(strings.TrimSpace(alternative), composer)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set...)
+	}
+	return sets, nil
+}
+
+func parseNPMComparatorSet(expr string, composer bool) ([][]VersionConstraint, error) {
+	if m := rangeHyphenRe.FindStringSubmatch(expr); m != nil {
+		lower, err := parsePartialVersion(m[1])
+		if err != nil {
+			return nil, err
+		}
+		upper, err := parsePartialVersion(m[2])
+		if err != nil {
+			return nil, err
+		}
+		set := []VersionConstraint{{Operator: ">=", Version: lower.full()}}
+		if len(upper.nums) == 0 {
+			return [][]VersionConstraint{set}, nil
+		}
+		if len(upper.nums) < 3 {
+			set = append(set, VersionConstraint{Operator: "<", Version: upper.bump(len(upper.nums) - 1)})
+		} else {
+			set = append(set, VersionConstraint{Operator: "<=", Version: upper.full()})
+		}
+		return [][]VersionConstraint{set}, nil
+	}
+
+	expr = rangeOperatorSpaceRe.ReplaceAllString(expr, "$1")
+	if composer {
+		expr = strings.ReplaceAll(expr, ",", " ")
+	}
+
+	sets := [][]VersionConstraint{{}}
+	for _, token := range strings.Fields(expr) {
+		constraints, err := parseNPMComparator(token, composer)
+		if err != nil {
+			return nil, err
+		}
+		sets = andSets(sets, [][]VersionConstraint{constraints})
+	}
+	return sets, nil
+}
+
+// splitOperator 拆分运算符和版本
+func splitOperator(token string) (string, string) {
+	for _, op := range []string{"===", ">=", "<=", "==", "!=", "~=", "~>", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(token, op) {
+			return op, strings.TrimSpace(token[len(op):])
+		}
+	}
+	return "", token
+}
+
+func parseNPMComparator(token string, composer bool) ([]VersionConstraint, error) {
+	op, version := splitOperator(token)
+	p, err := parsePartialVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	n := len(p.nums)
+	if n == 0 {
+		switch op {
+		case "", "=", "==", ">=", "^", "~", "~>":
+			return []VersionConstraint{}, nil // 任意版本
+		}
+		return nil, fmt.Errorf("invalid version range: %q", token)
+	}
+
+	switch op {
+	case "^":
+		upper := p.bump(0)
+		if p.nums[0] == 0 && n >= 2 {
+			if p.nums[1] > 0 || n == 2 {
+				upper = p.bump(1)
+			} else {
+				upper = p.bump(2)
+			}
+		}
+		return []VersionConstraint{{Operator: ">=", Version: p.full()}, {Operator: "<", Version: upper}}, nil
+	case "~", "~>":
+		idx := 1
+		if n == 1 {
+			idx = 0
+		} else if (composer || op == "~>") && n == 2 {
+			// composer 和 ~> 中最后指定的段可以变化：~1.2 表示 >=1.2.0 <2.0.0
+			idx = 0
+		}
+		return []VersionConstraint{{Operator: ">=", Version: p.full()}, {Operator: "<", Version: p.bump(idx)}}, nil
+	case ">":
+		if n < 3 {
+			return []VersionConstraint{{Operator: ">=", Version: p.bump(n - 1)}}, nil
+		}
+		return []VersionConstraint{{Operator: ">", Version: p.full()}}, nil
+	case ">=":
+		return []VersionConstraint{{Operator: ">=", Version: p.full()}}, nil
+	case "<":
+		return []VersionConstraint{{Operator: "<", Version: p.full()}}, nil
+	case "<=":
+		if n < 3 {
+			return []VersionConstraint{{Operator: "<", Version: p.bump(n - 1)}}, nil
+		}
+		return []VersionConstraint{{Operator: "<=", Version: p.full()}}, nil
+	case "!=":
+		return []VersionConstraint{{Operator: "!=", Version: p.full()}}, nil
+	case "", "=", "==", "===":
+		if n < 3 {
+			// 不完整版本视为通配：1.2 表示 >=1.2.0 <1.3.0
+			return []VersionConstraint{{Operator: ">=", Version: p.full()}, {Operator: "<", Version: p.bump(n - 1)}}, nil
+		}
+		return []VersionConstraint{{Operator: "=", Version: p.full()}}, nil
+	}
+	return nil, fmt.Errorf("unsupported version operator: %q", token)
+}
+
+// --- pip ---
+
+func parsePipRange(expr string) ([][]VersionConstraint, error) {
+	// 去除环境标记，例如 `>=1.0; python_version < "3.8"`
+	if idx := strings.Index(expr, ";"); idx >= 0 {
+		expr = expr[:idx]
+	}
+	expr = strings.Trim(strings.TrimSpace(expr), "()")
+	if expr == "" || expr == "*" {
+		return [][]VersionConstraint{{}}, nil
+	}
+
+	sets := [][]VersionConstraint{{}}
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		alternatives, err := parsePipSpecifier(part)
+		if err != nil {
+			return nil, err
+		}
+		sets = andSets(sets, alternatives)
+	}
+	return sets, nil
+}
+
+func parsePipSpecifier(spec string) ([][]VersionConstraint, error) {
+	op, version := splitOperator(spec)
+	switch op {
+	case "^", "~":
+		// Poetry 语法与 npm 相同
+		constraints, err := parseNPMComparator(spec, false)
+		if err != nil {
+			return nil, err
+		}
+		return [][]VersionConstraint{constraints}, nil
+	case "===":
+		return [][]VersionConstraint{{{Operator: "=", Version: version}}}, nil
+	}
+
+	// 前缀匹配，例如 ==1.2.* 或 !=1.2.*
+	if strings.HasSuffix(version, ".*") {
+		prefix, err := parsePartialVersion(strings.TrimSuffix(version, ".*"))
+		if err != nil || len(prefix.nums) == 0 {
+			return nil, fmt.Errorf("invalid version specifier: %q", spec)
+		}
+		lower := joinInts(prefix.nums)
+		upper := prefix.bumpExact(len(prefix.nums) - 1)
+		switch op {
+		case "==", "":
+			return [][]VersionConstraint{{{Operator: ">=", Version: lower}, {Operator: "<", Version: upper}}}, nil
+		case "!=":
+			return [][]VersionConstraint{{{Operator: "<", Version: lower}}, {{Operator: ">=", Version: upper}}}, nil
+		}
+		return nil, fmt.Errorf("invalid version specifier: %q", spec)
+	}
+
+	if _, err := parsePEP440Key(version); err != nil {
+		return nil, err
+	}
+	switch op {
+	case "~=":
+		p, err := parsePartialVersion(version)
+		if err != nil || len(p.nums) < 2 {
+			return nil, fmt.Errorf("invalid compatible release specifier: %q", spec)
+		}
+		// ~=1.4.5 表示 >=1.4.5, ==1.4.*
+		return [][]VersionConstraint{{{Operator: ">=", Version: version}, {Operator: "<", Version: p.bumpExact(len(p.nums) - 2)}}}, nil
+	case "", "==":
+		return [][]VersionConstraint{{{Operator: "=", Version: version}}}, nil
+	case ">", ">=", "<", "<=", "!=":
+		return [][]VersionConstraint{{{Operator: op, Version: version}}}, nil
+	}
+	return nil, fmt.Errorf("unsupported version specifier: %q", spec)
+}
+
+// bumpExact 将第 idx 段加一并截断之后的段，不补齐，例如 1.4.5 bumpExact(1) -> 1.5
+func (p partialVersion) bumpExact(idx int) string {
+	nums := make([]int, idx+1)
+	copy(nums, p.nums)
+	nums[idx]++
+	return joinInts(nums)
+}
+
+// --- maven ---
+
+func parseMavenRange(expr string) ([][]VersionConstraint, error) {
+	if expr == "" {
+		return nil, fmt.Errorf("empty version range")
+	}
+	// 不含区间符号的版本为软需求，视为固定版本
+	if !strings.ContainsAny(expr, "[(") {
+		return [][]VersionConstraint{{{Operator: "=", Version: expr}}}, nil
+	}
+
+	var sets [][]VersionConstraint
+	for _, m := range mavenRangeRe.FindAllStringSubmatch(expr, -1) {
+		bounds := strings.Split(m[2], ",")
+		lower := strings.TrimSpace(bounds[0])
+		if len(bounds) == 1 {
+			if m[1] != "[" || m[3] != "]" || lower == "" {
+				return nil, fmt.Errorf("invalid maven version range: %q", expr)
+			}
+			sets = append(sets, []VersionConstraint{{Operator: "=", Version: lower}})
+			continue
+		}
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid maven version range: %q", expr)
+		}
+		upper := strings.TrimSpace(bounds[1])
+		set := []VersionConstraint{}
+		if lower != "" {
+			op := ">"
+			if m[1] == "[" {
+				op = ">="
+			}
+			set = append(set, VersionConstraint{Operator: op, Version: lower})
+		}
+		if upper != "" {
+			op := "<"
+			if m[3] == "]" {
+				op = "<="
+			}
+			set = append(set, VersionConstraint{Operator: op, Version: upper})
+		}
+		sets = append(sets, set)
+	}
+	if len(sets) == 0 {
+		return nil, fmt.Errorf("invalid maven version range: %q", expr)
+	}
+	return sets, nil
+}
@@ -0,0 +1,22 @@
+package canvas
+
+import (
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/advisory"
+)
+
+// MatchAdvisories loads OSV-format advisories from a directory, a zip archive
+// or a single JSON file and stores the advisories affecting the report's
+// inventory dependencies and detected items in report.Findings.
+// Matching is fully offline; no network access is performed.
+func MatchAdvisories(report *camodels.CanvasReport, advisoriesPath string) error {
+	db, err := advisory.Load(advisoriesPath)
+	if err != nil {
+		return err
+	}
+	report.Findings = db.Match(report)
+	slogs.Infof("advisories: %d loaded, %d findings", db.Count(), len(report.Findings))
+	return nil
+}
@@ -9,7 +9,10 @@ import (
 
 	"github.com/winezer0/xcanvas/camodels"
 	"github.com/winezer0/xcanvas/internal/analyzer"
+	"github.com/winezer0/xcanvas/internal/embeds"
 	"github.com/winezer0/xcanvas/internal/frameengine"
+	"github.com/winezer0/xcanvas/internal/gitfs"
+	"github.com/winezer0/xcanvas/internal/lifecycle"
 )
 
 // Analyze performs a full analysis and returns a CanvasReport.
@@ -25,6 +28,23 @@ type Options struct {
 	MaxFileSize    int64
 	MaxDepth       int
 	FollowSymlinks bool
+
+	// IgnoreFiles honours .gitignore and .xcanvasignore files in the project.
+	IgnoreFiles bool
+	// IgnoreSets names the built-in per-ecosystem ignore sets (node_modules,
+	// vendor, target, ...) to apply, see IgnoreSetNames.
+	IgnoreSets []string
+	// SkipVendored skips the dependency directories of the ignore sets instead
+	// of reporting them as vendored code.
+	SkipVendored bool
+	// CountGenerated counts generated and minified files in the main statistics
+	// instead of reporting them separately.
+	CountGenerated bool
+
+	// GitRef analyzes the given revision (branch, tag or commit) of the git
+	// repository at path instead of its working directory. File contents are
+	// read from git objects, so bare repositories are supported as well.
+	GitRef string
 }
 
 // DefaultOptions returns production-safe defaults.
@@ -35,9 +55,16 @@ func DefaultOptions() Options {
 		MaxFileSize:    d.MaxFileSize,
 		MaxDepth:       d.MaxDepth,
 		FollowSymlinks: d.FollowSymlinks,
+		IgnoreFiles:    d.IgnoreFiles,
+		IgnoreSets:     d.IgnoreSets,
 	}
 }
 
+// IgnoreSetNames returns the names of the built-in ignore sets.
+func IgnoreSetNames() []string {
+	return analyzer.IgnoreSetNames()
+}
+
 // toWalkOptions converts public Options to internal analyzer.WalkOptions.
 func (o Options) toWalkOptions() analyzer.WalkOptions {
 	return analyzer.WalkOptions{
@@ -45,6 +72,10 @@ func (o Options) toWalkOptions() analyzer.WalkOptions {
 		MaxFileSize:    o.MaxFileSize,
 		MaxDepth:       o.MaxDepth,
 		FollowSymlinks: o.FollowSymlinks,
+		IgnoreFiles:    o.IgnoreFiles,
+		IgnoreSets:     o.IgnoreSets,
+		SkipVendored:   o.SkipVendored,
+		CountGenerated: o.CountGenerated,
 	}
 }
 
@@ -62,7 +93,23 @@ func AnalyzeWithContext(ctx context.Context, path string, rulesDir string, opts
 
 	// Analyze code structure with context and resource limits.
 	codeAnalyzer := analyzer.NewCodeAnalyzer()
-	codeProfile, fileIndex, diag, analyzerErr := codeAnalyzer.AnalyzeCodeProfileWithContext(ctx, path, opts.toWalkOptions())
+	var codeProfile *camodels.CodeProfile
+	var fileIndex *camodels.FileIndex
+	var diag *analyzer.WalkDiagnostics
+	var analyzerErr error
+	var revision *camodels.Revision
+	if opts.GitRef != "" {
+		tree, err := gitfs.Open(path, opts.GitRef)
+		if err != nil {
+			return nil, fmt.Errorf("open git revision error: %w", err)
+		}
+		defer tree.Close()
+		gitRevision := tree.Revision()
+		revision = &camodels.Revision{Ref: gitRevision.Ref, Commit: gitRevision.Commit, CommitTime: gitRevision.Time}
+		codeProfile, fileIndex, diag, analyzerErr = codeAnalyzer.AnalyzeCodeProfileFS(ctx, path, tree, opts.toWalkOptions())
+	} else {
+		codeProfile, fileIndex, diag, analyzerErr = codeAnalyzer.AnalyzeCodeProfileWithContext(ctx, path, opts.toWalkOptions())
+	}
 	if analyzerErr != nil {
 		return nil, fmt.Errorf("error analyzing code profile: %w", analyzerErr)
 	}
@@ -75,16 +122,22 @@ func AnalyzeWithContext(ctx context.Context, path string, rulesDir string, opts
 	}
 
 	// Detect frameworks and components using rules.
-	detectInfo, detectErr := canvasEngine.DetectFrameworks(fileIndex, codeProfile.Expands)
+	// Dependencies from all supported manifests are reported as an inventory, independent of the rules.
+	detectInfo, dependencies, detectErr := canvasEngine.DetectWithDependencies(fileIndex, codeProfile.Expands)
 	if detectErr != nil {
 		return nil, fmt.Errorf("error detecting frameworks and components: %v", detectErr)
 	}
 
 	report := &camodels.CanvasReport{
-		CodeProfile: *codeProfile,
-		Detection:   *detectInfo,
-		Timestamp:   time.Now(),
+		CodeProfile:  *codeProfile,
+		Detection:    *detectInfo,
+		Dependencies: dependencies,
+		Revision:     revision,
+		Timestamp:    time.Now(),
 	}
+
+	// Annotate detected items with release cycle and end-of-life data from the bundled lifecycle dataset.
+	lifecycle.New(embeds.EmbeddedLifecycles()).Annotate(&report.Detection, report.Timestamp)
 	return report, nil
 }
 
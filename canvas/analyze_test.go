@@ -74,7 +74,19 @@ func main() {
 		t.Error("未检测到 Gin 框架")
 	}
 
-	// 3. 验证前端语言 (应为空)
+	// 3. 验证依赖清单 (与规则无关，包含 go.mod 中声明的全部依赖)
+	foundDependency := false
+	for _, dependency := range result.Dependencies {
+		if dependency.Name == "github.com/gin-gonic/gin" && dependency.Version == "1.9.1" && dependency.File == "go.mod" {
+			foundDependency = true
+			break
+		}
+	}
+	if !foundDependency {
+		t.Errorf("依赖清单中未找到 gin: %+v", result.Dependencies)
+	}
+
+	// 4. 验证前端语言 (应为空)
 	if len(result.CodeProfile.FrontendLanguages) > 0 {
 		t.Errorf("检测到前端语言列表: %v", result.CodeProfile.FrontendLanguages)
 	}
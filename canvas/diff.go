@@ -0,0 +1,42 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// LoadReport reads a JSON report previously written with -o.
+func LoadReport(reportPath string) (*camodels.CanvasReport, error) {
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, fmt.Errorf("read report %s error: %w", reportPath, err)
+	}
+	report := &camodels.CanvasReport{}
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, fmt.Errorf("parse report %s error: %w", reportPath, err)
+	}
+	return report, nil
+}
+
+// DiffReports compares two analysis reports: added and removed languages,
+// frameworks and components, version changes with their direction, and
+// language line counts changing by more than the thresholds in opts.
+func DiffReports(oldReport, newReport *camodels.CanvasReport, opts camodels.DiffOptions) *camodels.ReportDiff {
+	return camodels.DiffReports(oldReport, newReport, opts)
+}
+
+// DiffReportFiles loads two JSON reports and compares them.
+func DiffReportFiles(oldPath, newPath string, opts camodels.DiffOptions) (*camodels.ReportDiff, error) {
+	oldReport, err := LoadReport(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newReport, err := LoadReport(newPath)
+	if err != nil {
+		return nil, err
+	}
+	return DiffReports(oldReport, newReport, opts), nil
+}
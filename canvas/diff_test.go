@@ -0,0 +1,139 @@
+package canvas
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+func diffTestReports() (*camodels.CanvasReport, *camodels.CanvasReport) {
+	oldReport := &camodels.CanvasReport{
+		CodeProfile: camodels.CodeProfile{
+			Path: "/src/app",
+			LanguageInfos: []camodels.LangInfo{
+				{Name: "Java", Files: 100, CodeLines: 10000},
+				{Name: "JavaScript", Files: 20, CodeLines: 2000},
+				{Name: "PHP", Files: 3, CodeLines: 120},
+				{Name: "Go", Files: 10, CodeLines: 1000},
+			},
+		},
+		Detection: camodels.DetectionInfo{
+			Frameworks: []camodels.DetectedItem{
+				{Name: "Spring Boot", Language: "Java", Version: "2.7.18"},
+				{Name: "Vue.js", Language: "JavaScript", Version: "3.4.0"},
+				{Name: "Laravel", Language: "PHP", Version: "10.0.0"},
+			},
+			Components: []camodels.DetectedItem{
+				{Name: "log4j", Language: "Java", Version: "2.17.1"},
+				{Name: "fastjson", Language: "Java", Version: "1.2.83"},
+				{Name: "jquery", Language: "JavaScript"},
+			},
+		},
+	}
+	newReport := &camodels.CanvasReport{
+		CodeProfile: camodels.CodeProfile{
+			Path: "/src/app",
+			LanguageInfos: []camodels.LangInfo{
+				{Name: "Java", Files: 110, CodeLines: 10500},
+				{Name: "JavaScript", Files: 40, CodeLines: 3000},
+				{Name: "TypeScript", Files: 5, CodeLines: 300},
+				{Name: "Go", Files: 10, CodeLines: 1090},
+			},
+		},
+		Detection: camodels.DetectionInfo{
+			Frameworks: []camodels.DetectedItem{
+				{Name: "Spring Boot", Language: "Java", Version: "3.2.5"},
+				{Name: "vue.js", Language: "JavaScript", Version: "3.4.0"},
+				{Name: "Gin", Language: "Go", Version: "1.9.1"},
+			},
+			Components: []camodels.DetectedItem{
+				{Name: "log4j", Language: "Java", Version: "2.17.1"},
+				{Name: "fastjson", Language: "Java", Version: "1.2.80"},
+				{Name: "jquery", Language: "JavaScript", Version: "3.7.1"},
+			},
+		},
+	}
+	return oldReport, newReport
+}
+
+// TestDiffReports tests language, item and version direction differences
+func TestDiffReports(t *testing.T) {
+	oldReport, newReport := diffTestReports()
+	diff := DiffReports(oldReport, newReport, camodels.DefaultDiffOptions())
+
+	if len(diff.AddedLanguages) != 1 || diff.AddedLanguages[0] != "TypeScript" {
+		t.Errorf("AddedLanguages = %v, want [TypeScript]", diff.AddedLanguages)
+	}
+	if len(diff.RemovedLanguages) != 1 || diff.RemovedLanguages[0] != "PHP" {
+		t.Errorf("RemovedLanguages = %v, want [PHP]", diff.RemovedLanguages)
+	}
+	// Java +500（5%）和 Go +90（9%）未达到阈值
+	if len(diff.LanguageChanges) != 1 {
+		t.Fatalf("LanguageChanges = %+v, want JavaScript only", diff.LanguageChanges)
+	}
+	if change := diff.LanguageChanges[0]; change.Name != "JavaScript" || change.CodeLineDelta != 1000 || change.Percent != 50 {
+		t.Errorf("LanguageChanges[0] = %+v", change)
+	}
+
+	frameworks := diff.Frameworks
+	if len(frameworks.Added) != 1 || frameworks.Added[0].Name != "Gin" {
+		t.Errorf("Frameworks.Added = %+v", frameworks.Added)
+	}
+	if len(frameworks.Removed) != 1 || frameworks.Removed[0].Name != "Laravel" {
+		t.Errorf("Frameworks.Removed = %+v", frameworks.Removed)
+	}
+	if len(frameworks.Changed) != 1 || frameworks.Changed[0].Direction != camodels.VersionUpgrade {
+		t.Errorf("Frameworks.Changed = %+v, want Spring Boot upgrade", frameworks.Changed)
+	}
+
+	components := diff.Components
+	if len(components.Added) != 0 || len(components.Removed) != 0 || len(components.Changed) != 2 {
+		t.Fatalf("Components = %+v", components)
+	}
+	want := map[string]string{"fastjson": camodels.VersionDowngrade, "jquery": camodels.VersionChanged}
+	for _, change := range components.Changed {
+		if want[change.Name] != change.Direction {
+			t.Errorf("%s direction = %s, want %s", change.Name, change.Direction, want[change.Name])
+		}
+	}
+
+	if diff.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+	if same := DiffReports(oldReport, oldReport, camodels.DefaultDiffOptions()); !same.IsEmpty() {
+		t.Errorf("DiffReports(same) = %+v, want empty", same)
+	}
+}
+
+// TestDiffReportFiles tests loading reports written as JSON
+func TestDiffReportFiles(t *testing.T) {
+	oldReport, newReport := diffTestReports()
+	dir := t.TempDir()
+	paths := make([]string, 0, 2)
+	for i, report := range []*camodels.CanvasReport{oldReport, newReport} {
+		data, err := json.Marshal(report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, []string{"old.json", "new.json"}[i])
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	diff, err := DiffReportFiles(paths[0], paths[1], camodels.DiffOptions{})
+	if err != nil {
+		t.Fatalf("DiffReportFiles() error = %v", err)
+	}
+	// 阈值为 0 时所有行数变化都视为显著
+	if len(diff.LanguageChanges) != 3 {
+		t.Errorf("LanguageChanges = %+v, want 3", diff.LanguageChanges)
+	}
+	if _, err := DiffReportFiles(paths[0], filepath.Join(dir, "missing.json"), camodels.DiffOptions{}); err == nil {
+		t.Error("DiffReportFiles(missing) error = nil, want error")
+	}
+}
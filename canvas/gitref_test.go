@@ -0,0 +1,81 @@
+package canvas
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func gitCommand(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// TestAnalyzeGitRef tests that a tagged revision is analyzed instead of the working directory
+func TestAnalyzeGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	write := func(name, content string) {
+		file := filepath.Join(repo, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitCommand(t, repo, "init", "-q")
+	write("go.mod", "module example.com/app\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.9.1\n")
+	write("main.go", "package main\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc main() {\n\tgin.Default().Run()\n}\n")
+	gitCommand(t, repo, "add", "-A")
+	gitCommand(t, repo, "commit", "-q", "-m", "init")
+	gitCommand(t, repo, "tag", "v1")
+
+	// 工作区中的修改不属于 v1
+	write("go.mod", "module example.com/app\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.10.0\n")
+	write("web/index.py", "print('hello')\n")
+
+	bare := filepath.Join(t.TempDir(), "app.git")
+	gitCommand(t, repo, "clone", "-q", "--bare", repo, bare)
+
+	for _, path := range []string{repo, bare} {
+		opts := DefaultOptions()
+		opts.GitRef = "v1"
+		report, err := AnalyzeWithContext(context.Background(), path, "", opts)
+		if err != nil {
+			t.Fatalf("AnalyzeWithContext(%s) error = %v", path, err)
+		}
+		if report.Revision == nil || report.Revision.Ref != "v1" || len(report.Revision.Commit) != 40 {
+			t.Errorf("%s: Revision = %+v", path, report.Revision)
+		}
+		if report.CodeProfile.TotalFiles != 1 || len(report.CodeProfile.LanguageInfos) != 1 || report.CodeProfile.LanguageInfos[0].Name != "Go" {
+			t.Errorf("%s: CodeProfile = %+v", path, report.CodeProfile)
+		}
+		found := false
+		for _, item := range report.Detection.Frameworks {
+			if item.Name == "Gin" {
+				found = true
+				if item.Version != "1.9.1" {
+					t.Errorf("%s: Gin version = %s, want 1.9.1", path, item.Version)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: Gin not detected in %+v", path, report.Detection.Frameworks)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.GitRef = "no-such-ref"
+	if _, err := AnalyzeWithContext(context.Background(), repo, "", opts); err == nil {
+		t.Error("AnalyzeWithContext(no-such-ref) error = nil, want error")
+	}
+}
@@ -0,0 +1,67 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/gitfs"
+)
+
+// HistoryOptions selects the revisions analyzed by AnalyzeHistory.
+type HistoryOptions struct {
+	Options
+
+	// Tags analyzes every tag of the repository instead of sampling commits.
+	Tags bool
+	// Ref is the branch or commit whose first-parent history is sampled (default HEAD).
+	Ref string
+	// Samples is the number of commits sampled evenly from the history of Ref,
+	// always including the first and the last commit; 0 analyzes every commit.
+	Samples int
+}
+
+// AnalyzeHistory analyzes several revisions of the git repository at path,
+// read directly from git objects, and builds a timeline of when frameworks and
+// components were first and last seen, how their versions changed and how the
+// language share evolved.
+func AnalyzeHistory(ctx context.Context, path string, rulesDir string, opts HistoryOptions) (*camodels.History, error) {
+	var revisions []gitfs.Revision
+	var err error
+	if opts.Tags {
+		revisions, err = gitfs.Tags(path)
+	} else {
+		ref := opts.Ref
+		if ref == "" {
+			ref = "HEAD"
+		}
+		revisions, err = gitfs.Commits(path, ref, opts.Samples)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no revisions to analyze in %s", path)
+	}
+
+	reports := make([]*camodels.CanvasReport, 0, len(revisions))
+	for i, revision := range revisions {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("xcanvas: history canceled: %w", err)
+		}
+		slogs.Infof("history: analyzing %s (%d/%d)", revision.Ref, i+1, len(revisions))
+		revisionOpts := opts.Options
+		revisionOpts.GitRef = revision.Commit
+		report, err := AnalyzeWithContext(ctx, path, rulesDir, revisionOpts)
+		if err != nil {
+			return nil, fmt.Errorf("analyze revision %s error: %w", revision.Ref, err)
+		}
+		// Identify the revision by its tag name or short hash rather than the full hash.
+		report.Revision.Ref = revision.Ref
+		reports = append(reports, report)
+	}
+
+	return camodels.BuildHistory(reports[len(reports)-1].CodeProfile.Path, reports), nil
+}
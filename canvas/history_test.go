@@ -0,0 +1,79 @@
+package canvas
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestAnalyzeHistory tests first/last seen revisions, version changes and language share across tags
+func TestAnalyzeHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func(tag, date string) {
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		gitCommand(t, repo, "add", "-A")
+		gitCommand(t, repo, "commit", "-q", "-m", tag)
+		gitCommand(t, repo, "tag", tag)
+	}
+	gitCommand(t, repo, "init", "-q")
+	write("main.go", "package main\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc main() {\n\tgin.Default().Run()\n}\n")
+	write("go.mod", "module example.com/app\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.9.1\n")
+	commit("v1", "2023-01-01T00:00:00Z")
+	write("go.mod", "module example.com/app\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.10.0\n")
+	write("package.json", `{"dependencies": {"react": "18.2.0"}}`)
+	write("app.jsx", "export const App = () => <div />\n")
+	commit("v2", "2023-06-01T00:00:00Z")
+	gitCommand(t, repo, "rm", "-q", "package.json", "app.jsx")
+	commit("v3", "2024-01-01T00:00:00Z")
+
+	history, err := AnalyzeHistory(context.Background(), repo, "", HistoryOptions{Options: DefaultOptions(), Tags: true})
+	if err != nil {
+		t.Fatalf("AnalyzeHistory() error = %v", err)
+	}
+	if len(history.Snapshots) != 3 || history.Snapshots[0].Revision.Ref != "v1" || history.Snapshots[2].Revision.Ref != "v3" {
+		t.Fatalf("Snapshots = %+v", history.Snapshots)
+	}
+	// package.json 也计入 JSON 语言：Go 5 行、JSON 1 行、JSX 1 行
+	if languages := history.Snapshots[1].Languages; len(languages) != 3 || languages[0].Name != "Go" || languages[0].Percent != 71.4 {
+		t.Errorf("v2 languages = %+v", languages)
+	}
+
+	timelines := make(map[string]camodels.ItemTimeline)
+	for _, timeline := range history.Frameworks {
+		timelines[timeline.Name] = timeline
+	}
+	gin, ok := timelines["Gin"]
+	if !ok || gin.FirstSeen.Ref != "v1" || gin.LastSeen.Ref != "v3" || !gin.Present {
+		t.Fatalf("Gin timeline = %+v", gin)
+	}
+	if len(gin.Versions) != 2 || gin.Versions[0].Version != "1.9.1" || gin.Versions[1].Version != "1.10.0" ||
+		gin.Versions[1].Direction != camodels.VersionUpgrade || gin.Versions[1].Revision.Ref != "v2" {
+		t.Errorf("Gin versions = %+v", gin.Versions)
+	}
+	react, ok := timelines["React"]
+	if !ok || react.FirstSeen.Ref != "v2" || react.LastSeen.Ref != "v2" || react.Present {
+		t.Errorf("React timeline = %+v", react)
+	}
+
+	// 抽样两个提交时包含最早和最新的提交
+	history, err = AnalyzeHistory(context.Background(), repo, "", HistoryOptions{Options: DefaultOptions(), Samples: 2})
+	if err != nil {
+		t.Fatalf("AnalyzeHistory(samples) error = %v", err)
+	}
+	if len(history.Snapshots) != 2 || history.Snapshots[0].Revision.CommitTime.Year() != 2023 || history.Snapshots[1].Revision.CommitTime.Year() != 2024 {
+		t.Errorf("sampled Snapshots = %+v", history.Snapshots)
+	}
+}
@@ -0,0 +1,19 @@
+package canvas
+
+import (
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/embeds"
+	"github.com/winezer0/xcanvas/internal/lifecycle"
+)
+
+// ApplyLifecycle re-annotates the detected items of the report with lifecycle
+// data loaded from a YAML file or directory. Entries are keyed by rule name and
+// replace the bundled entry of the same name; other bundled entries are kept.
+func ApplyLifecycle(report *camodels.CanvasReport, lifecyclePath string) error {
+	overrides, err := lifecycle.Load(lifecyclePath)
+	if err != nil {
+		return err
+	}
+	lifecycle.New(embeds.EmbeddedLifecycles(), overrides).Annotate(&report.Detection, report.Timestamp)
+	return nil
+}
@@ -0,0 +1,29 @@
+package canvas
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/winezer0/xcanvas/internal/embeds"
+	"github.com/winezer0/xcanvas/internal/embeds_lang"
+)
+
+// LintLanguageRules loads the language rule files (*.yml) in dir, or the
+// bundled rules when dir is empty, merges declarations of the same language
+// and returns one line per conflict: languages defined in more than one file
+// and extensions or filenames claimed by more than one language.
+func LintLanguageRules(dir string) ([]string, error) {
+	var fsys fs.FS = embeds_lang.LanguageEmbedFS
+	if dir != "" {
+		fsys = os.DirFS(dir)
+	}
+	_, conflicts, err := embeds.LoadLangRules(fsys)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		lines = append(lines, conflict.String())
+	}
+	return lines, nil
+}
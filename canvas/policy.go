@@ -0,0 +1,21 @@
+package canvas
+
+import (
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/policy"
+)
+
+// LoadPolicy reads and validates a YAML policy file. Each rule needs at least
+// one condition and an action of deny (the default) or warn.
+func LoadPolicy(policyPath string) (*camodels.Policy, error) {
+	return policy.Load(policyPath)
+}
+
+// EvaluatePolicy evaluates the policy rules against the detected items and
+// languages of the report and stores the violations in report.Violations.
+// It reports whether any deny rule and any warn rule was violated.
+func EvaluatePolicy(report *camodels.CanvasReport, p *camodels.Policy) (denied, warned bool) {
+	report.Violations = policy.Evaluate(p, report)
+	return policy.HasAction(report.Violations, camodels.PolicyActionDeny),
+		policy.HasAction(report.Violations, camodels.PolicyActionWarn)
+}
@@ -0,0 +1,39 @@
+package canvas
+
+import "github.com/winezer0/xcanvas/camodels"
+
+// Supported dependency ecosystems for version comparison and range parsing.
+const (
+	EcosystemNPM      = camodels.EcosystemNPM
+	EcosystemMaven    = camodels.EcosystemMaven
+	EcosystemPyPI     = camodels.EcosystemPyPI
+	EcosystemComposer = camodels.EcosystemComposer
+	EcosystemGo       = camodels.EcosystemGo
+)
+
+// ParseVersion parses a version string using the ordering rules of the given
+// ecosystem (semver by default, Maven for maven, PEP 440 for pypi).
+func ParseVersion(version string, ecosystem string) (*camodels.Version, error) {
+	return camodels.ParseVersion(version, camodels.SchemeForEcosystem(ecosystem))
+}
+
+// CompareVersions compares two versions of the given ecosystem.
+// It returns -1 if a < b, 0 if a == b and 1 if a > b.
+func CompareVersions(a, b string, ecosystem string) (int, error) {
+	return camodels.CompareVersions(a, b, camodels.SchemeForEcosystem(ecosystem))
+}
+
+// ParseVersionRange normalizes a version range written in the syntax of the
+// given ecosystem (npm, composer, pip, maven) into OR-ed sets of constraints.
+func ParseVersionRange(versionRange string, ecosystem string) (*camodels.VersionRange, error) {
+	return camodels.ParseVersionRange(versionRange, ecosystem)
+}
+
+// VersionInRange reports whether version satisfies the version range.
+func VersionInRange(version string, versionRange string, ecosystem string) (bool, error) {
+	r, err := camodels.ParseVersionRange(versionRange, ecosystem)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(version), nil
+}
@@ -0,0 +1,107 @@
+package canvas
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b      string
+		ecosystem string
+		want      int
+	}{
+		{"1.2.83", "1.2.9", EcosystemNPM, 1},
+		{"v1.9.1", "1.9.1", EcosystemGo, 0},
+		{"1.0.0-alpha", "1.0.0", EcosystemNPM, -1},
+		{"1.0.0-alpha.2", "1.0.0-alpha.10", EcosystemNPM, -1},
+		{"1.0.0+build.1", "1.0.0", EcosystemNPM, 0},
+		{"2.17.0", "2.17", EcosystemMaven, 0},
+		{"1.0-alpha-1", "1.0", EcosystemMaven, -1},
+		{"1.0-SNAPSHOT", "1.0", EcosystemMaven, -1},
+		{"1.0-rc1", "1.0-SNAPSHOT", EcosystemMaven, -1},
+		{"5.3.20.RELEASE", "5.3.20", EcosystemMaven, 0},
+		{"1.0-sp1", "1.0", EcosystemMaven, 1},
+		{"2.0.0rc1", "2.0.0", EcosystemPyPI, -1},
+		{"2.0.0.dev1", "2.0.0a1", EcosystemPyPI, -1},
+		{"2.0.0.post1", "2.0.0", EcosystemPyPI, 1},
+		{"1!1.0", "2.0", EcosystemPyPI, 1},
+	}
+	for _, c := range cases {
+		got, err := CompareVersions(c.a, c.b, c.ecosystem)
+		if err != nil {
+			t.Fatalf("CompareVersions(%q, %q, %s) error: %v", c.a, c.b, c.ecosystem, err)
+		}
+		if got != c.want {
+			t.Errorf("CompareVersions(%q, %q, %s) = %d, want %d", c.a, c.b, c.ecosystem, got, c.want)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("v2.17.1-beta", EcosystemNPM)
+	if err != nil {
+		t.Fatalf("ParseVersion error: %v", err)
+	}
+	if v.Major() != 2 || v.Minor() != 17 || v.Patch() != 1 || !v.IsPrerelease() {
+		t.Errorf("unexpected version: %+v", v)
+	}
+	if _, err := ParseVersion("not-a-version", EcosystemNPM); err == nil {
+		t.Error("expected error for invalid version")
+	}
+}
+
+func TestParseVersionRange(t *testing.T) {
+	cases := []struct {
+		raw        string
+		ecosystem  string
+		normalized string
+	}{
+		{"^4.17.0", EcosystemNPM, ">=4.17.0, <5.0.0"},
+		{"^0.2.3", EcosystemNPM, ">=0.2.3, <0.3.0"},
+		{"~2.1", EcosystemNPM, ">=2.1.0, <2.2.0"},
+		{"1.x", EcosystemNPM, ">=1.0.0, <2.0.0"},
+		{">= 1.0 < 2.0 || 3.0.0", EcosystemNPM, ">=1.0.0, <2.0.0 || =3.0.0"},
+		{"1.2.3 - 2.3", EcosystemNPM, ">=1.2.3, <2.4.0"},
+		{"*", EcosystemNPM, "*"},
+		{"~1.2", EcosystemComposer, ">=1.2.0, <2.0.0"},
+		{"^5.4|^6.0", EcosystemComposer, ">=5.4.0, <6.0.0 || >=6.0.0, <7.0.0"},
+		{">=2.0,<3.0", EcosystemPyPI, ">=2.0, <3.0"},
+		{"~=2.2.1", EcosystemPyPI, ">=2.2.1, <2.3"},
+		{"==1.4.*", EcosystemPyPI, ">=1.4, <1.5"},
+		{"[1.0,2.0)", EcosystemMaven, ">=1.0, <2.0"},
+		{"(,1.0],[1.2,)", EcosystemMaven, "<=1.0 || >=1.2"},
+		{"[1.2.17]", EcosystemMaven, "=1.2.17"},
+	}
+	for _, c := range cases {
+		r, err := ParseVersionRange(c.raw, c.ecosystem)
+		if err != nil {
+			t.Fatalf("ParseVersionRange(%q, %s) error: %v", c.raw, c.ecosystem, err)
+		}
+		if r.Normalized != c.normalized {
+			t.Errorf("ParseVersionRange(%q, %s) = %q, want %q", c.raw, c.ecosystem, r.Normalized, c.normalized)
+		}
+	}
+}
+
+func TestVersionInRange(t *testing.T) {
+	cases := []struct {
+		version, versionRange, ecosystem string
+		want                             bool
+	}{
+		{"2.1.5", "~2.1", EcosystemNPM, true},
+		{"2.2.0", "~2.1", EcosystemNPM, false},
+		{"1.2.83", ">=1.2.9", EcosystemNPM, true},
+		{"1.9.0", "~1.2", EcosystemComposer, true},
+		{"2.3.0", "~=2.2.1", EcosystemPyPI, false},
+		{"1.3.0", "!=1.3.*", EcosystemPyPI, false},
+		{"1.1", "(,1.0],[1.2,)", EcosystemMaven, false},
+		{"2.14.1", "[2.0,2.15.0)", EcosystemMaven, true},
+	}
+	for _, c := range cases {
+		got, err := VersionInRange(c.version, c.versionRange, c.ecosystem)
+		if err != nil {
+			t.Fatalf("VersionInRange(%q, %q) error: %v", c.version, c.versionRange, err)
+		}
+		if got != c.want {
+			t.Errorf("VersionInRange(%q, %q, %s) = %v, want %v", c.version, c.versionRange, c.ecosystem, got, c.want)
+		}
+	}
+}
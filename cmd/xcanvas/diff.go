@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/canvas"
+	"github.com/winezer0/xcanvas/internal/reportdiff"
+)
+
+// runDiff 执行 diff 子命令：对比两份 JSON 报告并输出差异，返回进程退出码
+func runDiff(args []string) int {
+	opts := &DiffOptions{}
+	parser := flags.NewParser(opts, flags.Default)
+	parser.Name = AppName + " diff"
+	parser.Usage = "[DIFF-OPTIONS]"
+	parser.ShortDescription = "Compare two json reports"
+	if _, err := parser.ParseArgs(args); err != nil {
+		var flagsErr *flags.Error
+		if errors.As(err, &flagsErr) && errors.Is(flagsErr.Type, flags.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+
+	diff, err := canvas.DiffReportFiles(opts.Args.Old, opts.Args.New, camodels.DiffOptions{
+		MinLineChange:  opts.MinLines,
+		MinLinePercent: opts.MinPercent,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error:%v\n", err)
+		return exitError
+	}
+
+	var data []byte
+	switch opts.Format {
+	case formatJSON:
+		if data, err = reportdiff.JSON(diff); err != nil {
+			fmt.Fprintf(os.Stderr, "Error:marshal diff error: %v\n", err)
+			return exitError
+		}
+		data = append(data, '\n')
+	case formatMarkdown:
+		data = reportdiff.Markdown(diff)
+	default:
+		data = reportdiff.Text(diff)
+	}
+
+	if opts.Output == "" {
+		os.Stdout.Write(data)
+		return exitOK
+	}
+	if err := os.WriteFile(opts.Output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error:write diff file error: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/canvas"
+	"github.com/winezer0/xcanvas/internal/reporthistory"
+)
+
+// runHistory 执行 history 子命令：分析仓库的多个修订版本，输出技术栈时间线，返回进程退出码
+func runHistory(args []string) int {
+	opts := &HistoryOptions{}
+	parser := flags.NewParser(opts, flags.Default)
+	parser.Name = AppName + " history"
+	parser.Usage = "[HISTORY-OPTIONS]"
+	parser.ShortDescription = "Technology timeline across git history"
+	if _, err := parser.ParseArgs(args); err != nil {
+		var flagsErr *flags.Error
+		if errors.As(err, &flagsErr) && errors.Is(flagsErr.Type, flags.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+
+	// 初始化日志器
+	logCfg := slogs.NewConfig(opts.LogLevel, opts.LogFile, opts.LogConsole)
+	if err := slogs.Init(logCfg); err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		return exitError
+	}
+	defer slogs.CloseAll()
+
+	options, err := analyzeOptions(opts.NoIgnore, opts.NoIgnoreSet)
+	if err != nil {
+		slogs.Errorf("%v\n", err)
+		return exitError
+	}
+	analyzeOpts := canvas.HistoryOptions{
+		Options: options,
+		Tags:    opts.Tags,
+		Ref:     opts.Ref,
+		Samples: opts.Samples,
+	}
+	history, err := canvas.AnalyzeHistory(context.Background(), opts.ProjectPath, opts.RulesDir, analyzeOpts)
+	if err != nil {
+		slogs.Errorf("Error analyzing history: %v\n", err)
+		return exitError
+	}
+
+	// 输出命令行时间线
+	os.Stdout.Write(reporthistory.Text(history))
+	if opts.Output == "" {
+		return exitOK
+	}
+
+	// 输出结果文件
+	var data []byte
+	switch opts.Format {
+	case formatMarkdown:
+		data = reporthistory.Markdown(history)
+	case formatText:
+		data = reporthistory.Text(history)
+	default:
+		if data, err = reporthistory.JSON(history); err != nil {
+			slogs.Errorf("marshal history error: %v", err)
+			return exitError
+		}
+	}
+	if err := os.WriteFile(opts.Output, data, 0644); err != nil {
+		slogs.Errorf("write history file error: %v", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/winezer0/xcanvas/canvas"
+)
+
+// runLint 执行 lint 子命令：检查语言规则文件之间的冲突，存在冲突时返回 exitError
+func runLint(args []string) int {
+	opts := &LintOptions{}
+	parser := flags.NewParser(opts, flags.Default)
+	parser.Name = AppName + " lint"
+	parser.Usage = "[LINT-OPTIONS]"
+	parser.ShortDescription = "Check language rules for conflicts"
+	if _, err := parser.ParseArgs(args); err != nil {
+		var flagsErr *flags.Error
+		if errors.As(err, &flagsErr) && errors.Is(flagsErr.Type, flags.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+
+	conflicts, err := canvas.LintLanguageRules(opts.LangRules)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error:%v\n", err)
+		return exitError
+	}
+	for _, conflict := range conflicts {
+		fmt.Println(conflict)
+	}
+	if len(conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "%d language rule conflicts\n", len(conflicts))
+		return exitError
+	}
+	fmt.Println("no language rule conflicts")
+	return exitOK
+}
@@ -2,42 +2,167 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
+	"slices"
 
 	"github.com/winezer0/slogs"
 
+	"github.com/winezer0/xcanvas/camodels"
 	"github.com/winezer0/xcanvas/canvas"
+	"github.com/winezer0/xcanvas/internal/sbom"
+)
+
+// 进程退出码
+const (
+	exitOK            = 0 // 分析完成且没有策略违规（或只有 warn 违规且未指定 --fail-on-warn）
+	exitError         = 1 // 分析或数据加载失败
+	exitPolicyWarn    = 2 // 存在 warn 违规且指定了 --fail-on-warn
+	exitPolicyDeny    = 3 // 存在 deny 违规
+	exitPolicyInvalid = 4 // 策略文件无法读取或校验失败
 )
 
 func main() {
+	// 子命令
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			os.Exit(runDiff(os.Args[2:]))
+		case "history":
+			os.Exit(runHistory(os.Args[2:]))
+		case "lint":
+			os.Exit(runLint(os.Args[2:]))
+		}
+	}
+
 	// 打印命令行输入配置
 	opts, _ := InitOptionsArgs(1)
 
+	// 分析前加载策略文件，避免策略错误时白白执行分析
+	var policy *camodels.Policy
+	if opts.Policy != "" {
+		var err error
+		if policy, err = canvas.LoadPolicy(opts.Policy); err != nil {
+			slogs.Errorf("Error loading policy: %v\n", err)
+			os.Exit(exitPolicyInvalid)
+		}
+	}
+
 	// Analyze operation
-	report, err := canvas.Analyze(opts.ProjectPath, opts.RulesDir)
+	analyzeOpts, err := analyzeOptions(opts.NoIgnore, opts.NoIgnoreSet)
+	if err != nil {
+		slogs.Errorf("%v\n", err)
+		os.Exit(exitError)
+	}
+	analyzeOpts.GitRef = opts.GitRef
+	analyzeOpts.SkipVendored = opts.SkipVendored
+	analyzeOpts.CountGenerated = opts.CountGenerated
+	report, err := canvas.AnalyzeWithContext(context.Background(), opts.ProjectPath, opts.RulesDir, analyzeOpts)
 	if err != nil {
 		slogs.Errorf("Error analyzing code profile: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	report.Version = AppVersion
+
+	// 使用自定义生命周期数据重新标注
+	if opts.Lifecycle != "" {
+		if err := canvas.ApplyLifecycle(report, opts.Lifecycle); err != nil {
+			slogs.Errorf("Error loading lifecycle data: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+
+	// 离线匹配安全公告
+	if opts.Advisories != "" {
+		if err := canvas.MatchAdvisories(report, opts.Advisories); err != nil {
+			slogs.Errorf("Error matching advisories: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+
+	// 评估策略规则（在生命周期和公告之后，以便规则使用 EOL 状态）
+	var denied, warned bool
+	if policy != nil {
+		denied, warned = canvas.EvaluatePolicy(report, policy)
 	}
 
 	// 输出命令行报告
 	PrintCanvasReport(report)
-	// 输出Json结果
-	saveJSON(opts.Output, report)
+	// 输出结果文件
+	saveReport(opts.Output, opts.Format, report)
+
+	os.Exit(exitCode(denied, warned, opts.FailOnWarn))
+}
+
+// exitCode 根据策略违规情况返回退出码，deny 优先于 warn
+func exitCode(denied, warned, failOnWarn bool) int {
+	switch {
+	case denied:
+		return exitPolicyDeny
+	case warned && failOnWarn:
+		return exitPolicyWarn
+	}
+	return exitOK
 }
 
-// saveJSON 将结果序列化为JSON并写入文件
-func saveJSON(path string, v any) {
+// analyzeOptions 返回默认分析选项，noIgnore 关闭忽略文件，disabledSets 中的内置忽略规则集（"all" 表示全部）不再生效
+func analyzeOptions(noIgnore bool, disabledSets []string) (canvas.Options, error) {
+	opts := canvas.DefaultOptions()
+	opts.IgnoreFiles = !noIgnore
+	for _, name := range disabledSets {
+		if name == "all" {
+			opts.IgnoreSets = nil
+			continue
+		}
+		if !slices.Contains(canvas.IgnoreSetNames(), name) {
+			return opts, fmt.Errorf("unknown ignore set %q", name)
+		}
+		opts.IgnoreSets = slices.DeleteFunc(opts.IgnoreSets, func(set string) bool { return set == name })
+	}
+	return opts, nil
+}
+
+// 输出文件格式
+const (
+	formatJSON          = "json"
+	formatCycloneDXJSON = "cyclonedx-json"
+	formatCycloneDXXML  = "cyclonedx-xml"
+	formatSPDXJSON      = "spdx-json"
+	formatSPDXTag       = "spdx-tag"
+	formatMarkdown      = "markdown" // diff / history
+	formatText          = "text"     // diff / history
+)
+
+// saveReport 将结果按指定格式序列化并写入文件
+func saveReport(path, format string, report *camodels.CanvasReport) {
 	if path == "" {
+		if format != formatJSON {
+			slogs.Warnf("output format %s ignored: no output file given (-o)", format)
+		}
 		return
 	}
-	data, err := json.MarshalIndent(v, "", "  ")
+	var data []byte
+	var err error
+	switch format {
+	case formatCycloneDXJSON:
+		data, err = sbom.CycloneDXJSON(report)
+	case formatCycloneDXXML:
+		data, err = sbom.CycloneDXXML(report)
+	case formatSPDXJSON:
+		data, err = sbom.SPDXJSON(report)
+	case formatSPDXTag:
+		data, err = sbom.SPDXTagValue(report)
+	default:
+		data, err = json.MarshalIndent(report, "", "  ")
+	}
 	if err != nil {
-		slogs.Errorf("marshal json error: %v", err)
+		slogs.Errorf("marshal %s error: %v", format, err)
 		return
 	}
 	if err := os.WriteFile(path, data, 0644); err != nil {
-		slogs.Errorf("write json file error: %v", err)
+		slogs.Errorf("write %s file error: %v", format, err)
 	}
 }
@@ -20,9 +20,19 @@ const (
 // Options defines the command-line parameters for CodeCanvas.
 type Options struct {
 	// Analysis parameters
-	ProjectPath string `short:"p" long:"path" description:"path to the codebase to analyze"`
-	RulesDir    string `short:"r" long:"rules" description:"detection rules dir path" default:""`
-	Output      string `short:"o" long:"output" description:"write json to file"`
+	ProjectPath    string   `short:"p" long:"path" description:"path to the codebase to analyze"`
+	RulesDir       string   `short:"r" long:"rules" description:"detection rules dir path" default:""`
+	Output         string   `short:"o" long:"output" description:"write report to file"`
+	GitRef         string   `long:"git-ref" description:"analyze this revision (branch, tag or commit) of the git repository at path (bare repos supported) instead of the working directory"`
+	NoIgnore       bool     `long:"no-ignore" description:"do not honour .gitignore and .xcanvasignore files"`
+	NoIgnoreSet    []string `long:"no-ignore-set" description:"disable a built-in ignore set (node/go/php/ruby/java/python/rust/dotnet/build, or all); repeatable"`
+	SkipVendored   bool     `long:"skip-vendored" description:"skip dependency dirs (vendor/, node_modules/, ...) instead of reporting them as vendored code"`
+	CountGenerated bool     `long:"count-generated" description:"count generated and minified files in the main statistics instead of reporting them separately"`
+	Lifecycle      string   `long:"lifecycle" description:"lifecycle (end-of-life) yaml file or dir overriding the bundled dataset"`
+	Advisories     string   `long:"advisories" description:"offline OSV advisories (dir, zip or json file) to match dependency versions against"`
+	Policy         string   `long:"policy" description:"policy yaml file with deny/warn rules; exit 3 on deny violations, 4 on an invalid policy"`
+	FailOnWarn     bool     `long:"fail-on-warn" description:"exit 2 when only warn policy violations are found"`
+	Format         string   `short:"f" long:"format" description:"output file format (json/cyclonedx-json/cyclonedx-xml/spdx-json/spdx-tag)" default:"json" choice:"json" choice:"cyclonedx-json" choice:"cyclonedx-xml" choice:"spdx-json" choice:"spdx-tag"`
 
 	// 日志参数（中文描述）
 	LogFile    string `long:"lf" description:"log file path (if empty, no file will be written)"`
@@ -31,12 +41,47 @@ type Options struct {
 	Version    bool   `short:"v" long:"version" description:"show version"`
 }
 
+// DiffOptions defines the parameters of the diff command.
+type DiffOptions struct {
+	Format     string  `short:"f" long:"format" description:"diff output format (text/json/markdown)" default:"text" choice:"text" choice:"json" choice:"markdown"`
+	Output     string  `short:"o" long:"output" description:"write diff to file instead of stdout"`
+	MinLines   int     `long:"min-lines" description:"minimum code line change of a language to report" default:"100"`
+	MinPercent float64 `long:"min-percent" description:"minimum code line change of a language in percent to report" default:"10"`
+
+	Args struct {
+		Old string `positional-arg-name:"OLD.json" description:"old json report"`
+		New string `positional-arg-name:"NEW.json" description:"new json report"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// HistoryOptions defines the parameters of the history command.
+type HistoryOptions struct {
+	ProjectPath string   `short:"p" long:"path" description:"path to the local git repository (bare repos supported)" required:"yes"`
+	RulesDir    string   `short:"r" long:"rules" description:"detection rules dir path" default:""`
+	Tags        bool     `long:"tags" description:"analyze every tag instead of sampling commits"`
+	Ref         string   `long:"ref" description:"branch or commit whose first-parent history is sampled" default:"HEAD"`
+	Samples     int      `long:"samples" description:"number of commits sampled evenly from the history of --ref (0 analyzes every commit)" default:"10"`
+	NoIgnore    bool     `long:"no-ignore" description:"do not honour .gitignore and .xcanvasignore files"`
+	NoIgnoreSet []string `long:"no-ignore-set" description:"disable a built-in ignore set (node/go/php/ruby/java/python/rust/dotnet/build, or all); repeatable"`
+	Output      string   `short:"o" long:"output" description:"write timeline to file"`
+	Format      string   `short:"f" long:"format" description:"output file format (json/markdown/text)" default:"json" choice:"json" choice:"markdown" choice:"text"`
+
+	LogFile    string `long:"lf" description:"log file path (if empty, no file will be written)"`
+	LogLevel   string `long:"ll" description:"log level (debug/info/warn/error)" default:"info"`
+	LogConsole string `long:"lc" description:"log format for console(TLCM OR off|null）" default:"LM"`
+}
+
+// LintOptions defines the parameters of the lint command.
+type LintOptions struct {
+	LangRules string `long:"lang-rules" description:"dir of language rule yaml files to lint (bundled rules if empty)"`
+}
+
 // InitOptionsArgs 常用的工具函数，解析parser和logging配置
 func InitOptionsArgs(minimumParams int) (*Options, *flags.Parser) {
 	opts := &Options{}
 	parser := flags.NewParser(opts, flags.Default)
 	parser.Name = AppName
-	parser.Usage = "[OPTIONS]"
+	parser.Usage = "[OPTIONS]\n  xcanvas diff [DIFF-OPTIONS] OLD.json NEW.json\n  xcanvas history [HISTORY-OPTIONS]\n  xcanvas lint [LINT-OPTIONS]"
 	parser.ShortDescription = AppShortDesc
 	parser.LongDescription = AppLongDesc
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/winezer0/xcanvas/camodels"
@@ -12,8 +13,23 @@ func PrintCanvasReport(report *camodels.CanvasReport) {
 	fmt.Println("CodeCanvas Analysis Report")
 	fmt.Println("=========================")
 	fmt.Printf("Path: %s\n", report.CodeProfile.Path)
+	if report.Revision != nil {
+		fmt.Printf("Revision: %s (%s, %s)\n", report.Revision.Ref, report.Revision.Commit, report.Revision.CommitTime.Format(time.RFC3339))
+	}
 	fmt.Printf("Total Files: %d\n", report.CodeProfile.TotalFiles)
 	fmt.Printf("Total Lines: %d\n", report.CodeProfile.TotalLines)
+	for _, group := range []struct {
+		label string
+		stats *camodels.CodeStats
+	}{
+		{"Vendored", report.CodeProfile.Vendored},
+		{"Generated", report.CodeProfile.Generated},
+		{"Minified", report.CodeProfile.Minified},
+	} {
+		if group.stats != nil {
+			fmt.Printf("%s: %d files, %d lines (excluded from totals)\n", group.label, group.stats.TotalFiles, group.stats.TotalLines)
+		}
+	}
 	fmt.Println()
 
 	// Frontend languages
@@ -73,9 +89,122 @@ func PrintCanvasReport(report *camodels.CanvasReport) {
 		fmt.Printf("Detected Components Is Empty !!!\n")
 	}
 
+	// End-of-life technologies
+	printEndOfLife(report.Detection.EndOfLifeItems())
+
+	// Dependency inventory (summary only, full list is in the json output)
+	printDependencySummary(report.Dependencies)
+
+	// Advisory findings (only when an advisory database is given)
+	if len(report.Findings) > 0 {
+		printFindings(report.Findings)
+	}
+
+	// Policy violations (only when a policy file is given)
+	if len(report.Violations) > 0 {
+		printViolations(report.Violations)
+	}
+
 	fmt.Printf("Generated: %s\n", report.Timestamp.Format(time.RFC1123))
 }
 
+// printDependencySummary prints the number of inventory dependencies per ecosystem.
+func printDependencySummary(dependencies []camodels.Dependency) {
+	if len(dependencies) == 0 {
+		fmt.Printf("Dependency Inventory Is Empty !!!\n")
+		return
+	}
+	var ecosystems []string
+	counts := make(map[string]int)
+	for _, dependency := range dependencies {
+		if counts[dependency.Ecosystem] == 0 {
+			ecosystems = append(ecosystems, dependency.Ecosystem)
+		}
+		counts[dependency.Ecosystem]++
+	}
+	fmt.Printf("Dependency Inventory: %d dependencies\n", len(dependencies))
+	for _, ecosystem := range ecosystems {
+		fmt.Printf("  - %s: %d\n", ecosystem, counts[ecosystem])
+	}
+	fmt.Println()
+}
+
+// printEndOfLife prints the detected frameworks and components whose release cycle is no longer supported.
+func printEndOfLife(items []camodels.DetectedItem) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Printf("End-of-Life Technologies: %d\n", len(items))
+	for _, item := range items {
+		fmt.Printf("  - %s %s (%s)%s\n", item.Name, item.Version, item.Language, lifecycleSummary(item))
+	}
+	fmt.Println()
+}
+
+// lifecycleSummary describes the release cycle of an item, empty when no lifecycle data matched.
+func lifecycleSummary(item camodels.DetectedItem) string {
+	var parts []string
+	if item.Cycle != "" {
+		parts = append(parts, "cycle "+item.Cycle)
+	}
+	switch {
+	case item.EOL && item.SupportedUntil != "":
+		parts = append(parts, "EOL since "+item.SupportedUntil)
+	case item.EOL:
+		parts = append(parts, "EOL")
+	case item.SupportedUntil != "":
+		parts = append(parts, "supported until "+item.SupportedUntil)
+	}
+	if item.LatestInCycle != "" {
+		parts = append(parts, "latest "+item.LatestInCycle)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(parts, ", ")
+}
+
+// printFindings prints the advisories matching dependency versions, most severe first.
+func printFindings(findings []camodels.Finding) {
+	fmt.Printf("Vulnerability Findings: %d\n", len(findings))
+	for _, finding := range findings {
+		severity := finding.Severity
+		if finding.Score > 0 {
+			severity = fmt.Sprintf("%s %.1f", finding.Severity, finding.Score)
+		}
+		fmt.Printf("  - [%s] %s %s@%s (%s)\n", severity, finding.ID, finding.Package, finding.Version, finding.Ecosystem)
+		if finding.Summary != "" {
+			fmt.Printf("    Summary: %s\n", finding.Summary)
+		}
+		if len(finding.Aliases) > 0 {
+			fmt.Printf("    Aliases: %s\n", strings.Join(finding.Aliases, ", "))
+		}
+		if len(finding.FixedVersions) > 0 {
+			fmt.Printf("    Fixed: %s\n", strings.Join(finding.FixedVersions, ", "))
+		}
+		if len(finding.Files) > 0 {
+			fmt.Printf("    Files: %s\n", strings.Join(finding.Files, ", "))
+		}
+	}
+	fmt.Println()
+}
+
+// printViolations prints the policy violations in rule order.
+func printViolations(violations []camodels.Violation) {
+	fmt.Printf("Policy Violations: %d\n", len(violations))
+	for _, violation := range violations {
+		target := violation.Target
+		if violation.Version != "" {
+			target += "@" + violation.Version
+		}
+		fmt.Printf("  - [%s] %s: %s (%s)\n", strings.ToUpper(violation.Action), violation.RuleID, target, violation.Type)
+		if violation.Message != "" {
+			fmt.Printf("    Message: %s\n", violation.Message)
+		}
+	}
+	fmt.Println()
+}
+
 func printDetectedItems(title string, items []camodels.DetectedItem) {
 	fmt.Println(title + ":")
 
@@ -111,9 +240,16 @@ func printCategoryItems(category string, items []camodels.DetectedItem) {
 		fmt.Printf("  [%s]\n", category)
 		for _, item := range items {
 			fmt.Printf("  - %s (%s)\n", item.Name, item.Language)
-			if item.Version != "" {
+			if len(item.Versions) > 0 {
+				for _, info := range item.Versions {
+					fmt.Printf("    Version: %s (%s: %s in %s)\n", info.Version, info.Source, info.Raw, strings.Join(info.Files, ", "))
+				}
+			} else if item.Version != "" {
 				fmt.Printf("    Version: %s\n", item.Version)
 			}
+			if summary := lifecycleSummary(item); summary != "" {
+				fmt.Printf("    Lifecycle%s\n", summary)
+			}
 			if item.Evidence != "" {
 				fmt.Printf("    Evidence: %s\n", item.Evidence)
 			}
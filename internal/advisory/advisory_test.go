@@ -0,0 +1,209 @@
+package advisory
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// testAdvisories OSV 格式的测试公告
+var testAdvisories = map[string]string{
+	"maven/GHSA-fastjson.json": `{"id": "GHSA-fastjson", "summary": "fastjson deserialization", "aliases": ["CVE-2022-25845"],
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+		"affected": [{"package": {"ecosystem": "Maven", "name": "com.alibaba:fastjson"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.2.83"}]}]}],
+		"references": [{"type": "ADVISORY", "url": "https://github.com/advisories/GHSA-fastjson"}]}`,
+	"maven/GHSA-log4j.json": `{"id": "GHSA-log4j", "summary": "Log4Shell",
+		"affected": [{"package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "2.0-beta9"}, {"fixed": "2.3.1"}, {"introduced": "2.4"}, {"fixed": "2.12.2"}, {"introduced": "2.13.0"}, {"fixed": "2.15.0"}]}],
+			"database_specific": {"severity": "CRITICAL"}}]}`,
+	"pypi/PYSEC-django.json": `[{"id": "PYSEC-django", "details": "Django SQL injection\nmore details",
+		"affected": [{"package": {"ecosystem": "PyPI", "name": "Django"}, "versions": ["3.2.1", "3.2.2"]}],
+		"database_specific": {"severity": "MODERATE"}}]`,
+	"go/GO-gin.json": `{"id": "GO-gin", "affected": [{"package": {"ecosystem": "Go", "name": "github.com/gin-gonic/gin"},
+		"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"last_affected": "1.9.0"}]}]}]}`,
+	"npm/withdrawn.json": `{"id": "GHSA-withdrawn", "withdrawn": "2023-01-01T00:00:00Z",
+		"affected": [{"package": {"ecosystem": "npm", "name": "react"}, "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}]}]}]}`,
+	"debian/DSA.json": `{"id": "DSA-1", "affected": [{"package": {"ecosystem": "Debian:11", "name": "openssl"}, "versions": ["1.1.1"]}]}`,
+	"README.md":       "not an advisory",
+}
+
+// testReport 构建包含依赖清单和检测结果的测试报告
+func testReport() *camodels.CanvasReport {
+	return &camodels.CanvasReport{
+		Dependencies: []camodels.Dependency{
+			{Ecosystem: camodels.EcosystemMaven, Name: "com.alibaba:fastjson", Version: "1.2.24", Source: camodels.VersionSourcePinned, File: "pom.xml"},
+			{Ecosystem: camodels.EcosystemMaven, Name: "com.alibaba:fastjson", Version: "1.2.24", Source: camodels.VersionSourcePinned, File: "web/pom.xml"},
+			{Ecosystem: camodels.EcosystemMaven, Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", File: "pom.xml"},
+			{Ecosystem: camodels.EcosystemMaven, Name: "org.apache.logging.log4j:log4j-api", Version: "2.14.1", File: "pom.xml"},
+			{Ecosystem: camodels.EcosystemPyPI, Name: "django", Version: "3.2.2", File: "requirements.txt"},
+			{Ecosystem: camodels.EcosystemGo, Name: "github.com/gin-gonic/gin", Version: "1.9.1", File: "go.mod"},
+			{Ecosystem: camodels.EcosystemNPM, Name: "react", Version: "18.2.0", File: "package.json"},
+		},
+		Detection: camodels.DetectionInfo{
+			Components: []camodels.DetectedItem{{
+				Name: "fastjson", Type: camodels.RuleTypeComponent, Language: "Java",
+				Versions: []*camodels.VersionInfo{{Version: "1.2.24", Name: "com.alibaba:fastjson", Files: []string{"pom.xml", "lib/pom.xml"}}},
+			}},
+		},
+	}
+}
+
+func writeAdvisories(t *testing.T, dir string) {
+	t.Helper()
+	for name, content := range testAdvisories {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestMatchDirectory tests loading a directory of advisories and matching versions by range and version list
+func TestMatchDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeAdvisories(t, dir)
+	db, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if db.Count() != 4 {
+		t.Errorf("Count() = %d, want 4", db.Count())
+	}
+
+	findings := db.Match(testReport())
+	if len(findings) != 3 {
+		t.Fatalf("findings = %+v, want 3", findings)
+	}
+	// 按严重程度排序：CRITICAL（log4j、fastjson 9.8），MEDIUM（django）
+	fastjson, log4j, django := findings[0], findings[1], findings[2]
+	if fastjson.ID != "GHSA-fastjson" || fastjson.Severity != camodels.SeverityCritical || fastjson.Score != 9.8 ||
+		fastjson.Detected != "fastjson" || len(fastjson.FixedVersions) != 1 || fastjson.FixedVersions[0] != "1.2.83" ||
+		len(fastjson.Files) != 3 || len(fastjson.References) != 1 || fastjson.Aliases[0] != "CVE-2022-25845" {
+		t.Errorf("fastjson finding = %+v", fastjson)
+	}
+	if log4j.ID != "GHSA-log4j" || log4j.Severity != camodels.SeverityCritical || log4j.Score != 0 ||
+		len(log4j.FixedVersions) != 3 || log4j.Detected != "" {
+		t.Errorf("log4j finding = %+v", log4j)
+	}
+	if django.ID != "PYSEC-django" || django.Severity != camodels.SeverityMedium || django.Summary != "Django SQL injection" {
+		t.Errorf("django finding = %+v", django)
+	}
+}
+
+// TestMatchSkipsRanges tests that range lower bounds are not matched as installed versions
+func TestMatchSkipsRanges(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "GHSA-lodash.json")
+	content := `{"id": "GHSA-lodash", "affected": [{"package": {"ecosystem": "npm", "name": "lodash"},
+		"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]}]}`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	db, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+
+	// 锁文件解析出已修复的 4.17.21，"^4.17.0" 的声明和按声明下界提取的检测项版本不产生结果
+	report := &camodels.CanvasReport{
+		Dependencies: []camodels.Dependency{
+			{Ecosystem: camodels.EcosystemNPM, Name: "lodash", Version: "4.17.21", Raw: "^4.17.0", Source: camodels.VersionSourceResolved, File: "package.json"},
+			{Ecosystem: camodels.EcosystemNPM, Name: "lodash", Version: "4.17.0", Raw: "^4.17.0", Source: camodels.VersionSourceRange, File: "tools/package.json"},
+		},
+		Detection: camodels.DetectionInfo{
+			Components: []camodels.DetectedItem{{
+				Name: "lodash", Type: camodels.RuleTypeComponent, Language: "JavaScript",
+				Versions: []*camodels.VersionInfo{{Version: "4.17.0", Raw: "^4.17.0", Source: camodels.VersionSourceRange, Name: "lodash", File: "package.json"}},
+			}},
+		},
+	}
+	if findings := db.Match(report); len(findings) != 0 {
+		t.Errorf("findings = %+v, want none", findings)
+	}
+
+	// 固定版本仍然匹配
+	report.Dependencies[1].Source = camodels.VersionSourcePinned
+	if findings := db.Match(report); len(findings) != 1 || findings[0].Version != "4.17.0" || findings[0].Files[0] != "tools/package.json" {
+		t.Errorf("findings = %+v, want the pinned 4.17.0", findings)
+	}
+}
+
+// TestLoadZip tests loading advisories from a zip archive
+func TestLoadZip(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "all.zip")
+	out, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(out)
+	for name, content := range testAdvisories {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out.Close()
+
+	db, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if db.Count() != 4 || len(db.Match(testReport())) != 3 {
+		t.Errorf("zip database: count=%d findings=%d", db.Count(), len(db.Match(testReport())))
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Load of missing path should fail")
+	}
+}
+
+// TestInRange tests OSV range event evaluation
+func TestInRange(t *testing.T) {
+	events := []osvEvent{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "2.0.0"}, {LastAffected: "2.1.0"}}
+	testCases := map[string]bool{
+		"0.1.0": true,
+		"1.1.9": true,
+		"1.2.0": false,
+		"1.5.0": false,
+		"2.0.0": true,
+		"2.1.0": true,
+		"2.1.1": false,
+	}
+	for version, want := range testCases {
+		if got := inRange(events, version, camodels.VersionSchemeSemver); got != want {
+			t.Errorf("inRange(%s) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+// TestCVSS3BaseScore tests CVSS v3 base score calculation
+func TestCVSS3BaseScore(t *testing.T) {
+	testCases := map[string]float64{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H": 10.0,
+		"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:U/C:L/I:N/A:N": 4.3,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N": 6.1,
+		"CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:N/I:N/A:N": 0,
+	}
+	for vector, want := range testCases {
+		if got, ok := cvss3BaseScore(vector); !ok || got != want {
+			t.Errorf("cvss3BaseScore(%s) = %v, %v, want %v", vector, got, ok, want)
+		}
+	}
+	for _, vector := range []string{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", "CVSS:3.1/AV:N/AC:L"} {
+		if _, ok := cvss3BaseScore(vector); ok {
+			t.Errorf("cvss3BaseScore(%s) should fail", vector)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package advisory
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// target 待匹配的包版本
+type target struct {
+	ecosystem string
+	name      string
+	version   string
+	source    string
+	detected  string
+	files     []string
+}
+
+// Match 将报告中依赖清单的依赖和检测项（通过依赖名称提取到版本的）与公告库匹配，
+// 返回按严重程度排序的结果；同一公告、包和版本只输出一次，文件合并。
+// 来源为 range 的版本只是声明范围的下界，实际安装的版本可能已经修复，不参与匹配
+func (db *Database) Match(report *camodels.CanvasReport) []camodels.Finding {
+	var targets []target
+	for _, dependency := range report.Dependencies {
+		targets = append(targets, target{
+			ecosystem: dependency.Ecosystem,
+			name:      dependency.Name,
+			version:   dependency.Version,
+			source:    dependency.Source,
+			files:     []string{dependency.File},
+		})
+	}
+	items := append(append([]camodels.DetectedItem{}, report.Detection.Frameworks...), report.Detection.Components...)
+	for _, item := range items {
+		ecosystem := camodels.EcosystemForLanguage(item.Language)
+		for _, info := range item.Versions {
+			if info == nil || info.Name == "" {
+				continue
+			}
+			files := info.Files
+			if len(files) == 0 {
+				files = []string{info.File}
+			}
+			targets = append(targets, target{
+				ecosystem: ecosystem,
+				name:      info.Name,
+				version:   info.Version,
+				source:    info.Source,
+				detected:  item.Name,
+				files:     files,
+			})
+		}
+	}
+
+	var findings []camodels.Finding
+	index := make(map[string]int) // 公告编号 + 包 + 版本 -> findings 下标
+	for _, t := range targets {
+		if t.ecosystem == "" || t.name == "" || t.version == "" || t.source == camodels.VersionSourceRange {
+			continue
+		}
+		for _, e := range db.entries[packageKey(t.ecosystem, t.name)] {
+			fixed, ok := affects(e.affected, t.ecosystem, t.version)
+			if !ok {
+				continue
+			}
+			key := e.advisory.ID + "\x00" + packageKey(t.ecosystem, t.name) + "\x00" + t.version
+			if i, exists := index[key]; exists {
+				findings[i].Files = appendUnique(findings[i].Files, t.files...)
+				if findings[i].Detected == "" {
+					findings[i].Detected = t.detected
+				}
+				continue
+			}
+			severity, score := severityOf(e.advisory, e.affected)
+			finding := camodels.Finding{
+				ID:            e.advisory.ID,
+				Aliases:       e.advisory.Aliases,
+				Summary:       summaryOf(e.advisory),
+				Severity:      severity,
+				Score:         score,
+				Ecosystem:     t.ecosystem,
+				Package:       t.name,
+				Version:       t.version,
+				Source:        t.source,
+				FixedVersions: fixed,
+				Detected:      t.detected,
+				Files:         appendUnique(nil, t.files...),
+			}
+			for _, reference := range e.advisory.References {
+				finding.References = appendUnique(finding.References, reference.URL)
+			}
+			index[key] = len(findings)
+			findings = append(findings, finding)
+		}
+	}
+	camodels.SortFindings(findings)
+	return findings
+}
+
+// affects 判断版本是否受 affected 记录影响，受影响时同时返回相关范围中的修复版本。
+// 先匹配明确列出的 versions，再按 OSV 规范计算 SEMVER / ECOSYSTEM 范围，GIT 范围无法按版本号计算，会被忽略
+func affects(affected *osvAffected, ecosystem, version string) ([]string, bool) {
+	scheme := camodels.SchemeForEcosystem(ecosystem)
+	matched := false
+	for _, listed := range affected.Versions {
+		if equalVersion(listed, version, scheme) {
+			matched = true
+			break
+		}
+	}
+	var fixed []string
+	for _, r := range affected.Ranges {
+		rangeScheme := scheme
+		switch r.Type {
+		case "SEMVER":
+			rangeScheme = camodels.VersionSchemeSemver
+		case "ECOSYSTEM":
+		default:
+			continue
+		}
+		if !inRange(r.Events, version, rangeScheme) {
+			continue
+		}
+		matched = true
+		for _, event := range r.Events {
+			fixed = appendUnique(fixed, event.Fixed)
+		}
+	}
+	if !matched {
+		return nil, false
+	}
+	return fixed, true
+}
+
+// inRange 按 OSV 规范计算版本是否位于事件描述的受影响区间：事件按版本排序后依次处理，
+// introduced 进入受影响区间，fixed 和 last_affected 之后离开区间
+func inRange(events []osvEvent, version, scheme string) bool {
+	type point struct {
+		version string
+		kind    string
+	}
+	var points []point
+	for _, event := range events {
+		switch {
+		case event.Introduced != "":
+			points = append(points, point{event.Introduced, "introduced"})
+		case event.Fixed != "":
+			points = append(points, point{event.Fixed, "fixed"})
+		case event.LastAffected != "":
+			points = append(points, point{event.LastAffected, "last_affected"})
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return compare(points[i].version, points[j].version, scheme) < 0
+	})
+
+	affected := false
+	for _, p := range points {
+		c := compare(version, p.version, scheme)
+		switch p.kind {
+		case "introduced":
+			if c >= 0 {
+				affected = true
+			}
+		case "fixed":
+			if c >= 0 {
+				affected = false
+			}
+		case "last_affected":
+			if c > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+// compare 比较两个版本，"0" 表示最小版本；无法解析时按字符串比较
+func compare(a, b, scheme string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "0":
+		return -1
+	case b == "0":
+		return 1
+	}
+	if c, err := camodels.CompareVersions(strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v"), scheme); err == nil {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// equalVersion 判断两个版本是否相同，忽略 Go 版本的 "v" 前缀
+func equalVersion(a, b, scheme string) bool {
+	if a == b {
+		return true
+	}
+	c, err := camodels.CompareVersions(strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v"), scheme)
+	return err == nil && c == 0
+}
+
+// summaryOf 返回公告摘要，没有 summary 时取 details 的第一行
+func summaryOf(advisory *osvAdvisory) string {
+	if advisory.Summary != "" {
+		return advisory.Summary
+	}
+	summary, _, _ := strings.Cut(strings.TrimSpace(advisory.Details), "\n")
+	return summary
+}
+
+// appendUnique 追加非空且不重复的字符串
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if value != "" && !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
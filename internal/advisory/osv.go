@@ -0,0 +1,203 @@
+// Package advisory 加载本地 OSV 格式的安全公告，并按生态、名称和版本范围与分析结果进行离线匹配。
+package advisory
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/manifest"
+)
+
+// osvEcosystems OSV 生态名称对应的依赖生态
+var osvEcosystems = map[string]string{
+	"maven":     camodels.EcosystemMaven,
+	"npm":       camodels.EcosystemNPM,
+	"pypi":      camodels.EcosystemPyPI,
+	"packagist": camodels.EcosystemComposer,
+	"go":        camodels.EcosystemGo,
+}
+
+// osvAdvisory OSV 公告（https://ossf.github.io/osv-schema/）中与匹配相关的字段
+type osvAdvisory struct {
+	ID               string             `json:"id"`
+	Summary          string             `json:"summary"`
+	Details          string             `json:"details"`
+	Aliases          []string           `json:"aliases"`
+	Withdrawn        string             `json:"withdrawn"`
+	Severity         []osvSeverity      `json:"severity"`
+	Affected         []osvAffected      `json:"affected"`
+	References       []osvReference     `json:"references"`
+	DatabaseSpecific osvSpecificDetails `json:"database_specific"`
+}
+
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type osvAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges           []osvRange         `json:"ranges"`
+	Versions         []string           `json:"versions"`
+	Severity         []osvSeverity      `json:"severity"`
+	DatabaseSpecific osvSpecificDetails `json:"database_specific"`
+}
+
+type osvRange struct {
+	Type   string     `json:"type"` // SEMVER | ECOSYSTEM | GIT
+	Events []osvEvent `json:"events"`
+}
+
+type osvEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+type osvReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// osvSpecificDetails database_specific 中的严重程度（GitHub Advisory 等数据库提供）
+type osvSpecificDetails struct {
+	Severity string `json:"severity"`
+}
+
+// Database 按生态和包名索引的本地公告库
+type Database struct {
+	entries map[string][]entry // 生态 + 规范化包名 -> 影响该包的公告
+	count   int
+}
+
+// entry 公告中影响某个包的一条 affected 记录
+type entry struct {
+	advisory *osvAdvisory
+	affected *osvAffected
+}
+
+// Count 返回已加载的公告数量（不含已撤回的公告）
+func (db *Database) Count() int {
+	return db.count
+}
+
+// Load 从目录（递归读取 *.json）、zip 压缩包（例如 OSV 按生态导出的 all.zip）或单个 JSON 文件加载 OSV 公告，
+// 每个文件可以是单个公告或公告数组；无法解析的文件会被跳过，已撤回的公告和不支持的生态会被忽略
+func Load(path string) (*Database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("open advisories %s error: %w", path, err)
+	}
+	db := &Database{entries: make(map[string][]entry)}
+	switch {
+	case info.IsDir():
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(file), ".json") {
+				return walkErr
+			}
+			content, readErr := os.ReadFile(file)
+			if readErr != nil {
+				return readErr
+			}
+			db.add(file, content)
+			return nil
+		})
+	case strings.EqualFold(filepath.Ext(path), ".zip"):
+		err = db.loadZip(path)
+	default:
+		var content []byte
+		if content, err = os.ReadFile(path); err == nil {
+			db.add(path, content)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load advisories %s error: %w", path, err)
+	}
+	return db, nil
+}
+
+// loadZip 读取 zip 压缩包中的全部 *.json 文件
+func (db *Database) loadZip(path string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(filepath.Ext(file.Name), ".json") {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		db.add(file.Name, content)
+	}
+	return nil
+}
+
+// add 解析单个文件中的公告并加入索引
+func (db *Database) add(file string, content []byte) {
+	var advisories []*osvAdvisory
+	var single osvAdvisory
+	if err := json.Unmarshal(content, &single); err == nil {
+		advisories = append(advisories, &single)
+	} else if err := json.Unmarshal(content, &advisories); err != nil {
+		slogs.Debugf("parse advisory (%s) error: %v", file, err)
+		return
+	}
+	for _, advisory := range advisories {
+		if advisory == nil || advisory.ID == "" || advisory.Withdrawn != "" {
+			continue
+		}
+		indexed := false
+		for i := range advisory.Affected {
+			affected := &advisory.Affected[i]
+			ecosystem := osvEcosystem(affected.Package.Ecosystem)
+			if ecosystem == "" || affected.Package.Name == "" {
+				continue
+			}
+			key := packageKey(ecosystem, affected.Package.Name)
+			db.entries[key] = append(db.entries[key], entry{advisory: advisory, affected: affected})
+			indexed = true
+		}
+		if indexed {
+			db.count++
+		}
+	}
+}
+
+// osvEcosystem 返回 OSV 生态名称对应的依赖生态，忽略 "Debian:11" 等生态中的版本后缀，不支持的生态返回空字符串
+func osvEcosystem(name string) string {
+	name, _, _ = strings.Cut(name, ":")
+	return osvEcosystems[strings.ToLower(name)]
+}
+
+// packageKey 返回生态和规范化包名组成的索引键：PyPI 按 PEP 503 规范化，Composer 不区分大小写
+func packageKey(ecosystem, name string) string {
+	switch ecosystem {
+	case camodels.EcosystemPyPI:
+		name = manifest.NormalizePythonName(name)
+	case camodels.EcosystemComposer:
+		name = strings.ToLower(name)
+	}
+	return ecosystem + "\x00" + name
+}
@@ -0,0 +1,135 @@
+package advisory
+
+import (
+	"math"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// severityOf 返回公告对该包的严重程度和 CVSS 基础分：优先根据 CVSS v3 向量计算（affected 中的优先于公告级别），
+// 其次使用 database_specific.severity（例如 GitHub Advisory 的 MODERATE），都没有时为 UNKNOWN
+func severityOf(advisory *osvAdvisory, affected *osvAffected) (string, float64) {
+	for _, severities := range [][]osvSeverity{affected.Severity, advisory.Severity} {
+		for _, severity := range severities {
+			if severity.Type != "CVSS_V3" {
+				continue
+			}
+			if score, ok := cvss3BaseScore(severity.Score); ok {
+				return severityForScore(score), score
+			}
+		}
+	}
+	for _, name := range []string{affected.DatabaseSpecific.Severity, advisory.DatabaseSpecific.Severity} {
+		if severity := normalizeSeverity(name); severity != camodels.SeverityUnknown {
+			return severity, 0
+		}
+	}
+	return camodels.SeverityUnknown, 0
+}
+
+// normalizeSeverity 将数据库提供的严重程度名称规范化
+func normalizeSeverity(name string) string {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "CRITICAL":
+		return camodels.SeverityCritical
+	case "HIGH":
+		return camodels.SeverityHigh
+	case "MODERATE", "MEDIUM":
+		return camodels.SeverityMedium
+	case "LOW":
+		return camodels.SeverityLow
+	}
+	return camodels.SeverityUnknown
+}
+
+// severityForScore 按 CVSS v3 定性评级返回严重程度，0 分为 UNKNOWN
+func severityForScore(score float64) string {
+	switch {
+	case score >= 9.0:
+		return camodels.SeverityCritical
+	case score >= 7.0:
+		return camodels.SeverityHigh
+	case score >= 4.0:
+		return camodels.SeverityMedium
+	case score > 0:
+		return camodels.SeverityLow
+	}
+	return camodels.SeverityUnknown
+}
+
+// CVSS v3 基础指标权重
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvss3BaseScore 按 CVSS v3.0 / v3.1 规范根据向量（例如 "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"）计算基础分，
+// 向量不完整或无法识别时返回 false
+func cvss3BaseScore(vector string) (float64, bool) {
+	if !strings.HasPrefix(vector, "CVSS:3.") {
+		return 0, false
+	}
+	metrics := make(map[string]string)
+	for _, part := range strings.Split(vector, "/")[1:] {
+		if name, value, ok := strings.Cut(part, ":"); ok {
+			metrics[name] = value
+		}
+	}
+	scope := metrics["S"]
+	if scope != "U" && scope != "C" {
+		return 0, false
+	}
+	values := make(map[string]float64)
+	for name, weights := range cvss3Weights {
+		weight, ok := weights[metrics[name]]
+		if !ok {
+			return 0, false
+		}
+		values[name] = weight
+	}
+	var privileges float64
+	switch metrics["PR"] {
+	case "N":
+		privileges = 0.85
+	case "L":
+		privileges = 0.62
+		if scope == "C" {
+			privileges = 0.68
+		}
+	case "H":
+		privileges = 0.27
+		if scope == "C" {
+			privileges = 0.5
+		}
+	default:
+		return 0, false
+	}
+
+	iss := 1 - (1-values["C"])*(1-values["I"])*(1-values["A"])
+	impact := 6.42 * iss
+	if scope == "C" {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * values["AV"] * values["AC"] * privileges * values["UI"]
+	if scope == "C" {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), true
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), true
+}
+
+// roundUp CVSS v3.1 定义的向上取整到一位小数，避免浮点误差
+func roundUp(value float64) float64 {
+	scaled := int(math.Round(value * 100000))
+	if scaled%10000 == 0 {
+		return float64(scaled) / 100000
+	}
+	return float64(scaled/10000+1) / 10
+}
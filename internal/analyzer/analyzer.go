@@ -2,9 +2,13 @@
 package analyzer
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -30,13 +34,15 @@ func NewCodeAnalyzer() *CodeAnalyzer {
 
 // AnalysisTask 定义一个分析任务
 type AnalysisTask struct {
-	Path    string
+	Path    string // 磁盘文件的绝对路径，从 fs.FS 分析时为相对路径
 	LangDef *camodels.Language
+	Kind    FileKind // 按路径识别的文件分类，FileKindSource 的文件还会按内容识别
 }
 
 // AnalysisResult 定义分析结果
 type AnalysisResult struct {
 	LangName string
+	Kind     FileKind
 	Stats    FileStats
 	Err      error
 }
@@ -50,6 +56,7 @@ var (
 func init() {
 	// 初始化语言映射，直接使用新的语言规则
 	for _, language := range langengine.LanguageRules {
+		nameToLanguage[strings.ToLower(language.Name)] = &language
 		for _, ext := range language.Extensions {
 			extToLanguage[strings.ToLower(ext)] = &language
 		}
@@ -94,9 +101,41 @@ func (a *CodeAnalyzer) AnalyzeCodeProfileWithContext(
 	}
 
 	// Process collected tasks concurrently.
-	stats, errorFiles := a.processTasks(taskList)
+	stats, errorFiles := a.processTasks(taskList, nil, opts)
 
-	codeProfile := convertToCodeProfile(absPath, stats, errorFiles)
+	codeProfile := convertToCodeProfile(absPath, nil, stats, errorFiles)
+	return codeProfile, fileIndex, &diag, nil
+}
+
+// AnalyzeCodeProfileFS 分析 fsys（例如 git 修订版本的文件树）中的代码，文件内容全部从 fsys 读取。
+// rootPath 只用于报告中的项目路径和文件索引的根目录，返回的文件索引的 FS 为 fsys。
+// 资源限制与磁盘分析相同，符号链接总是被跳过
+func (a *CodeAnalyzer) AnalyzeCodeProfileFS(
+	ctx context.Context, rootPath string, fsys fs.FS, opts WalkOptions,
+) (*camodels.CodeProfile, *camodels.FileIndex, *WalkDiagnostics, error) {
+	opts = opts.Normalize()
+
+	absPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	fileIndex := camodels.NewFileIndex(absPath)
+	fileIndex.FS = fsys
+	var taskList []AnalysisTask
+	var diag WalkDiagnostics
+
+	err = a.walkFS(ctx, fsys, opts, fileIndex, &taskList, &diag)
+	if err != nil {
+		return nil, nil, &diag, err
+	}
+
+	stats, errorFiles := a.processTasks(taskList, fsys, opts)
+
+	codeProfile := convertToCodeProfile(absPath, fsys, stats, errorFiles)
 	return codeProfile, fileIndex, &diag, nil
 }
 
@@ -110,6 +149,8 @@ func (a *CodeAnalyzer) walkAndCollect(
 	diag *WalkDiagnostics,
 ) error {
 	fileCount := 0
+	rootFS := os.DirFS(absPath)
+	ignores := newIgnoreMatcher(rootFS, opts)
 
 	return filepath.WalkDir(absPath, func(path string, dirEntry os.DirEntry, walkErr error) error {
 		if walkErr != nil {
@@ -134,9 +175,23 @@ func (a *CodeAnalyzer) walkAndCollect(
 			}
 		}
 
+		// Compute relative path for ignore rules and the index.
+		relPath, _ := filepath.Rel(absPath, path)
+		relPath = filepath.ToSlash(relPath)
+
 		if dirEntry.IsDir() {
+			if relPath == "." {
+				ignores.loadDir(relPath)
+				return nil
+			}
 			// Skip hidden directories.
-			if strings.HasPrefix(dirEntry.Name(), ".") && dirEntry.Name() != "." {
+			if strings.HasPrefix(dirEntry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			// Skip ignored directories, keeping dependency metadata needed by manifest parsing.
+			if ignores.ignored(relPath, true) {
+				diag.Ignored++
+				fileCount = a.indexMetadata(rootFS, relPath, opts, fileIndex, fileCount)
 				return filepath.SkipDir
 			}
 			// Enforce depth limit.
@@ -145,6 +200,12 @@ func (a *CodeAnalyzer) walkAndCollect(
 				diag.MaxDepthReached = true
 				return filepath.SkipDir
 			}
+			ignores.loadDir(relPath)
+			return nil
+		}
+
+		if ignores.ignored(relPath, false) {
+			diag.Ignored++
 			return nil
 		}
 
@@ -164,25 +225,127 @@ func (a *CodeAnalyzer) walkAndCollect(
 
 		fileCount++
 
-		// Compute relative path and add to index.
-		relPath, _ := filepath.Rel(absPath, path)
-		relPath = filepath.ToSlash(relPath)
+		// Add to index.
 		fileIndex.AddFile(relPath, dirEntry.Name(), filepath.Ext(dirEntry.Name()))
+		kind := ClassifyPath(relPath)
+		if kind == FileKindVendored {
+			fileIndex.MarkVendored(relPath)
+		}
 
 		// Identify language.
-		langDef := extToLanguage[strings.ToLower(filepath.Ext(path))]
-		if langDef == nil {
-			langDef = fileToLanguage[dirEntry.Name()]
+		if langDef := identifyLanguage(rootFS, relPath); langDef != nil {
+			*taskList = append(*taskList, AnalysisTask{Path: path, LangDef: langDef, Kind: kind})
 		}
-		if langDef != nil {
-			*taskList = append(*taskList, AnalysisTask{Path: path, LangDef: langDef})
+		return nil
+	})
+}
+
+// walkFS traverses fsys applying the same resource limits as walkAndCollect.
+func (a *CodeAnalyzer) walkFS(
+	ctx context.Context,
+	fsys fs.FS,
+	opts WalkOptions,
+	fileIndex *camodels.FileIndex,
+	taskList *[]AnalysisTask,
+	diag *WalkDiagnostics,
+) error {
+	fileCount := 0
+	ignores := newIgnoreMatcher(fsys, opts)
+
+	return fs.WalkDir(fsys, ".", func(name string, dirEntry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return nil // skip unreadable entries
+		}
+
+		// Periodic context check.
+		if fileCount%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		// Symlinks cannot be followed inside fsys.
+		if dirEntry.Type()&fs.ModeSymlink != 0 {
+			diag.SkippedSymlinks++
+			return nil
+		}
+
+		if dirEntry.IsDir() {
+			if name == "." {
+				ignores.loadDir(name)
+				return nil
+			}
+			// Skip hidden directories.
+			if strings.HasPrefix(dirEntry.Name(), ".") {
+				return fs.SkipDir
+			}
+			// Skip ignored directories, keeping dependency metadata needed by manifest parsing.
+			if ignores.ignored(name, true) {
+				diag.Ignored++
+				fileCount = a.indexMetadata(fsys, name, opts, fileIndex, fileCount)
+				return fs.SkipDir
+			}
+			// Enforce depth limit.
+			if strings.Count(name, "/")+1 >= opts.MaxDepth {
+				diag.MaxDepthReached = true
+				return fs.SkipDir
+			}
+			ignores.loadDir(name)
+			return nil
+		}
+
+		if ignores.ignored(name, false) {
+			diag.Ignored++
+			return nil
+		}
+
+		// Enforce file count limit.
+		if fileCount >= opts.MaxFiles {
+			diag.Truncated = true
+			return fs.SkipAll
+		}
+
+		if info, infoErr := dirEntry.Info(); infoErr == nil {
+			if info.Size() > opts.MaxFileSize {
+				diag.SkippedLarge++
+				return nil
+			}
+		}
+
+		fileCount++
+		fileIndex.AddFile(name, dirEntry.Name(), path.Ext(dirEntry.Name()))
+		kind := ClassifyPath(name)
+		if kind == FileKindVendored {
+			fileIndex.MarkVendored(name)
+		}
+
+		if langDef := identifyLanguage(fsys, name); langDef != nil {
+			*taskList = append(*taskList, AnalysisTask{Path: name, LangDef: langDef, Kind: kind})
 		}
 		return nil
 	})
 }
 
-// processTasks runs concurrent file stats collection.
-func (a *CodeAnalyzer) processTasks(taskList []AnalysisTask) (map[string]*camodels.LangSummary, int) {
+// indexMetadata adds dependency metadata files of an ignored directory to the index
+// without analyzing them, honouring the file count limit. It returns the new file count.
+func (a *CodeAnalyzer) indexMetadata(fsys fs.FS, dir string, opts WalkOptions, fileIndex *camodels.FileIndex, fileCount int) int {
+	for _, file := range dependencyMetadata(fsys, dir) {
+		if fileCount >= opts.MaxFiles {
+			break
+		}
+		fileCount++
+		fileIndex.AddFile(file, path.Base(file), path.Ext(file))
+		if IsVendoredPath(file) {
+			fileIndex.MarkVendored(file)
+		}
+	}
+	return fileCount
+}
+
+// processTasks runs concurrent file stats collection, reading from fsys when it is not nil.
+// Files are summarized per FileKind; generated and minified files are detected from their
+// content as well and count as source when opts.CountGenerated is set.
+func (a *CodeAnalyzer) processTasks(taskList []AnalysisTask, fsys fs.FS, opts WalkOptions) (map[FileKind]map[string]*camodels.LangSummary, int) {
 	bar := progress.NewProcessBar(int64(len(taskList)), "Analyzing Code")
 	workers := autoWorkers()
 
@@ -195,9 +358,24 @@ func (a *CodeAnalyzer) processTasks(taskList []AnalysisTask) (map[string]*camode
 		go func() {
 			defer wg.Done()
 			for task := range tasks {
-				stats, err := CountFileStats(task.Path)
+				stats, header, err := inspectFile(fsys, task.Path)
+				kind := task.Kind
+				if errors.Is(err, bufio.ErrTooLong) {
+					// A line longer than the scan buffer only occurs in minified bundles.
+					err = nil
+					if kind == FileKindSource {
+						kind = FileKindMinified
+					}
+				}
+				if kind == FileKindSource && err == nil {
+					kind = classifyContent(task.Path, header, stats)
+				}
+				if opts.CountGenerated && (kind == FileKindGenerated || kind == FileKindMinified) {
+					kind = FileKindSource
+				}
 				results <- AnalysisResult{
 					LangName: task.LangDef.Name,
+					Kind:     kind,
 					Stats:    stats,
 					Err:      err,
 				}
@@ -206,7 +384,7 @@ func (a *CodeAnalyzer) processTasks(taskList []AnalysisTask) (map[string]*camode
 		}()
 	}
 
-	stats := make(map[string]*camodels.LangSummary)
+	stats := make(map[FileKind]map[string]*camodels.LangSummary)
 	var errorFiles int
 	done := make(chan struct{})
 	go func() {
@@ -215,10 +393,15 @@ func (a *CodeAnalyzer) processTasks(taskList []AnalysisTask) (map[string]*camode
 				errorFiles++
 				continue
 			}
-			summary, ok := stats[res.LangName]
+			target, ok := stats[res.Kind]
+			if !ok {
+				target = make(map[string]*camodels.LangSummary)
+				stats[res.Kind] = target
+			}
+			summary, ok := target[res.LangName]
 			if !ok {
 				summary = &camodels.LangSummary{Name: res.LangName}
-				stats[res.LangName] = summary
+				target[res.LangName] = summary
 			}
 			summary.Count++
 			summary.Code += res.Stats.Code
@@ -258,8 +441,10 @@ func autoWorkers() int {
 	return workers
 }
 
-// convertToCodeProfile converts statistics to CodeCanvas CodeProfile.
-func convertToCodeProfile(absPath string, stats map[string]*camodels.LangSummary, errorFiles int) *camodels.CodeProfile {
+// convertToCodeProfile converts statistics to CodeCanvas CodeProfile. Vendored, generated and minified
+// statistics are reported separately and do not take part in totals or language classification.
+// Project files used for language classification are read from fsys, or from absPath on disk when fsys is nil.
+func convertToCodeProfile(absPath string, fsys fs.FS, stats map[FileKind]map[string]*camodels.LangSummary, errorFiles int) *camodels.CodeProfile {
 
 	profile := &camodels.CodeProfile{
 		Path:              absPath,
@@ -271,32 +456,28 @@ func convertToCodeProfile(absPath string, stats map[string]*camodels.LangSummary
 		LanguageInfos:     []camodels.LangInfo{},
 	}
 
-	// 将统计表转换为切片
-	var summaries []camodels.LangSummary
-	for _, summary := range stats {
-		summaries = append(summaries, *summary)
+	source := summarizeStats(stats[FileKindSource])
+	profile.LanguageInfos = source.LanguageInfos
+	profile.TotalFiles = source.TotalFiles
+	profile.TotalLines = source.TotalLines
+	if len(stats[FileKindVendored]) > 0 {
+		profile.Vendored = summarizeStats(stats[FileKindVendored])
 	}
-
-	for _, stat := range summaries {
-		langInfo := camodels.LangInfo{
-			Name:         stat.Name,
-			Files:        int(stat.Count),
-			CodeLines:    int(stat.Code),
-			CommentLines: int(stat.Comment),
-			BlankLines:   int(stat.Blank),
-		}
-
-		// Add to profile
-		profile.LanguageInfos = append(profile.LanguageInfos, langInfo)
-		profile.TotalFiles += langInfo.Files
-		profile.TotalLines += langInfo.CodeLines + langInfo.CommentLines + langInfo.BlankLines
+	if len(stats[FileKindGenerated]) > 0 {
+		profile.Generated = summarizeStats(stats[FileKindGenerated])
+	}
+	if len(stats[FileKindMinified]) > 0 {
+		profile.Minified = summarizeStats(stats[FileKindMinified])
 	}
 
 	profileJSON, _ := json.Marshal(profile)
 	slogs.Infof("profile ToJson: %s", string(profileJSON))
 
 	// 进行语言信息分析
-	frontend, backend, desktop, other, allLang, expand := langengine.NewLangClassifier().DetectCategories(absPath, profile.LanguageInfos)
+	if fsys == nil {
+		fsys = os.DirFS(absPath)
+	}
+	frontend, backend, desktop, other, allLang, expand := langengine.NewLangClassifier().DetectCategoriesFS(fsys, profile.LanguageInfos)
 	profile.FrontendLanguages = frontend
 	profile.BackendLanguages = backend
 	profile.DesktopLanguages = desktop
@@ -305,3 +486,21 @@ func convertToCodeProfile(absPath string, stats map[string]*camodels.LangSummary
 	profile.Expands = expand
 	return profile
 }
+
+// summarizeStats converts per-language summaries to CodeStats.
+func summarizeStats(stats map[string]*camodels.LangSummary) *camodels.CodeStats {
+	result := &camodels.CodeStats{LanguageInfos: []camodels.LangInfo{}}
+	for _, stat := range stats {
+		langInfo := camodels.LangInfo{
+			Name:         stat.Name,
+			Files:        int(stat.Count),
+			CodeLines:    int(stat.Code),
+			CommentLines: int(stat.Comment),
+			BlankLines:   int(stat.Blank),
+		}
+		result.LanguageInfos = append(result.LanguageInfos, langInfo)
+		result.TotalFiles += langInfo.Files
+		result.TotalLines += langInfo.CodeLines + langInfo.CommentLines + langInfo.BlankLines
+	}
+	return result
+}
@@ -3,11 +3,13 @@ package analyzer
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/winezer0/xcanvas/camodels"
 )
@@ -323,3 +325,44 @@ func writeFile(t *testing.T, dir, name, content string) {
 		t.Fatalf("write %s: %v", name, err)
 	}
 }
+
+// TestAnalyzeCodeProfileFS verifies analysis of an in-memory file tree with the same limits as the disk walk.
+func TestAnalyzeCodeProfileFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":       {Data: []byte("package main\n\n// entry\nfunc main() {}\n")},
+		"web/app.py":    {Data: []byte("print('hi')\n")},
+		"web/large.go":  {Data: []byte(strings.Repeat("x", 200))},
+		".git/config":   {Data: []byte("[core]\n")},
+		"a/b/c/deep.go": {Data: []byte("package deep\n")},
+		"link.go":       {Data: []byte("main.go"), Mode: fs.ModeSymlink},
+	}
+
+	az := NewCodeAnalyzer()
+	opts := WalkOptions{MaxFiles: 100, MaxFileSize: 100, MaxDepth: 3}
+	profile, index, diag, err := az.AnalyzeCodeProfileFS(context.Background(), "/virtual/app", fsys, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diag.SkippedLarge != 1 || diag.SkippedSymlinks != 1 || !diag.MaxDepthReached {
+		t.Errorf("unexpected diagnostics: %+v", diag)
+	}
+	if index.FS == nil || index.RootDir != filepath.Clean("/virtual/app") {
+		t.Errorf("unexpected index root %q / FS %v", index.RootDir, index.FS)
+	}
+	for _, f := range index.Files {
+		if strings.HasPrefix(f, ".git/") || strings.HasPrefix(f, "a/") {
+			t.Errorf("unexpected indexed file %s", f)
+		}
+	}
+
+	stats := make(map[string]camodels.LangInfo)
+	for _, info := range profile.LanguageInfos {
+		stats[info.Name] = info
+	}
+	if stats["Go"].Files != 1 || stats["Go"].CodeLines != 2 || stats["Go"].CommentLines != 1 {
+		t.Errorf("unexpected Go stats: %+v", stats["Go"])
+	}
+	if stats["Python"].Files != 1 {
+		t.Errorf("unexpected Python stats: %+v", stats["Python"])
+	}
+}
@@ -2,6 +2,8 @@ package analyzer
 
 import (
 	"bufio"
+	"io"
+	"io/fs"
 	"os"
 	"strings"
 )
@@ -12,6 +14,7 @@ type FileStats struct {
 	Comment int64
 	Blank   int64
 	Lines   int64
+	Bytes   int64 // 所有行的字节数（不含换行符），用于识别压缩代码
 }
 
 // CountFileStats 分析文件并返回其统计信息
@@ -21,9 +24,56 @@ func CountFileStats(path string) (FileStats, error) {
 		return FileStats{}, err
 	}
 	defer file.Close()
+	return countStats(file)
+}
+
+// CountFileStatsFS 分析 fsys 中的文件并返回其统计信息
+func CountFileStatsFS(fsys fs.FS, name string) (FileStats, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return FileStats{}, err
+	}
+	defer file.Close()
+	return countStats(file)
+}
+
+// inspectFile 统计文件（fsys 为 nil 时 name 为磁盘路径）的行数，同时返回文件开头 headerSize 字节用于识别生成代码。
+// 超过扫描缓冲区的超长行说明文件是压缩代码，此时返回已统计的部分和 bufio.ErrTooLong
+func inspectFile(fsys fs.FS, name string) (FileStats, []byte, error) {
+	var file io.ReadCloser
+	var err error
+	if fsys != nil {
+		file, err = fsys.Open(name)
+	} else {
+		file, err = os.Open(name)
+	}
+	if err != nil {
+		return FileStats{}, nil, err
+	}
+	defer file.Close()
+
+	header := &prefixWriter{limit: headerSize}
+	stats, err := countStats(io.TeeReader(file, header))
+	return stats, header.buf, err
+}
+
+// prefixWriter 只保留写入内容的前 limit 个字节
+type prefixWriter struct {
+	buf   []byte
+	limit int
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	if room := w.limit - len(w.buf); room > 0 {
+		w.buf = append(w.buf, p[:min(room, len(p))]...)
+	}
+	return len(p), nil
+}
 
+// countStats 按行统计代码、注释和空白行
+func countStats(reader io.Reader) (FileStats, error) {
 	stats := FileStats{}
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(reader)
 	// 增加长行的缓冲区大小
 	const maxCapacity = 1024 * 1024
 	buf := make([]byte, maxCapacity)
@@ -34,6 +84,7 @@ func CountFileStats(path string) (FileStats, error) {
 	for scanner.Scan() {
 		line := scanner.Text()
 		stats.Lines++
+		stats.Bytes += int64(len(line))
 		trimmedLine := strings.TrimSpace(line)
 
 		// 处理空白行
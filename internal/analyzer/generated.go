@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileKind 文件分类，决定文件的统计计入代码画像中的哪一组
+type FileKind int
+
+const (
+	FileKindSource    FileKind = iota // 项目自身的代码，计入主统计
+	FileKindVendored                  // 第三方代码
+	FileKindGenerated                 // 生成代码（protobuf、代码生成器输出、锁文件等）
+	FileKindMinified                  // 压缩代码（*.min.js 等）
+)
+
+// headerSize 生成代码的注解只在文件开头的这部分内容中查找
+const headerSize = 8 * 1024
+
+// headerLines 生成代码的注释标记只在文件开头的这些行中查找
+const headerLines = 10
+
+// minifiedLineLength 平均行长度超过该值的 JavaScript/CSS 文件视为压缩代码（与 linguist 相同）
+const minifiedLineLength = 110
+
+// generatedPathPatterns 生成代码的路径规则
+var generatedPathPatterns = compilePatterns(
+	// protobuf / gRPC
+	`\.pb(\.gw)?\.go$`,
+	`\.pb\.(cc|h|swift|dart)$`,
+	`_pb2(_grpc)?\.pyi?$`,
+	`_(grpc_)?pb\.(js|d\.ts)$`,
+	// 代码生成器输出
+	`(^|/)zz_generated[^/]*\.go$`,
+	`\.(g|freezed|gr)\.dart$`,
+	`(?i)\.designer\.cs$`,
+	`\.g(\.i)?\.cs$`,
+	`(^|/)__generated__/`,
+	// Maven/Gradle 注解处理器（Lombok、MapStruct 等）和 delombok 输出目录
+	`(^|/)generated-(test-)?sources/`,
+	`(^|/)build/generated/`,
+	`(^|/)delombok/`,
+	// 锁文件
+	`(^|/)(package-lock\.json|npm-shrinkwrap\.json|yarn\.lock|pnpm-lock\.yaml|composer\.lock|Gemfile\.lock|Cargo\.lock|poetry\.lock|Pipfile\.lock|go\.sum|gradle\.lockfile)$`,
+)
+
+// minifiedPathPatterns 压缩代码的文件名规则
+var minifiedPathPatterns = compilePatterns(
+	`[.-]min\.(js|mjs|cjs|css)$`,
+)
+
+// generatedMarkerPatterns 文件开头几行注释中的生成代码标记，只使用代码生成工具输出的特定标记（参考 linguist），
+// 避免 "the id is auto-generated by the DB" 之类的普通注释被误判
+var generatedMarkerPatterns = compilePatterns(
+	`(?m)^// Code generated .* DO NOT EDIT\.$`,
+	`@generated\b`,
+	`(?i)\bthis (file|class|code) (was|is|has been) (automatically |auto-?)generated (by|from|using)\b`,
+	`(?i)generated by the protocol buffer compiler`,
+	`(?i)autogenerated by thrift`,
+	`<auto-generated`,
+	`(?i)generated by delombok`,
+)
+
+// generatedAnnotationPattern MapStruct 等注解处理器在生成的类上添加的注解
+var generatedAnnotationPattern = regexp.MustCompile(`(?m)^@(javax\.annotation\.(processing\.)?|jakarta\.annotation\.)?Generated\(\s*(value\s*=\s*)?"`)
+
+// minifiableExtensions 按内容识别压缩代码的扩展名
+var minifiableExtensions = map[string]bool{".js": true, ".mjs": true, ".cjs": true, ".css": true}
+
+// compilePatterns 编译路径或内容规则
+func compilePatterns(patterns ...string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		compiled = append(compiled, regexp.MustCompile(pattern))
+	}
+	return compiled
+}
+
+// matchAny 判断 s 是否匹配任一规则
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyPath 按相对路径（正斜杠分隔）对文件分类，第三方代码优先于生成代码和压缩代码
+func ClassifyPath(relPath string) FileKind {
+	switch {
+	case IsVendoredPath(relPath):
+		return FileKindVendored
+	case matchAny(generatedPathPatterns, relPath):
+		return FileKindGenerated
+	case matchAny(minifiedPathPatterns, relPath):
+		return FileKindMinified
+	}
+	return FileKindSource
+}
+
+// classifyContent 按文件开头的内容和行统计识别生成代码和压缩代码，无法识别时返回 FileKindSource
+func classifyContent(name string, header []byte, stats FileStats) FileKind {
+	lines := strings.SplitN(string(header), "\n", headerLines+1)
+	if matchAny(generatedMarkerPatterns, strings.Join(lines[:min(len(lines), headerLines)], "\n")) ||
+		generatedAnnotationPattern.Match(header) {
+		return FileKindGenerated
+	}
+	if minifiableExtensions[strings.ToLower(filepath.Ext(name))] && stats.Lines > 0 && stats.Bytes/stats.Lines > minifiedLineLength {
+		return FileKindMinified
+	}
+	return FileKindSource
+}
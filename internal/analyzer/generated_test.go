@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestClassifyPath verifies name-based classification and that vendored code takes precedence
+func TestClassifyPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want FileKind
+	}{
+		{"api/user.pb.go", FileKindGenerated},
+		{"api/user_grpc.pb.go", FileKindGenerated},
+		{"api/user.pb.gw.go", FileKindGenerated},
+		{"proto/user_pb2.py", FileKindGenerated},
+		{"lib/model.g.dart", FileKindGenerated},
+		{"Forms/Main.Designer.cs", FileKindGenerated},
+		{"pkg/apis/zz_generated.deepcopy.go", FileKindGenerated},
+		{"target/generated-sources/annotations/UserMapperImpl.java", FileKindGenerated},
+		{"web/package-lock.json", FileKindGenerated},
+		{"static/app.min.js", FileKindMinified},
+		{"static/site-min.css", FileKindMinified},
+		{"vendor/google.golang.org/grpc/x.pb.go", FileKindVendored},
+		{"static/jquery.min.js", FileKindVendored},
+		{"main.go", FileKindSource},
+		{"api/pb.go", FileKindSource},
+		{"src/admin.js", FileKindSource},
+	}
+	for _, tc := range tests {
+		if got := ClassifyPath(tc.path); got != tc.want {
+			t.Errorf("ClassifyPath(%q) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
+
+// TestClassifyContent verifies generated headers, generated annotations and minified content sniffing
+func TestClassifyContent(t *testing.T) {
+	mapstruct := "package com.example;\n\nimport javax.annotation.processing.Generated;\n\n" +
+		strings.Repeat("import java.util.List;\n", 20) +
+		"@Generated(\n    value = \"org.mapstruct.ap.MappingProcessor\"\n)\npublic class UserMapperImpl {}\n"
+	longLine := strings.Repeat("var a=1;", 40)
+	tests := []struct {
+		name    string
+		content string
+		want    FileKind
+	}{
+		{"a.go", "// Code generated by protoc-gen-go. DO NOT EDIT.\npackage api\n", FileKindGenerated},
+		{"a.ts", "/* eslint-disable */\n// This file was automatically generated by openapi-generator.\nexport {}\n", FileKindGenerated},
+		{"a.pb.h", "// Generated by the protocol buffer compiler.  DO NOT EDIT!\n", FileKindGenerated},
+		{"a.cs", "//------\n// <auto-generated>\n//     This code was generated by a tool.\n// </auto-generated>\n", FileKindGenerated},
+		{"a.java", "// Generated by delombok at Mon Jan 01\npackage x;\n", FileKindGenerated},
+		{"UserMapperImpl.java", mapstruct, FileKindGenerated},
+		{"a.js", longLine + "\n" + longLine + "\n", FileKindMinified},
+		{"a.py", longLine + "\n", FileKindSource},
+		{"a.go", "package main\n\n" + strings.Repeat("\n", 12) + "// do not edit the values below\n", FileKindSource},
+		{"a.java", "package x;\n\nclass A {\n    String s = \"@Generated(\\\"x\\\")\";\n}\n", FileKindSource},
+		// ordinary first-party comments
+		{"user.go", "package model\n\n// User id is auto-generated by the DB.\ntype User struct{ ID int }\n", FileKindSource},
+		{"config.py", "# Do not modify without updating docs/config.md\nTIMEOUT = 30\n", FileKindSource},
+		{"Order.java", "package x;\n\n// The order number is automatically generated on save. Do not edit it by hand.\nclass Order {}\n", FileKindSource},
+	}
+	for _, tc := range tests {
+		stats, err := countStats(strings.NewReader(tc.content))
+		if err != nil {
+			t.Fatalf("countStats(%s): %v", tc.name, err)
+		}
+		if got := classifyContent(tc.name, []byte(tc.content), stats); got != tc.want {
+			t.Errorf("classifyContent(%s, %q) = %v, want %v", tc.name, tc.content[:min(len(tc.content), 40)], got, tc.want)
+		}
+	}
+}
+
+// TestAnalyzeCodeProfileGenerated verifies generated and minified files are reported separately unless CountGenerated is set
+func TestAnalyzeCodeProfileGenerated(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":        {Data: []byte("package main\n\nfunc main() {}\n")},
+		"api/user.pb.go": {Data: []byte("package api\n")},
+		"api/wire.go":    {Data: []byte("// Code generated by Wire. DO NOT EDIT.\n\npackage api\n")},
+		"web/app.js":     {Data: []byte("console.log('hi')\n")},
+		"web/bundle.js":  {Data: []byte(strings.Repeat("a", 2*1024*1024) + "\n")},
+		"web/app.min.js": {Data: []byte("console.log(1)\n")},
+	}
+
+	az := NewCodeAnalyzer()
+	opts := WalkOptions{MaxFiles: 100, MaxFileSize: 4 * 1024 * 1024, MaxDepth: 10}
+	profile, _, _, err := az.AnalyzeCodeProfileFS(context.Background(), "/virtual", fsys, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.TotalFiles != 2 || profile.ErrorFiles != 0 {
+		t.Errorf("expected 2 source files and no errors, got %d / %d", profile.TotalFiles, profile.ErrorFiles)
+	}
+	if profile.Generated == nil || profile.Generated.TotalFiles != 2 {
+		t.Errorf("expected 2 generated files, got %+v", profile.Generated)
+	}
+	if profile.Minified == nil || profile.Minified.TotalFiles != 2 {
+		t.Errorf("expected 2 minified files, got %+v", profile.Minified)
+	}
+
+	opts.CountGenerated = true
+	profile, _, _, err = az.AnalyzeCodeProfileFS(context.Background(), "/virtual", fsys, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.TotalFiles != 6 || profile.Generated != nil || profile.Minified != nil {
+		t.Errorf("expected all 6 files counted as source, got %d / %+v / %+v", profile.TotalFiles, profile.Generated, profile.Minified)
+	}
+}
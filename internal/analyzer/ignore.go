@@ -0,0 +1,317 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/winezer0/slogs"
+)
+
+// 遍历时读取的忽略文件，语法与 .gitignore 相同；同一目录中 .xcanvasignore 的规则优先
+const (
+	GitIgnoreFile     = ".gitignore"
+	XcanvasIgnoreFile = ".xcanvasignore"
+)
+
+// ignoreSet 按生态划分的内置忽略规则（gitignore 语法）。
+// 设置了 manifests 时，规则只在包含其中任一清单文件（支持 * 通配）的目录中生效，并锚定到该目录，
+// 避免 Go 项目中的 internal/build/ 之类的自有目录被 Java 规则集忽略；未设置时规则在整个项目中生效
+type ignoreSet struct {
+	manifests []string
+	patterns  []string
+}
+
+// ignoreSets 内置忽略规则集，优先级低于项目中的忽略文件，因此可以在 .xcanvasignore 中用 "!vendor/" 等规则重新包含。
+// 其中的依赖目录（vendor/、node_modules/ 等第三方代码目录）默认不忽略，作为第三方代码单独统计，WalkOptions.SkipVendored 时才忽略
+var ignoreSets = map[string]ignoreSet{
+	"node":   {manifests: []string{"package.json", "bower.json"}, patterns: []string{"/node_modules/", "/bower_components/", "/jspm_packages/"}},
+	"go":     {manifests: []string{"go.mod"}, patterns: []string{"/vendor/"}},
+	"php":    {manifests: []string{"composer.json"}, patterns: []string{"/vendor/"}},
+	"ruby":   {manifests: []string{"Gemfile"}, patterns: []string{"/vendor/bundle/"}},
+	"java":   {manifests: []string{"pom.xml", "build.gradle", "build.gradle.kts"}, patterns: []string{"/target/", "/build/"}},
+	"python": {patterns: []string{"__pycache__/", "*.pyc", "venv/", "site-packages/", "*.egg-info/"}},
+	"rust":   {manifests: []string{"Cargo.toml"}, patterns: []string{"/target/"}},
+	"dotnet": {manifests: []string{"*.csproj", "*.fsproj", "*.vbproj", "*.sln"}, patterns: []string{"/obj/", "/bin/Debug/", "/bin/Release/"}},
+	"build":  {manifests: []string{"package.json", "pyproject.toml", "setup.py"}, patterns: []string{"/dist/", "/build/"}},
+}
+
+// IgnoreSetNames 返回所有内置忽略规则集的名称（按名称排序）
+func IgnoreSetNames() []string {
+	names := make([]string, 0, len(ignoreSets))
+	for name := range ignoreSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ignoreRule 一条 gitignore 规则，segments 相对 base 目录按 "/" 切分，非锚定规则以 "**" 开头
+type ignoreRule struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// ignoreMatcher 按 gitignore 语义判断路径是否被忽略：不限目录的内置规则在前，然后是从根目录到父目录
+// 按清单文件生效的内置规则，最后从根目录到父目录依次应用每层目录的忽略文件，最后一条匹配的规则生效
+type ignoreMatcher struct {
+	fsys         fs.FS
+	useFiles     bool
+	skipVendored bool
+	defaults     []ignoreRule
+	scoped       []ignoreSet             // 按清单文件生效的内置规则集
+	setRules     map[string][]ignoreRule // 目录相对路径（根目录为 ""） -> 该目录中生效的内置规则
+	rules        map[string][]ignoreRule // 目录相对路径（根目录为 ""） -> 该目录忽略文件中的规则
+}
+
+// newIgnoreMatcher 根据遍历选项创建匹配器，忽略文件和清单文件从 fsys 读取
+func newIgnoreMatcher(fsys fs.FS, opts WalkOptions) *ignoreMatcher {
+	m := &ignoreMatcher{fsys: fsys, useFiles: opts.IgnoreFiles, skipVendored: opts.SkipVendored,
+		setRules: make(map[string][]ignoreRule), rules: make(map[string][]ignoreRule)}
+	for _, name := range opts.IgnoreSets {
+		set, ok := ignoreSets[name]
+		if !ok {
+			slogs.Warnf("unknown ignore set %q (available: %s)", name, strings.Join(IgnoreSetNames(), ", "))
+			continue
+		}
+		if len(set.manifests) > 0 {
+			m.scoped = append(m.scoped, set)
+			continue
+		}
+		m.defaults = append(m.defaults, m.activeRules(set)...)
+	}
+	return m
+}
+
+// activeRules 返回规则集中生效的规则，不忽略第三方代码时跳过依赖目录
+func (m *ignoreMatcher) activeRules(set ignoreSet) []ignoreRule {
+	var rules []ignoreRule
+	for _, pattern := range set.patterns {
+		if !m.skipVendored && IsVendoredPath(strings.Trim(pattern, "/")+"/") {
+			continue
+		}
+		if rule, ok := parseIgnoreLine(pattern); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// loadDir 读取目录 dir（相对路径，根目录为 "." 或 ""）中的忽略文件，并按目录中的清单文件启用内置规则集
+func (m *ignoreMatcher) loadDir(dir string) {
+	if dir == "." {
+		dir = ""
+	}
+	m.loadSets(dir)
+	if !m.useFiles {
+		return
+	}
+	var rules []ignoreRule
+	for _, file := range []string{GitIgnoreFile, XcanvasIgnoreFile} {
+		content, err := fs.ReadFile(m.fsys, path.Join(".", dir, file))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, parseIgnore(content)...)
+	}
+	if len(rules) > 0 {
+		m.rules[dir] = rules
+	}
+}
+
+// loadSets 目录 dir 中存在规则集的清单文件时，启用锚定到该目录的规则
+func (m *ignoreMatcher) loadSets(dir string) {
+	if len(m.scoped) == 0 {
+		return
+	}
+	entries, err := fs.ReadDir(m.fsys, path.Join(".", dir))
+	if err != nil {
+		return
+	}
+	var rules []ignoreRule
+	for _, set := range m.scoped {
+		if hasManifest(entries, set.manifests) {
+			rules = append(rules, m.activeRules(set)...)
+		}
+	}
+	if len(rules) > 0 {
+		m.setRules[dir] = rules
+	}
+}
+
+// hasManifest 判断目录项中是否有文件匹配任一清单文件名
+func hasManifest(entries []fs.DirEntry, manifests []string) bool {
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, manifest := range manifests {
+			if ok, _ := path.Match(manifest, entry.Name()); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ignored 判断相对路径 name 是否被忽略
+func (m *ignoreMatcher) ignored(name string, isDir bool) bool {
+	ignored := false
+	apply := func(rules []ignoreRule, rel string) {
+		if len(rules) == 0 {
+			return
+		}
+		segments := strings.Split(rel, "/")
+		for _, rule := range rules {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if matchSegments(rule.segments, segments) {
+				ignored = !rule.negate
+			}
+		}
+	}
+
+	// 按目录从根目录到父目录依次应用 rules 中的规则
+	applyDirs := func(rules map[string][]ignoreRule) {
+		dir, rest := "", name
+		for {
+			apply(rules[dir], rest)
+			i := strings.IndexByte(rest, '/')
+			if i < 0 {
+				return
+			}
+			dir, rest = path.Join(dir, rest[:i]), rest[i+1:]
+		}
+	}
+
+	apply(m.defaults, name)
+	applyDirs(m.setRules)
+	applyDirs(m.rules)
+	return ignored
+}
+
+// parseIgnore 解析忽略文件内容，无效的规则会被跳过
+func parseIgnore(content []byte) []ignoreRule {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreLine(scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseIgnoreLine 解析一行 gitignore 规则，空行、注释和无效模式返回 false
+func parseIgnoreLine(line string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	// 末尾空格被忽略，除非使用反斜杠转义
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	// 包含 "/" 的模式相对忽略文件所在目录锚定，否则匹配任意层级的名称
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if !anchored {
+		line = "**/" + line
+	}
+	for _, segment := range strings.Split(line, "/") {
+		if segment != "**" {
+			segment = strings.ReplaceAll(segment, "[!", "[^")
+			if strings.Contains(segment, "**") {
+				segment = strings.ReplaceAll(segment, "**", "*")
+			}
+			if _, err := path.Match(segment, ""); err != nil {
+				return ignoreRule{}, false
+			}
+		}
+		rule.segments = append(rule.segments, segment)
+	}
+	return rule, true
+}
+
+// matchSegments 逐段匹配路径，"**" 匹配零个或多个目录，末尾的 "**" 至少匹配一段
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return len(name) > 0
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// dependencyMetadata 返回被忽略的依赖目录中依赖解析需要的元数据文件，这些文件仍会加入文件索引
+// （vendor/modules.txt、vendor/composer/installed.json、node_modules/<name>/package.json），
+// 只读取目录列表，不遍历依赖的源码
+func dependencyMetadata(fsys fs.FS, dir string) []string {
+	var candidates []string
+	switch path.Base(dir) {
+	case "vendor":
+		candidates = []string{path.Join(dir, "modules.txt"), path.Join(dir, "composer", "installed.json")}
+	case "node_modules":
+		entries, _ := fs.ReadDir(fsys, dir)
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			if !strings.HasPrefix(entry.Name(), "@") {
+				candidates = append(candidates, path.Join(dir, entry.Name(), "package.json"))
+				continue
+			}
+			scoped, _ := fs.ReadDir(fsys, path.Join(dir, entry.Name()))
+			for _, pkg := range scoped {
+				if pkg.IsDir() {
+					candidates = append(candidates, path.Join(dir, entry.Name(), pkg.Name(), "package.json"))
+				}
+			}
+		}
+	}
+
+	var files []string
+	for _, file := range candidates {
+		if info, err := fs.Stat(fsys, file); err == nil && info.Mode().IsRegular() {
+			files = append(files, file)
+		}
+	}
+	return files
+}
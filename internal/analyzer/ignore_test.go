@@ -0,0 +1,229 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// TestIgnoreMatcher verifies gitignore semantics: anchoring, directory-only rules, "**", negation and nested files
+func TestIgnoreMatcher(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":         {Data: []byte("# comment\n*.log\n/docs/\nbuild/\n!keep.log\nsrc/**/gen\n\\#literal\n")},
+		"pkg/.gitignore":     {Data: []byte("!debug.log\nlocal.txt\n")},
+		"pkg/.xcanvasignore": {Data: []byte("!local.txt\n")},
+	}
+	m := newIgnoreMatcher(fsys, WalkOptions{IgnoreFiles: true})
+	m.loadDir(".")
+	m.loadDir("pkg")
+
+	tests := []struct {
+		name  string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"a/b/app.log", false, true},
+		{"keep.log", false, false},
+		{"docs", true, true},
+		{"a/docs", true, false},
+		{"build", false, false},
+		{"a/build", true, true},
+		{"src/gen", true, true},
+		{"src/x/y/gen", true, true},
+		{"#literal", false, true},
+		{"pkg/debug.log", false, false},
+		{"pkg/other.log", false, true},
+		{"pkg/local.txt", false, false},
+		{"local.txt", false, false},
+		{"main.go", false, false},
+	}
+	for _, tc := range tests {
+		if got := m.ignored(tc.name, tc.isDir); got != tc.want {
+			t.Errorf("ignored(%q, %v) = %v, want %v", tc.name, tc.isDir, got, tc.want)
+		}
+	}
+}
+
+// skipVendoredOptions returns the default walk options with dependency directories skipped
+func skipVendoredOptions() WalkOptions {
+	opts := DefaultWalkOptions()
+	opts.SkipVendored = true
+	return opts
+}
+
+// TestIgnoreSets verifies built-in sets apply next to their manifests and can be re-included by project ignore files
+func TestIgnoreSets(t *testing.T) {
+	fsys := fstest.MapFS{
+		".xcanvasignore":   {Data: []byte("!vendor/\n")},
+		"go.mod":           {Data: []byte("module demo\n")},
+		"pom.xml":          {Data: []byte("<project/>")},
+		"App.csproj":       {Data: []byte("<Project/>")},
+		"web/package.json": {Data: []byte("{}")},
+	}
+	m := newIgnoreMatcher(fsys, skipVendoredOptions())
+	m.loadDir(".")
+	m.loadDir("web")
+	if !m.ignored("web/node_modules", true) || !m.ignored("app/__pycache__", true) || !m.ignored("target", true) {
+		t.Error("default ignore sets not applied")
+	}
+	if m.ignored("node_modules", true) || m.ignored("src/target", true) {
+		t.Error("ignore sets should be anchored to the directory of their manifest")
+	}
+	if m.ignored("vendor", true) {
+		t.Error("vendor should be re-included by .xcanvasignore")
+	}
+	if m.ignored("bin", true) || !m.ignored("bin/Debug", true) {
+		t.Error("unexpected dotnet ignore result")
+	}
+
+	// 默认不忽略依赖目录，其他规则不受影响
+	m = newIgnoreMatcher(fsys, DefaultWalkOptions())
+	m.loadDir(".")
+	m.loadDir("web")
+	if m.ignored("web/node_modules", true) || !m.ignored("target", true) || !m.ignored("app/__pycache__", true) {
+		t.Error("only dependency directories should be walked without SkipVendored")
+	}
+
+	m = newIgnoreMatcher(fsys, WalkOptions{IgnoreSets: []string{"python"}})
+	m.loadDir(".")
+	if m.ignored("target", true) || !m.ignored("mod.pyc", false) {
+		t.Error("only the python set should apply")
+	}
+}
+
+// TestIgnoreSetsRequireManifest verifies first-party build/ and dist/ directories survive in a project without the matching manifests
+func TestIgnoreSetsRequireManifest(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":               {Data: []byte("module demo\n")},
+		"main.go":              {Data: []byte("package main\n")},
+		"internal/build/x.go":  {Data: []byte("package build\n")},
+		"src/dist/y.go":        {Data: []byte("package dist\n")},
+		"vendor/a/b/b.go":      {Data: []byte("package b\n")},
+		"tools/target/main.go": {Data: []byte("package main\n")},
+	}
+	az := NewCodeAnalyzer()
+	_, index, diag, err := az.AnalyzeCodeProfileFS(context.Background(), "/virtual", fsys, skipVendoredOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := slices.Sorted(slices.Values(index.Files))
+	want := []string{"go.mod", "internal/build/x.go", "main.go", "src/dist/y.go", "tools/target/main.go"}
+	if !slices.Equal(got, want) {
+		t.Errorf("indexed files = %v, want %v", got, want)
+	}
+	if diag.Ignored != 1 {
+		t.Errorf("expected only vendor/ to be ignored, got %d ignored directories", diag.Ignored)
+	}
+}
+
+// TestAnalyzeCodeProfileIgnores verifies ignored paths are neither indexed nor counted on disk and in fs.FS,
+// while dependency metadata inside skipped dependency directories stays indexed
+func TestAnalyzeCodeProfileIgnores(t *testing.T) {
+	files := map[string]string{
+		".gitignore":                                "generated/\n",
+		"go.mod":                                    "module demo\n",
+		"main.go":                                   "package main\n",
+		"generated/api.go":                          "package api\n",
+		"vendor/modules.txt":                        "# github.com/a/b v1.0.0\n",
+		"vendor/github.com/a/b/b.go":                "package b\n",
+		"web/node_modules/react/package.json":       `{"name": "react", "version": "18.2.0"}`,
+		"web/node_modules/react/index.js":           "module.exports = {}\n",
+		"web/node_modules/@types/node/package.json": `{"name": "@types/node", "version": "20.0.0"}`,
+		"web/app.py":                                "print('hi')\n",
+		"web/package.json":                          `{"name": "web"}`,
+		"web/__pycache__/app.cpython-312.py":        "print('hi')\n",
+	}
+	tmpDir := t.TempDir()
+	mapFS := fstest.MapFS{}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, tmpDir, name, content)
+		mapFS[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+
+	az := NewCodeAnalyzer()
+	diskProfile, diskIndex, diskDiag, err := az.AnalyzeCodeProfileWithContext(context.Background(), tmpDir, skipVendoredOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fsProfile, fsIndex, fsDiag, err := az.AnalyzeCodeProfileFS(context.Background(), "/virtual", mapFS, skipVendoredOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{".gitignore", "go.mod", "main.go", "vendor/modules.txt", "web/app.py", "web/node_modules/@types/node/package.json",
+		"web/node_modules/react/package.json", "web/package.json"}
+	for label, result := range map[string]struct {
+		files   []string
+		total   int
+		ignored int
+	}{
+		"disk": {diskIndex.Files, diskProfile.TotalFiles, diskDiag.Ignored},
+		"fs":   {fsIndex.Files, fsProfile.TotalFiles, fsDiag.Ignored},
+	} {
+		got := slices.Sorted(slices.Values(result.files))
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: indexed files = %v, want %v", label, got, want)
+		}
+		// main.go, web/app.py and web/package.json
+		if result.total != 3 {
+			t.Errorf("%s: expected 3 analyzed files, got %d", label, result.total)
+		}
+		if result.ignored != 4 {
+			t.Errorf("%s: expected 4 ignored directories, got %d", label, result.ignored)
+		}
+	}
+
+	opts := DefaultWalkOptions()
+	opts.IgnoreFiles = false
+	opts.IgnoreSets = nil
+	profile, _, _, err := az.AnalyzeCodeProfileWithContext(context.Background(), tmpDir, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// vendor/ and node_modules/ files are counted separately as vendored code
+	if profile.TotalFiles != 5 || profile.Vendored == nil || profile.Vendored.TotalFiles != 4 {
+		t.Errorf("expected 5 first-party and 4 vendored files without ignores, got %d / %+v", profile.TotalFiles, profile.Vendored)
+	}
+}
+
+// TestAnalyzeCodeProfileVendoredDefault verifies dependency directories matched by the default ignore sets
+// are reported as vendored code instead of being skipped
+func TestAnalyzeCodeProfileVendoredDefault(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":                            {Data: []byte("module demo\n")},
+		"main.go":                           {Data: []byte("package main\n")},
+		"vendor/modules.txt":                {Data: []byte("# github.com/a/b v1.0.0\n")},
+		"vendor/github.com/a/b/b.go":        {Data: []byte("package b\n\nfunc B() {}\n")},
+		"web/dist/app.js":                   {Data: []byte("console.log(1)\n")},
+		"web/package.json":                  {Data: []byte(`{"name": "web"}`)},
+		"web/node_modules/react/index.js":   {Data: []byte("module.exports = {}\n")},
+		"web/node_modules/react/README.txt": {Data: []byte("react\n")},
+	}
+	az := NewCodeAnalyzer()
+	profile, index, _, err := az.AnalyzeCodeProfileFS(context.Background(), "/virtual", fsys, DefaultWalkOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Vendored == nil || profile.Vendored.TotalFiles == 0 {
+		t.Fatalf("expected vendored stats for vendor/ and node_modules/, got %+v", profile.Vendored)
+	}
+	for _, file := range []string{"vendor/github.com/a/b/b.go", "web/node_modules/react/index.js"} {
+		if !slices.Contains(index.Files, file) || !index.IsVendored(file) {
+			t.Errorf("%s should be indexed as vendored", file)
+		}
+	}
+	if index.IsVendored("main.go") || slices.Contains(index.Files, "web/dist/app.js") {
+		t.Errorf("unexpected index: %v, vendored %v", index.Files, index.Vendored)
+	}
+	// main.go and web/package.json
+	if profile.TotalFiles != 2 {
+		t.Errorf("expected 2 first-party files, got %d", profile.TotalFiles)
+	}
+}
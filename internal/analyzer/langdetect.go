@@ -0,0 +1,212 @@
+package analyzer
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// sniffSize 按内容识别语言时只读取文件开头的这部分内容
+const sniffSize = 4 * 1024
+
+// languageHeuristic 歧义扩展名的内容规则，内容匹配全部 patterns 时识别为 language
+type languageHeuristic struct {
+	language string
+	patterns []*regexp.Regexp
+}
+
+// heuristic 创建内容规则，patterns 需要同时匹配
+func heuristic(language string, patterns ...string) languageHeuristic {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		compiled = append(compiled, regexp.MustCompile(pattern))
+	}
+	return languageHeuristic{language: language, patterns: compiled}
+}
+
+// ambiguousExtensions 有多种可能语言的扩展名，按顺序匹配内容规则，都不匹配时使用扩展名的默认语言
+var ambiguousExtensions = map[string][]languageHeuristic{
+	".h": {
+		heuristic("Objective-C", `(?m)^\s*(@(interface|implementation|protocol|property|end)\b|#import\s)`),
+		heuristic("C++", `(?m)^\s*(template\s*<|namespace\s+\w+|(class|struct)\s+\w+\s*(final\s*)?:\s*(public|protected|private)\b|using\s+namespace\s|#include\s*<(iostream|string|vector|map|memory|algorithm|cstdio|cstdlib|cstdint)>)|\bstd::`),
+	},
+	".m": {
+		heuristic("Objective-C", `(?m)^\s*(@(interface|implementation|protocol|end)\b|#(import|include)\s)`),
+		heuristic("MATLAB", `(?m)^\s*(function\s.*=|function\s+\w+|classdef\s)`),
+		// % 开头的行也可能是 Objective-C 的格式串，需要同时出现 MATLAB 特有的写法
+		heuristic("MATLAB", `(?m)^\s*%`, `(?m)^\s*(%%|%\{\s*$|end\s*;?\s*$|(if|for|while|switch)\s+[^({;]*$|(disp|plot|zeros|ones)\(.*\)\s*;?\s*$)`),
+	},
+	".pl": {
+		heuristic("Perl", `(?m)^(#!.*\bperl\b|\s*use\s+(strict|warnings)\b|\s*my\s+[$@%]|\s*sub\s+\w+|\s*package\s+[\w:]+;)`),
+		heuristic("Prolog", `(?m)^\s*(:-|[a-z]\w*(\([^)]*\))?\s*:-)`),
+	},
+	".ts": {
+		// Qt Linguist 翻译文件
+		heuristic("XML", `\A\s*(<\?xml|<!DOCTYPE TS>|<TS\b)`),
+	},
+}
+
+// shebangPattern 解析 shebang 中的解释器，支持 /usr/bin/env（包括 -S 和环境变量赋值）
+var shebangPattern = regexp.MustCompile(`\A#!\s*(?:\S*/)?([\w.+-]+)(?:[ \t]+(?:-\S+[ \t]+|\w+=\S*[ \t]+)*([\w.+-]+))?`)
+
+// interpreterVersion 解释器名称末尾的版本号，例如 python3.11 -> python
+var interpreterVersion = regexp.MustCompile(`[\d.]+$`)
+
+// interpreterLanguages 解释器名称 -> 语言名称
+var interpreterLanguages = map[string]string{
+	"python": "Python", "pypy": "Python",
+	"sh": "Shell", "bash": "Shell", "zsh": "Shell", "ksh": "Shell", "dash": "Shell", "ash": "Shell",
+	"node": "JavaScript", "nodejs": "JavaScript",
+	"deno": "TypeScript", "ts-node": "TypeScript", "tsx": "TypeScript",
+	"ruby": "Ruby", "jruby": "Ruby",
+	"perl": "Perl",
+	"php":  "PHP",
+	"lua":  "Lua", "luajit": "Lua",
+	"groovy": "Groovy",
+	"pwsh":   "PowerShell", "powershell": "PowerShell",
+	"elixir": "Elixir",
+	"swift":  "Swift",
+	"scala":  "Scala",
+	"kotlin": "Kotlin",
+	"swipl":  "Prolog",
+	"octave": "MATLAB",
+	"make":   "Makefile",
+}
+
+// modelinePatterns Emacs（-*- mode: python -*-）和 Vim（vim: set ft=python:）模式行
+var modelinePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`-\*-(?:.*;)?\s*mode:\s*([\w+.-]+)\s*(?:;.*)?-\*-`),
+	regexp.MustCompile(`-\*-\s*([\w+.-]+)\s*-\*-`),
+	regexp.MustCompile(`\b(?:vim?|ex):.*\b(?:ft|filetype|syntax)=([\w+.-]+)`),
+}
+
+// modeLanguages 模式行中的模式名称 -> 语言名称，未列出的名称按语言名称匹配（不区分大小写）
+var modeLanguages = map[string]string{
+	"sh": "Shell", "bash": "Shell", "zsh": "Shell", "shell-script": "Shell",
+	"js": "JavaScript", "javascript": "JavaScript",
+	"ts": "TypeScript", "typescript": "TypeScript",
+	"c++": "C++", "cpp": "C++",
+	"objc": "Objective-C", "objective-c": "Objective-C",
+	"cperl": "Perl",
+	"make":  "Makefile", "makefile": "Makefile",
+	"dockerfile": "Docker",
+	"octave":     "MATLAB",
+	"yml":        "YAML",
+	"ps1":        "PowerShell",
+}
+
+// nameToLanguage 小写语言名称 -> 语言定义
+var nameToLanguage = make(map[string]*camodels.Language)
+
+// identifyLanguage 识别文件 name（fsys 中的相对路径）的语言：歧义扩展名按内容规则区分，
+// 然后依次按扩展名、文件名识别；无扩展名的文件再按 shebang 和模式行识别。只读取文件开头 sniffSize 字节
+func identifyLanguage(fsys fs.FS, name string) *camodels.Language {
+	fileName := path.Base(name)
+	ext := strings.ToLower(path.Ext(fileName))
+	if heuristics, ok := ambiguousExtensions[ext]; ok {
+		if lang := matchHeuristics(heuristics, readPrefix(fsys, name)); lang != nil {
+			return lang
+		}
+	}
+	if lang := extToLanguage[ext]; lang != nil {
+		return lang
+	}
+	if lang := fileToLanguage[fileName]; lang != nil {
+		return lang
+	}
+	if ext == "" {
+		prefix := readPrefix(fsys, name)
+		if lang := shebangLanguage(prefix); lang != nil {
+			return lang
+		}
+		return modelineLanguage(prefix)
+	}
+	return nil
+}
+
+// readPrefix 读取文件开头 sniffSize 字节，二进制文件或读取失败时返回 nil
+func readPrefix(fsys fs.FS, name string) []byte {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	buf := make([]byte, sniffSize)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil
+	}
+	buf = buf[:n]
+	if bytes.IndexByte(buf, 0) >= 0 {
+		return nil
+	}
+	return bytes.TrimPrefix(buf, []byte("\xef\xbb\xbf"))
+}
+
+// matchHeuristics 返回第一个内容规则匹配的语言
+func matchHeuristics(heuristics []languageHeuristic, prefix []byte) *camodels.Language {
+	if prefix == nil {
+		return nil
+	}
+	for _, heuristic := range heuristics {
+		if heuristic.match(prefix) {
+			return nameToLanguage[strings.ToLower(heuristic.language)]
+		}
+	}
+	return nil
+}
+
+// match 内容是否匹配全部 patterns
+func (h languageHeuristic) match(prefix []byte) bool {
+	for _, pattern := range h.patterns {
+		if !pattern.Match(prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// shebangLanguage 按 shebang 中的解释器识别语言
+func shebangLanguage(prefix []byte) *camodels.Language {
+	match := shebangPattern.FindSubmatch(prefix)
+	if match == nil {
+		return nil
+	}
+	interpreter := string(match[1])
+	if interpreter == "env" && len(match[2]) > 0 {
+		interpreter = string(match[2])
+	}
+	if name, ok := interpreterLanguages[interpreter]; ok {
+		return nameToLanguage[strings.ToLower(name)]
+	}
+	if name, ok := interpreterLanguages[strings.TrimRight(interpreterVersion.ReplaceAllString(interpreter, ""), "-")]; ok {
+		return nameToLanguage[strings.ToLower(name)]
+	}
+	return nil
+}
+
+// modelineLanguage 按 Emacs / Vim 模式行识别语言，只检查文件开头的前几行
+func modelineLanguage(prefix []byte) *camodels.Language {
+	lines := strings.SplitN(string(prefix), "\n", 6)
+	for _, line := range lines[:min(len(lines), 5)] {
+		for _, re := range modelinePatterns {
+			match := re.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			mode := strings.ToLower(match[1])
+			if name, ok := modeLanguages[mode]; ok {
+				mode = strings.ToLower(name)
+			}
+			if lang := nameToLanguage[mode]; lang != nil {
+				return lang
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+// TestIdentifyLanguage verifies shebang, modeline and ambiguous-extension detection
+func TestIdentifyLanguage(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bin/tool":     {Data: []byte("#!/usr/bin/env python3\nprint('hi')\n")},
+		"bin/deploy":   {Data: []byte("#!/bin/bash -e\necho hi\n")},
+		"bin/serve":    {Data: []byte("#!/usr/bin/env -S NODE_ENV=production node --harmony\nconsole.log(1)\n")},
+		"bin/py311":    {Data: []byte("\xef\xbb\xbf#!/usr/local/bin/python3.11\n")},
+		"bin/unknown":  {Data: []byte("#!/usr/bin/awk -f\n")},
+		"scripts/task": {Data: []byte("# -*- mode: ruby -*-\nputs 1\n")},
+		"scripts/run":  {Data: []byte("# vim: set ft=sh:\necho hi\n")},
+		"scripts/blob": {Data: []byte("#!/bin/sh\x00\x01")},
+		"README":       {Data: []byte("plain text\n")},
+		"inc/a.h":      {Data: []byte("#ifndef A_H\nint add(int a, int b);\n#endif\n")},
+		"inc/b.h":      {Data: []byte("#pragma once\nnamespace app {\nclass B {};\n}\n")},
+		"inc/c.h":      {Data: []byte("#import <Foundation/Foundation.h>\n@interface C : NSObject\n@end\n")},
+		"src/d.m":      {Data: []byte("#import \"C.h\"\n@implementation C\n@end\n")},
+		"src/e.m":      {Data: []byte("function y = e(x)\n  y = x * 2;\nend\n")},
+		"src/e2.m":     {Data: []byte("% scale input\nx = 1;\nif x > 0\n  disp(x)\nend\n")},
+		"src/e3.m":     {Data: []byte("static void report(int n) {\n    fprintf(stderr, \"count: \"\n%d items\\n\", n);\n    if (n > 0) {\n        return;\n    }\n}\n")},
+		"src/e4.m":     {Data: []byte("% legacy table\nstatic int width = 10;\n")},
+		"src/f.pl":     {Data: []byte("use strict;\nmy $x = 1;\n")},
+		"src/g.pl":     {Data: []byte("parent(tom, bob).\nancestor(X, Y) :- parent(X, Y).\n")},
+		"src/h.ts":     {Data: []byte("export const h = 1;\n")},
+		"i18n/zh.ts":   {Data: []byte("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<!DOCTYPE TS>\n<TS version=\"2.1\"></TS>\n")},
+		"Dockerfile":   {Data: []byte("FROM alpine\n")},
+		"main.go":      {Data: []byte("#!/usr/bin/env python3\n")},
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"bin/tool", "Python"},
+		{"bin/deploy", "Shell"},
+		{"bin/serve", "JavaScript"},
+		{"bin/py311", "Python"},
+		{"bin/unknown", ""},
+		{"scripts/task", "Ruby"},
+		{"scripts/run", "Shell"},
+		{"scripts/blob", ""},
+		{"README", ""},
+		{"inc/a.h", "C"},
+		{"inc/b.h", "C++"},
+		{"inc/c.h", "Objective-C"},
+		{"src/d.m", "Objective-C"},
+		{"src/e.m", "MATLAB"},
+		{"src/e2.m", "MATLAB"},
+		{"src/e3.m", "Objective-C"},
+		{"src/e4.m", "Objective-C"},
+		{"src/f.pl", "Perl"},
+		{"src/g.pl", "Prolog"},
+		{"src/h.ts", "TypeScript"},
+		{"i18n/zh.ts", "XML"},
+		{"Dockerfile", "Docker"},
+		{"main.go", "Go"},
+	}
+	for _, tc := range tests {
+		got := ""
+		if lang := identifyLanguage(fsys, tc.name); lang != nil {
+			got = lang.Name
+		}
+		if got != tc.want {
+			t.Errorf("identifyLanguage(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestAnalyzeCodeProfileExtensionless verifies extensionless scripts are counted in the code profile
+func TestAnalyzeCodeProfileExtensionless(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":    {Data: []byte("package main\n")},
+		"bin/tool":   {Data: []byte("#!/usr/bin/env python3\nprint('hi')\n")},
+		"bin/deploy": {Data: []byte("#!/bin/bash\necho hi\n")},
+		"LICENSE":    {Data: []byte("MIT\n")},
+	}
+	az := NewCodeAnalyzer()
+	opts := WalkOptions{MaxFiles: 100, MaxFileSize: 1024 * 1024, MaxDepth: 10}
+	profile, _, _, err := az.AnalyzeCodeProfileFS(context.Background(), "/virtual", fsys, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.TotalFiles != 3 {
+		t.Errorf("expected 3 analyzed files, got %d", profile.TotalFiles)
+	}
+	found := map[string]bool{}
+	for _, info := range profile.LanguageInfos {
+		found[info.Name] = true
+	}
+	if !found["Python"] || !found["Shell"] {
+		t.Errorf("expected Python and Shell in language infos, got %+v", profile.LanguageInfos)
+	}
+}
@@ -15,6 +15,22 @@ type WalkOptions struct {
 	// FollowSymlinks controls whether symbolic links are followed.
 	// Default: false (symlinks are skipped to avoid cycles).
 	FollowSymlinks bool
+	// IgnoreFiles honours .gitignore and .xcanvasignore files (nested files and
+	// negation included) found during traversal.
+	// Default: true.
+	IgnoreFiles bool
+	// IgnoreSets names the built-in per-ecosystem ignore sets to apply, see IgnoreSetNames.
+	// Default: all sets.
+	IgnoreSets []string
+	// SkipVendored also skips the dependency directories of the ignore sets
+	// (vendor/, node_modules/, ...). By default they are walked as vendored code,
+	// reported in CodeProfile.Vendored and usable as evidence by vendored rules.
+	// Default: false.
+	SkipVendored bool
+	// CountGenerated counts generated and minified files in the main statistics
+	// instead of reporting them separately.
+	// Default: false.
+	CountGenerated bool
 }
 
 // DefaultWalkOptions returns production-safe defaults.
@@ -24,6 +40,8 @@ func DefaultWalkOptions() WalkOptions {
 		MaxFileSize:    2 * 1024 * 1024, // 2MB
 		MaxDepth:       30,
 		FollowSymlinks: false,
+		IgnoreFiles:    true,
+		IgnoreSets:     IgnoreSetNames(),
 	}
 }
 
@@ -52,4 +70,6 @@ type WalkDiagnostics struct {
 	SkippedSymlinks int
 	// MaxDepthReached is true when traversal hit the depth limit.
 	MaxDepthReached bool
+	// Ignored is the count of files and directories skipped by ignore rules.
+	Ignored int
 }
@@ -0,0 +1,38 @@
+package analyzer
+
+// vendorPatterns 第三方（vendored）代码的路径规则，参考 GitHub linguist 的 vendor.yml：
+// 依赖目录、复制进项目的第三方目录、构建工具包装脚本和常见的前端库文件
+var vendorPatterns = compilePatterns(
+	// 依赖目录
+	`(^|/)vendors?/`,
+	`(^|/)node_modules/`,
+	`(^|/)bower_components/`,
+	`(^|/)jspm_packages/`,
+	`(^|/)Godeps/_workspace/`,
+	`(^|/)(site|dist)-packages/`,
+	`(^|/)Pods/`,
+	`(^|/)Carthage/`,
+	`(^|/)packages/[^/]+\.\d+(\.\d+)*/`, // NuGet packages/<Name>.<Version>/
+	// 复制进项目的第三方代码
+	`(?i)(^|/)(3rd|third)[-_]?party/`,
+	`(^|/)extern(al)?/`,
+	`(^|/)deps/`,
+	// 构建工具包装脚本
+	`(^|/)gradlew(\.bat)?$`,
+	`(^|/)gradle/wrapper/`,
+	`(^|/)mvnw(\.cmd)?$`,
+	// 常见的前端库文件
+	`(?i)(^|/)jquery([-.]\d+(\.\d+)*)?([-.]min)?\.js$`,
+	`(?i)(^|/)jquery[-.]ui(\.min)?\.(js|css)$`,
+	`(?i)(^|/)bootstrap(\.bundle)?(\.min)?\.(js|css)$`,
+	`(?i)(^|/)angular(\.min)?\.js$`,
+	`(?i)(^|/)(react|react-dom)(\.(development|production))?(\.min)?\.js$`,
+	`(?i)(^|/)vue(\.runtime)?(\.global)?(\.prod)?(\.min)?\.js$`,
+	`(?i)(^|/)(lodash|underscore|backbone|moment|d3|ember|prototype|mootools|modernizr|require|popper|chart|select2|echarts|axios)(\.min)?\.js$`,
+	`(?i)(^|/)(normalize|font-awesome|animate)(\.min)?\.css$`,
+)
+
+// IsVendoredPath 判断相对路径（正斜杠分隔）是否为第三方（vendored）代码
+func IsVendoredPath(relPath string) bool {
+	return matchAny(vendorPatterns, relPath)
+}
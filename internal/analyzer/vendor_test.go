@@ -0,0 +1,35 @@
+package analyzer
+
+import "testing"
+
+// TestIsVendoredPath verifies linguist-style vendored path heuristics
+func TestIsVendoredPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"vendor/github.com/pkg/errors/errors.go", true},
+		{"web/node_modules/react/index.js", true},
+		{"third_party/zlib/inflate.c", true},
+		{"src/ThirdParty/lib.cs", true},
+		{"libs/3rdparty/x.js", true},
+		{"static/js/jquery-3.6.0.min.js", true},
+		{"static/js/jquery.js", true},
+		{"public/css/bootstrap.min.css", true},
+		{"assets/angular.min.js", true},
+		{"gradlew", true},
+		{"gradle/wrapper/gradle-wrapper.properties", true},
+		{"ios/Pods/AFNetworking/AFURLSession.m", true},
+		{"packages/Newtonsoft.Json.13.0.1/lib/a.dll", true},
+		{"main.go", false},
+		{"src/vendoring.go", false},
+		{"packages/web/index.ts", false},
+		{"static/js/app.min.js", false},
+		{"src/jquery-plugin.js", false},
+	}
+	for _, tc := range tests {
+		if got := IsVendoredPath(tc.path); got != tc.want {
+			t.Errorf("IsVendoredPath(%q) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
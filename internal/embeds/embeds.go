@@ -1,7 +1,6 @@
 package embeds
 
 import (
-	"errors"
 	"io"
 	"io/fs"
 	"strings"
@@ -9,6 +8,7 @@ import (
 	"github.com/winezer0/xcanvas/camodels"
 	"github.com/winezer0/xcanvas/internal/embeds_frame"
 	"github.com/winezer0/xcanvas/internal/embeds_lang"
+	"github.com/winezer0/xcanvas/internal/embeds_lifecycle"
 	"gopkg.in/yaml.v3"
 )
 
@@ -64,51 +64,36 @@ func EmbeddedFrameRules() []*camodels.Framework {
 	return allRules
 }
 
-// EmbeddedLangRules 从 embed.FS 中加载所有 .yml 文件并解析为语言分类规则
-// 支持两种 YAML 格式：
-//  1. 单个文件包含一个 LangRule 数组（推荐）
-//  2. 多文档 YAML 流（每个文档是一个规则）
-func EmbeddedLangRules() map[string]camodels.Language {
-	rules := make(map[string]camodels.Language)
+// EmbeddedLangRules 加载内置的语言分类规则，同名语言按 LoadLangRules 的语义合并，返回合并后的规则和冲突
+func EmbeddedLangRules() (map[string]camodels.Language, []LangConflict) {
+	rules, conflicts, err := LoadLangRules(embeds_lang.LanguageEmbedFS)
+	if err != nil {
+		// Should not happen in a valid build
+		return make(map[string]camodels.Language), nil
+	}
+	return rules, conflicts
+}
+
+// EmbeddedLifecycles 从 embed.FS 中加载所有 .yml 文件并解析为生命周期数据，每个文件为 Lifecycle 数组
+func EmbeddedLifecycles() []camodels.Lifecycle {
+	var lifecycles []camodels.Lifecycle
 
-	files, err := fs.Glob(embeds_lang.LanguageEmbedFS, "*.yml")
+	files, err := fs.Glob(embeds_lifecycle.LifecycleEmbedFS, "*.yml")
 	if err != nil {
-		return rules // 返回空 map
+		return lifecycles
 	}
 
 	for _, filename := range files {
-		content, err := embeds_lang.LanguageEmbedFS.ReadFile(filename)
+		content, err := embeds_lifecycle.LifecycleEmbedFS.ReadFile(filename)
 		if err != nil {
-			continue // 跳过无法读取的文件
-		}
-
-		// 尝试作为单文档数组解析
-		var rulesArray []camodels.Language
-		if err := yaml.Unmarshal(content, &rulesArray); err == nil && len(rulesArray) > 0 && rulesArray[0].Name != "" {
-			for _, rule := range rulesArray {
-				if rule.Name != "" {
-					rules[strings.ToLower(rule.Name)] = rule
-				}
-			}
 			continue
 		}
-
-		// 回退到多文档流解析
-		decoder := yaml.NewDecoder(strings.NewReader(string(content)))
-		for {
-			var rule camodels.Language
-			if err := decoder.Decode(&rule); err != nil {
-				if errors.Is(err, io.EOF) {
-					break
-				}
-				// 遇到非 EOF 错误，放弃当前文件
-				break
-			}
-			if rule.Name != "" {
-				rules[strings.ToLower(rule.Name)] = rule
-			}
+		var items []camodels.Lifecycle
+		if err := yaml.Unmarshal(content, &items); err != nil {
+			continue
 		}
+		lifecycles = append(lifecycles, items...)
 	}
 
-	return rules
+	return lifecycles
 }
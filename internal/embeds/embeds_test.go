@@ -1,6 +1,7 @@
 package embeds
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -37,7 +38,7 @@ func TestEmbeddedRulesVerification(t *testing.T) {
 		if rule.Type == "" {
 			t.Errorf("Rule '%s' has empty type", rule.Name)
 		}
-		if rule.Language == "" {
+		if rule.Language == "" && len(rule.Languages) == 0 {
 			t.Errorf("Rule '%s' has empty language", rule.Name)
 		}
 
@@ -63,3 +64,21 @@ func TestEmbeddedRulesVerification(t *testing.T) {
 
 	t.Logf("Successfully verified %d embedded rules", len(rules))
 }
+
+// TestEmbeddedLifecycles tests that every lifecycle entry is keyed by an embedded rule name
+func TestEmbeddedLifecycles(t *testing.T) {
+	lifecycles := EmbeddedLifecycles()
+	if len(lifecycles) == 0 {
+		t.Fatal("Expected embedded lifecycles to be loaded, got 0")
+	}
+
+	ruleNames := make(map[string]bool)
+	for _, rule := range EmbeddedFrameRules() {
+		ruleNames[strings.ToLower(rule.Name)] = true
+	}
+	for _, lifecycle := range lifecycles {
+		if !ruleNames[strings.ToLower(lifecycle.Name)] {
+			t.Errorf("lifecycle %q does not match any embedded rule", lifecycle.Name)
+		}
+	}
+}
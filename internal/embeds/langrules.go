@@ -0,0 +1,192 @@
+package embeds
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// LangConflict 语言规则之间的冲突，加载时按确定的顺序（文件名顺序、文件内顺序）保留先出现的定义
+type LangConflict struct {
+	Language string   // 冲突的语言名称
+	Files    []string // 涉及的规则文件
+	Message  string   // 冲突说明
+}
+
+// String 返回冲突的单行描述
+func (c LangConflict) String() string {
+	return fmt.Sprintf("%s (%s): %s", c.Language, strings.Join(c.Files, ", "), c.Message)
+}
+
+// langDeclaration 规则文件中的一条语言声明
+type langDeclaration struct {
+	file string
+	rule camodels.Language
+}
+
+// defines 判断声明是否包含语言定义（扩展名、文件名、注释语法），只声明分类和动态规则的是附加声明
+func (d langDeclaration) defines() bool {
+	return len(d.rule.Extensions) > 0 || len(d.rule.Filenames) > 0 || len(d.rule.LineComments) > 0 || len(d.rule.MultiLine) > 0
+}
+
+// LoadLangRules 从 fsys 根目录的 .yml 文件加载语言规则，并按语言名称（不区分大小写）合并：
+//   - 同一语言可以在多个分类文件中声明，动态规则和隐含语言合并；附加声明的分类只通过它的动态规则生效
+//   - 只有一个声明包含语言定义（扩展名、文件名、注释语法），它的 category 是默认分类；多个定义时保留先出现的并报告冲突
+//   - 同一扩展名或文件名只属于先声明它的语言，其他语言中的重复声明被移除并报告冲突
+//
+// 文件按文件名顺序加载，支持单个数组文档和多文档 YAML 流两种格式
+func LoadLangRules(fsys fs.FS) (map[string]camodels.Language, []LangConflict, error) {
+	files, err := fs.Glob(fsys, "*.yml")
+	if err != nil {
+		return nil, nil, err
+	}
+	var declarations []langDeclaration
+	for _, filename := range files {
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+		rules, err := parseLangRules(content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse %s: %w", filename, err)
+		}
+		for _, rule := range rules {
+			if rule.Name != "" {
+				declarations = append(declarations, langDeclaration{file: filename, rule: rule})
+			}
+		}
+	}
+	rules, conflicts := mergeLangDeclarations(declarations)
+	return rules, conflicts, nil
+}
+
+// parseLangRules 解析单个语言规则文件：先尝试数组格式，再回退到多文档流
+func parseLangRules(content []byte) ([]camodels.Language, error) {
+	var rulesArray []camodels.Language
+	if err := yaml.Unmarshal(content, &rulesArray); err == nil && len(rulesArray) > 0 && rulesArray[0].Name != "" {
+		return rulesArray, nil
+	}
+
+	var rules []camodels.Language
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	for {
+		var rule camodels.Language
+		if err := decoder.Decode(&rule); err != nil {
+			if errors.Is(err, io.EOF) {
+				return rules, nil
+			}
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+}
+
+// mergeLangDeclarations 按 LoadLangRules 的合并语义合并语言声明
+func mergeLangDeclarations(declarations []langDeclaration) (map[string]camodels.Language, []LangConflict) {
+	var conflicts []LangConflict
+	var order []string
+	merged := make(map[string]*camodels.Language)
+	definedIn := make(map[string]string) // 语言 -> 包含语言定义的文件
+	declaredIn := make(map[string][]string)
+
+	for _, decl := range declarations {
+		key := strings.ToLower(decl.rule.Name)
+		declaredIn[key] = appendUnique(declaredIn[key], decl.file)
+		lang, ok := merged[key]
+		if !ok {
+			rule := decl.rule
+			merged[key] = &rule
+			order = append(order, key)
+			if decl.defines() {
+				definedIn[key] = decl.file
+			}
+			continue
+		}
+
+		lang.Dynamic = append(lang.Dynamic, decl.rule.Dynamic...)
+		for _, implied := range decl.rule.Implies {
+			lang.Implies = appendUnique(lang.Implies, implied)
+		}
+		if !decl.defines() {
+			continue
+		}
+		if file, ok := definedIn[key]; ok {
+			conflicts = append(conflicts, LangConflict{
+				Language: lang.Name,
+				Files:    []string{file, decl.file},
+				Message:  fmt.Sprintf("defined more than once, keeping the definition in %s; declare only category and dynamic rules in the other files", file),
+			})
+			continue
+		}
+		// 先出现的是附加声明，采用这个声明的定义和默认分类
+		definedIn[key] = decl.file
+		lang.Name = decl.rule.Name
+		lang.LineComments = decl.rule.LineComments
+		lang.MultiLine = decl.rule.MultiLine
+		lang.Extensions = decl.rule.Extensions
+		lang.Filenames = decl.rule.Filenames
+		lang.Category = decl.rule.Category
+	}
+
+	// 扩展名和文件名只属于先声明它的语言
+	extOwner := make(map[string]string)
+	nameOwner := make(map[string]string)
+	rules := make(map[string]camodels.Language, len(merged))
+	for _, key := range order {
+		lang := merged[key]
+		lang.Extensions = claimUnique(lang.Extensions, strings.ToLower, extOwner, key, func(ext, owner string) {
+			conflicts = append(conflicts, LangConflict{
+				Language: lang.Name,
+				Files:    appendUnique([]string{definedIn[owner]}, definedIn[key]),
+				Message:  fmt.Sprintf("extension %s already belongs to %s", ext, merged[owner].Name),
+			})
+		})
+		lang.Filenames = claimUnique(lang.Filenames, func(s string) string { return s }, nameOwner, key, func(name, owner string) {
+			conflicts = append(conflicts, LangConflict{
+				Language: lang.Name,
+				Files:    appendUnique([]string{definedIn[owner]}, definedIn[key]),
+				Message:  fmt.Sprintf("filename %s already belongs to %s", name, merged[owner].Name),
+			})
+		})
+		if _, ok := definedIn[key]; !ok {
+			conflicts = append(conflicts, LangConflict{
+				Language: lang.Name,
+				Files:    declaredIn[key],
+				Message:  "declared without extensions, filenames or comment syntax in every file",
+			})
+		}
+		rules[key] = *lang
+	}
+	return rules, conflicts
+}
+
+// claimUnique 为语言 key 认领 values 中尚未被其他语言认领的值，返回保留的值；已被认领的值交给 conflict 处理
+func claimUnique(values []string, normalize func(string) string, owners map[string]string, key string, conflict func(value, owner string)) []string {
+	var kept []string
+	for _, value := range values {
+		normalized := normalize(value)
+		owner, ok := owners[normalized]
+		if !ok {
+			owners[normalized] = key
+			kept = append(kept, value)
+		} else if owner != key {
+			conflict(value, owner)
+		}
+	}
+	return kept
+}
+
+// appendUnique 追加 s 中不存在的非空字符串
+func appendUnique(s []string, v string) []string {
+	if v == "" || slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
@@ -0,0 +1,142 @@
+package embeds
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestLoadLangRulesMerge verifies candidate category declarations merge into the defining declaration
+func TestLoadLangRulesMerge(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.yml": {Data: []byte(`
+- name: JavaScript
+  category: backend
+  dynamic:
+    - category: backend
+      dependencies: ["express"]
+`)},
+		"b.yml": {Data: []byte(`
+- name: javascript
+  extensions: [".js"]
+  category: frontend
+  dynamic:
+    - category: frontend
+      dependencies: ["react"]
+- name: Python
+  extensions: [".py"]
+  category: backend
+- name: MATLAB
+  line_comments: ["%"]
+  multi_line: [["%{", "%}"]]
+  category: backend
+`)},
+		"c.yml": {Data: []byte(`
+- name: Python
+  category: desktop
+  implies: ["Cython"]
+`)},
+	}
+	rules, conflicts, err := LoadLangRules(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+
+	js := rules["javascript"]
+	if js.Name != "javascript" || js.Category != "frontend" {
+		t.Errorf("unexpected JavaScript merge: %q %q", js.Name, js.Category)
+	}
+	if len(js.Dynamic) != 2 || !slices.Equal(js.Extensions, []string{".js"}) {
+		t.Errorf("expected merged dynamic rules and extensions, got %+v", js)
+	}
+	python := rules["python"]
+	if python.Category != "backend" || !slices.Equal(python.Implies, []string{"Cython"}) {
+		t.Errorf("unexpected Python merge: %+v", python)
+	}
+	matlab := rules["matlab"]
+	if !slices.Equal(matlab.LineComments, []string{"%"}) || len(matlab.MultiLine) != 1 {
+		t.Errorf("expected MATLAB comment syntax to load, got %+v", matlab)
+	}
+}
+
+// TestLoadLangRulesConflicts verifies duplicate definitions and shared extensions are reported and resolved deterministically
+func TestLoadLangRulesConflicts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"backend.yml": {Data: []byte(`
+- name: Node.js
+  extensions: [".js", ".mjs"]
+  category: backend
+- name: Rust
+  extensions: [".rs"]
+  category: backend
+`)},
+		"desktop.yml": {Data: []byte(`
+- name: Rust
+  extensions: [".rs"]
+  category: desktop
+- name: Tauri
+  category: desktop
+`)},
+		"frontend.yml": {Data: []byte(`
+- name: JavaScript
+  extensions: [".js", ".jsx"]
+  filenames: ["Jakefile"]
+  category: frontend
+- name: Jake
+  filenames: ["Jakefile"]
+  category: frontend
+`)},
+	}
+	for range 5 {
+		rules, conflicts, err := LoadLangRules(fsys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got []string
+		for _, conflict := range conflicts {
+			got = append(got, conflict.String())
+		}
+		want := []string{
+			"Rust (backend.yml, desktop.yml): defined more than once",
+			"Tauri (desktop.yml): declared without extensions",
+			"JavaScript (backend.yml, frontend.yml): extension .js already belongs to Node.js",
+			"Jake (frontend.yml): filename Jakefile already belongs to JavaScript",
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %d conflicts, got %v", len(want), got)
+		}
+		for i := range want {
+			if !strings.HasPrefix(got[i], want[i]) {
+				t.Errorf("conflict %d = %q, want prefix %q", i, got[i], want[i])
+			}
+		}
+		if rules["rust"].Category != "backend" {
+			t.Errorf("expected the first Rust definition to win, got %+v", rules["rust"])
+		}
+		if !slices.Equal(rules["javascript"].Extensions, []string{".jsx"}) || len(rules["jake"].Filenames) != 0 {
+			t.Errorf("expected claimed extensions and filenames to be removed, got %+v / %+v", rules["javascript"], rules["jake"])
+		}
+	}
+}
+
+// TestEmbeddedLangRulesConflicts verifies the bundled language rules are free of conflicts
+func TestEmbeddedLangRulesConflicts(t *testing.T) {
+	rules, conflicts := EmbeddedLangRules()
+	for _, conflict := range conflicts {
+		t.Errorf("embedded language rule conflict: %s", conflict)
+	}
+	for name, category := range map[string]string{"python": "desktop", "rust": "desktop", "c#": "desktop", "javascript": "backend"} {
+		if !slices.ContainsFunc(rules[name].Dynamic, func(d camodels.DynamicCategory) bool { return d.Category == category }) {
+			t.Errorf("expected %s to carry the %s dynamic rules, got %+v", name, category, rules[name].Dynamic)
+		}
+	}
+	if rules["python"].Category != "backend" || rules["javascript"].Category != "frontend" {
+		t.Errorf("unexpected default categories: python=%s javascript=%s", rules["python"].Category, rules["javascript"].Category)
+	}
+}
@@ -0,0 +1,9 @@
+// Package embeds_lifecycle provides the embedded lifecycle (end-of-life) dataset.
+package embeds_lifecycle
+
+import (
+	"embed"
+)
+
+//go:embed *.yml
+var LifecycleEmbedFS embed.FS
@@ -0,0 +1,67 @@
+package embeds_lifecycle
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestAllYamlFiles tests that all embedded lifecycle files are valid
+func TestAllYamlFiles(t *testing.T) {
+	files, err := fs.Glob(LifecycleEmbedFS, "*.yml")
+	if err != nil {
+		t.Fatalf("Failed to get YAML files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No YAML files found in embedded filesystem")
+	}
+
+	seen := make(map[string]bool)
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			content, err := LifecycleEmbedFS.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Failed to read file %s: %v", file, err)
+			}
+			var lifecycles []struct {
+				Name   string `yaml:"name"`
+				Cycles []struct {
+					Cycle  string `yaml:"cycle"`
+					EOL    string `yaml:"eol"`
+					Latest string `yaml:"latest"`
+				} `yaml:"cycles"`
+			}
+			if err := yaml.Unmarshal(content, &lifecycles); err != nil {
+				t.Fatalf("Failed to parse YAML file %s: %v", file, err)
+			}
+			for _, lifecycle := range lifecycles {
+				name := strings.ToLower(lifecycle.Name)
+				if name == "" || len(lifecycle.Cycles) == 0 {
+					t.Errorf("lifecycle %q has no name or cycles", lifecycle.Name)
+				}
+				if seen[name] {
+					t.Errorf("duplicate lifecycle %q", lifecycle.Name)
+				}
+				seen[name] = true
+				cycles := make(map[string]bool)
+				for _, cycle := range lifecycle.Cycles {
+					if cycle.Cycle == "" || cycles[cycle.Cycle] {
+						t.Errorf("lifecycle %s has empty or duplicate cycle %q", lifecycle.Name, cycle.Cycle)
+					}
+					cycles[cycle.Cycle] = true
+					if cycle.EOL != "" && cycle.EOL != "true" && cycle.EOL != "false" {
+						if _, err := time.Parse("2006-01-02", cycle.EOL); err != nil {
+							t.Errorf("lifecycle %s cycle %s has invalid eol %q", lifecycle.Name, cycle.Cycle, cycle.EOL)
+						}
+					}
+					if cycle.Latest != "" && !strings.HasPrefix(cycle.Latest, cycle.Cycle+".") {
+						t.Errorf("lifecycle %s cycle %s latest %q is not in cycle", lifecycle.Name, cycle.Cycle, cycle.Latest)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package frameengine
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/manifest"
+)
+
+// collectDependencies 解析索引中的依赖清单文件（pom.xml 等），文件内容复用检测缓存
+func collectDependencies(matcher *IndexMatcher, fileContentCache map[string][]byte) []camodels.Dependency {
+	return manifest.Collect(matcher.Index.Files, func(relPath string) ([]byte, error) {
+		return matcher.ReadFile(filepath.Join(matcher.Index.RootDir, filepath.FromSlash(relPath)), fileContentCache)
+	})
+}
+
+// dependenciesForFramework 只保留与规则语言对应生态的依赖，避免不同生态的同名包误匹配；
+// 规则语言无法推断生态时（例如 language: any）返回全部依赖
+func dependenciesForFramework(dependencies []camodels.Dependency, framework *camodels.Framework) []camodels.Dependency {
+	ecosystems := make(map[string]bool)
+	for _, language := range framework.AllLanguages() {
+		if ecosystem := camodels.EcosystemForLanguage(language); ecosystem != "" {
+			ecosystems[ecosystem] = true
+		}
+	}
+	if len(ecosystems) == 0 {
+		return dependencies
+	}
+	var result []camodels.Dependency
+	for _, dependency := range dependencies {
+		if ecosystems[dependency.Ecosystem] {
+			result = append(result, dependency)
+		}
+	}
+	return result
+}
+
+// findDependencies 返回名称匹配模式的依赖，模式支持通配符，不区分大小写，例如 "org.springframework:spring-*"
+func findDependencies(dependencies []camodels.Dependency, pattern string) []camodels.Dependency {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	var result []camodels.Dependency
+	for _, dependency := range dependencies {
+		if matched, _ := path.Match(pattern, strings.ToLower(dependency.Name)); matched {
+			result = append(result, dependency)
+		}
+	}
+	return result
+}
+
+// matchDependencies 检查所有依赖模式是否都存在，返回每个模式的命中统计（文件数和依赖数）
+func matchDependencies(dependencies []camodels.Dependency, patterns []string) (bool, []contentMatch) {
+	counts := make([]contentMatch, 0, len(patterns))
+	for _, pattern := range patterns {
+		found := findDependencies(dependencies, pattern)
+		if len(found) == 0 {
+			return false, nil
+		}
+		files := make(map[string]bool)
+		for _, dependency := range found {
+			files[dependency.File] = true
+		}
+		counts = append(counts, contentMatch{Pattern: "dependency " + pattern, Files: len(files), Matches: len(found)})
+	}
+	return true, counts
+}
+
+// dependencyVersions 从解析后的依赖中提取版本详情，未解析出版本的依赖会被忽略
+func dependencyVersions(versionExtractor camodels.VersionExtractor, dependencies []camodels.Dependency) []*camodels.VersionInfo {
+	var result []*camodels.VersionInfo
+	for _, dependency := range findDependencies(dependencies, versionExtractor.Dependency) {
+		version := formatVersion(dependency.Version)
+		if version == "" {
+			continue
+		}
+		raw := dependency.Raw
+		if raw == "" {
+			raw = dependency.Version
+		}
+		source := dependency.Source
+		if isVersionRange(dependency.Version) {
+			// 清单中的通配符版本（例如 "1.x"）只是范围下界
+			source = camodels.VersionSourceRange
+		}
+		if versionExtractor.Source != "" {
+			source = versionExtractor.Source
+		}
+		result = append(result, &camodels.VersionInfo{
+			Version: version,
+			Raw:     raw,
+			Source:  source,
+			Name:    dependency.Name,
+			File:    dependency.File,
+		})
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package frameengine
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestDependencyRules tests dependency based rule matching and version extraction
+func TestDependencyRules(t *testing.T) {
+	dependencies := []camodels.Dependency{
+		{Ecosystem: camodels.EcosystemMaven, Name: "org.springframework:spring-core", Version: "5.3.20", Raw: "${spring.version}", Source: camodels.VersionSourcePinned, File: "a/pom.xml"},
+		{Ecosystem: camodels.EcosystemMaven, Name: "org.springframework:spring-web", Version: "5.3.20", Raw: "${spring.version}", Source: camodels.VersionSourcePinned, File: "b/pom.xml"},
+		{Ecosystem: camodels.EcosystemMaven, Name: "com.alibaba:fastjson", File: "a/pom.xml"},
+	}
+
+	matched, counts := matchDependencies(dependencies, []string{"org.springframework:spring-*", "com.alibaba:fastjson"})
+	if !matched || len(counts) != 2 || counts[0].Files != 2 || counts[0].Matches != 2 {
+		t.Errorf("matchDependencies() = %v, %+v", matched, counts)
+	}
+	if matched, _ := matchDependencies(dependencies, []string{"com.alibaba:fastjson", "log4j:log4j"}); matched {
+		t.Error("matchDependencies() matched with a missing dependency")
+	}
+
+	// 未解析出版本的依赖不产生版本
+	if versions := dependencyVersions(camodels.VersionExtractor{Dependency: "com.alibaba:fastjson"}, dependencies); len(versions) != 0 {
+		t.Errorf("dependencyVersions() = %+v, want none", versions)
+	}
+	versions := dependencyVersions(camodels.VersionExtractor{Dependency: "ORG.SPRINGFRAMEWORK:spring-core"}, dependencies)
+	if len(versions) != 1 || versions[0].Version != "5.3.20" || versions[0].Raw != "${spring.version}" || versions[0].File != "a/pom.xml" {
+		t.Errorf("dependencyVersions() = %+v", versions)
+	}
+
+	// 通配符版本只保留数字前缀并视为范围，"*" 等占位符不产生版本
+	wildcards := []camodels.Dependency{
+		{Ecosystem: camodels.EcosystemNPM, Name: "lodash", Version: "4.x", Raw: "4.x", Source: camodels.VersionSourcePinned, File: "package.json"},
+		{Ecosystem: camodels.EcosystemNPM, Name: "left-pad", Version: "*", Raw: "*", Source: camodels.VersionSourcePinned, File: "package.json"},
+	}
+	versions = dependencyVersions(camodels.VersionExtractor{Dependency: "lodash"}, wildcards)
+	if len(versions) != 1 || versions[0].Version != "4" || versions[0].Source != camodels.VersionSourceRange {
+		t.Errorf("dependencyVersions() = %+v, want 4 from a range", versions)
+	}
+	if versions := dependencyVersions(camodels.VersionExtractor{Dependency: "left-pad"}, wildcards); len(versions) != 0 {
+		t.Errorf("dependencyVersions() = %+v, want none", versions)
+	}
+}
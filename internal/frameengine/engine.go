@@ -2,7 +2,6 @@
 package frameengine
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/winezer0/slogs"
@@ -10,16 +9,22 @@ import (
 	"github.com/winezer0/xcanvas/camodels"
 )
 
-// formatVersion 格式化版本号，去除常见前缀和多余字符
+// formatVersion 格式化版本号，去除常见前缀和多余字符。
+// 复合范围（例如 ">=2.0,<3"、"[1.0,2.0)"、">=1.0 <2.0"、"1.x || 2.x"）只保留第一个约束的版本，
+// 完整的范围由 Raw 和 Source 记录。
+// 通配符段被去除（例如 "1.x" -> "1"、"2.1.*" -> "2.1"），"*"、"latest" 等不含版本号的值返回空
 func formatVersion(version string) string {
 	if version == "" {
 		return ""
 	}
 
-	// 去除常见的版本前缀
-	version = strings.TrimPrefix(version, "^")
-	version = strings.TrimPrefix(version, "~")
-	version = strings.TrimPrefix(version, "=")
+	// 去除常见的版本范围前缀，例如 ^ ~ = >= ~= [ ( 等
+	version = strings.TrimLeft(strings.TrimSpace(version), "^~=<>!([ ")
+
+	// 只保留第一个约束
+	if idx := strings.IndexAny(version, ",| "); idx >= 0 {
+		version = version[:idx]
+	}
 	version = strings.TrimSpace(version)
 
 	// 去除构建元数据
@@ -27,9 +32,33 @@ func formatVersion(version string) string {
 		version = version[:idx]
 	}
 
+	// 通配符段及其后的部分不是具体版本
+	parts := strings.Split(version, ".")
+	for i, part := range parts {
+		if isWildcardSegment(part) {
+			parts = parts[:i]
+			break
+		}
+	}
+	version = strings.Join(parts, ".")
+
+	// 不以数字开头（允许 v 前缀）的值是占位符或标签，例如 "latest"、"next"、"dev-master"
+	if !startsWithDigit(strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")) {
+		return ""
+	}
 	return version
 }
 
+// isWildcardSegment 判断版本号的一段是否为通配符，例如 "x"、"X"、"*"
+func isWildcardSegment(part string) bool {
+	return part == "x" || part == "X" || part == "*"
+}
+
+// startsWithDigit 判断字符串是否以数字开头
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
 // CanvasEngine 实现框架和组件检测功能。
 type CanvasEngine struct {
 	rules          []*camodels.Framework
@@ -65,6 +94,13 @@ func NewCanvasEngine(rulesDir string) (*CanvasEngine, error) {
 // DetectFrameworks 根据加载的规则检测给定目录中的框架和组件。
 // 使用文件索引进行加速。
 func (e *CanvasEngine) DetectFrameworks(index *camodels.FileIndex, languages []string) (*camodels.DetectionInfo, error) {
+	result, _, err := e.DetectWithDependencies(index, languages)
+	return result, err
+}
+
+// DetectWithDependencies 检测框架和组件，同时返回解析出的全部依赖（按生态、名称排序），
+// 依赖清单只解析一次，供规则匹配和依赖清单输出共用。
+func (e *CanvasEngine) DetectWithDependencies(index *camodels.FileIndex, languages []string) (*camodels.DetectionInfo, []camodels.Dependency, error) {
 	result := &camodels.DetectionInfo{
 		Frameworks: []camodels.DetectedItem{},
 		Components: []camodels.DetectedItem{},
@@ -79,20 +115,31 @@ func (e *CanvasEngine) DetectFrameworks(index *camodels.FileIndex, languages []s
 	// 文件内容缓存
 	fileContentCache := make(map[string][]byte)
 
+	// 解析依赖清单，供基于依赖的规则和版本提取使用
+	dependencies := collectDependencies(matcher, fileContentCache)
+
 	// 遍历所有规则，对每个框架进行检测
 	for _, framework := range filteredRules {
+		frameworkDependencies := dependenciesForFramework(dependencies, framework)
 		// 遍历框架的所有规则（OR关系）
-		if matchFrame(matcher, framework.Rules, fileContentCache) {
-			// 提取版本信息
-			version := extractorVersion(matcher, framework.Versions, fileContentCache)
+		if matched, contents := matchFrame(matcher, framework.Rules, frameworkDependencies, fileContentCache); matched {
 			// 规则匹配成功，创建检测结果
 			item := camodels.DetectedItem{
 				Name:     framework.Name,
 				Type:     framework.Type,
 				Language: framework.Language,
-				Version:  version,
 				Category: framework.Category,
-				Evidence: fmt.Sprintf("FrameRule matched for %s", framework.Name),
+				Evidence: formatEvidence(framework.Name, contents),
+			}
+			// 提取版本信息
+			if versions := extractorVersions(matcher, framework.Versions, frameworkDependencies, fileContentCache); len(versions) > 0 {
+				ecosystem := camodels.EcosystemForLanguage(framework.Language)
+				for _, versionInfo := range versions {
+					versionInfo.ParseFor(ecosystem)
+				}
+				item.Version = versions[0].Version
+				item.VersionInfo = versions[0]
+				item.Versions = versions
 			}
 			// 根据规则类型添加到结果
 			switch framework.Type {
@@ -104,17 +151,31 @@ func (e *CanvasEngine) DetectFrameworks(index *camodels.FileIndex, languages []s
 		}
 	}
 
-	return result, nil
+	// 依赖清单按稳定顺序输出，不影响上面规则匹配时的声明顺序
+	inventory := make([]camodels.Dependency, len(dependencies))
+	copy(inventory, dependencies)
+	camodels.SortDependencies(inventory)
+	return result, inventory, nil
 }
 
 // filterRulesByLanguages 过滤规则，只包含与检测到的语言匹配的规则。
+// 规则的任一语言存在即适用，language: any 的规则始终适用。
 func (e *CanvasEngine) filterRulesByLanguages(languages []string) []*camodels.Framework {
 	var filtered []*camodels.Framework
 
+	detected := make(map[string]bool)
+	for _, lang := range languages {
+		detected[strings.ToLower(lang)] = true
+	}
+
 	for _, rule := range e.rules {
+		if rule.IsLanguageAgnostic() {
+			filtered = append(filtered, rule)
+			continue
+		}
 		// 检查规则的语言是否在检测到的语言中
-		for _, lang := range languages {
-			if rule.Language == lang {
+		for _, lang := range rule.AllLanguages() {
+			if detected[strings.ToLower(lang)] {
 				filtered = append(filtered, rule)
 				break
 			}
@@ -23,6 +23,11 @@ func (e *CanvasEngine) loadEmbeddedRules() {
 
 // addRule 向引擎添加单个规则，替换具有相同名称的任何现有规则。
 func (e *CanvasEngine) addRule(rule *camodels.Framework) {
+	// 只声明了 languages 时，使用第一个语言作为主语言
+	if rule.Language == "" && len(rule.Languages) > 0 {
+		rule.Language = rule.Languages[0]
+	}
+
 	// 检查规则是否已存在
 	existingIndex := -1
 	for i, r := range e.rules {
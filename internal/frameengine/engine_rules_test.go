@@ -156,5 +156,50 @@ func setupTestEnv(t *testing.T, dir string, framework *camodels.Framework) bool
 		}
 	}
 
+	// 处理 Dependencies：按规则语言对应的生态生成声明全部依赖的清单文件
+	if len(rule.Dependencies) > 0 {
+		var name, content string
+		switch camodels.EcosystemForLanguage(framework.Language) {
+		case camodels.EcosystemNPM:
+			name = "package.json"
+			var entries []string
+			for _, dependency := range rule.Dependencies {
+				entries = append(entries, fmt.Sprintf("%q: \"^1.0.0\"", strings.ReplaceAll(dependency, "*", "x")))
+			}
+			content = "{\"dependencies\": {" + strings.Join(entries, ", ") + "}}\n"
+		case camodels.EcosystemGo:
+			name = "go.mod"
+			content = "module example.com/test\n\nrequire (\n"
+			for _, dependency := range rule.Dependencies {
+				content += "\t" + strings.ReplaceAll(dependency, "*", "x") + " v1.0.0\n"
+			}
+			content += ")\n"
+		case camodels.EcosystemComposer:
+			name = "composer.json"
+			var entries []string
+			for _, dependency := range rule.Dependencies {
+				entries = append(entries, fmt.Sprintf("%q: \"^1.0.0\"", strings.ReplaceAll(dependency, "*", "x")))
+			}
+			content = "{\"require\": {" + strings.Join(entries, ", ") + "}}\n"
+		case camodels.EcosystemPyPI:
+			name = "requirements.txt"
+			for _, dependency := range rule.Dependencies {
+				content += strings.ReplaceAll(dependency, "*", "x") + "==1.0.0\n"
+			}
+		default:
+			name = "pom.xml"
+			content = "<project><dependencies>\n"
+			for _, dependency := range rule.Dependencies {
+				group, artifact, _ := strings.Cut(strings.ReplaceAll(dependency, "*", "x"), ":")
+				content += fmt.Sprintf("<dependency><groupId>%s</groupId><artifactId>%s</artifactId><version>1.0.0</version></dependency>\n", group, artifact)
+			}
+			content += "</dependencies></project>\n"
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Errorf("Failed to write %s: %v", name, err)
+			return false
+		}
+	}
+
 	return true
 }
@@ -1,8 +1,12 @@
 package frameengine
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/winezer0/xcanvas/camodels"
@@ -218,6 +222,69 @@ func TestDetectVersion(t *testing.T) {
 	}
 }
 
+// TestDetectMultipleVersions tests that every distinct version and source is reported with its files,
+// including several versions declared in one file.
+func TestDetectMultipleVersions(t *testing.T) {
+	projectDir := t.TempDir()
+	poms := map[string]string{
+		"module-a/pom.xml": "<artifactId>log4j</artifactId><version>1.2.17</version>",
+		"module-b/pom.xml": "<artifactId>log4j-core</artifactId><version>2.17.1</version>",
+		"module-c/pom.xml": "<artifactId>log4j-core</artifactId><version>2.17.1</version>",
+		"module-d/pom.xml": "<artifactId>log4j</artifactId><version>1.2.12</version>\n" +
+			"<artifactId>log4j-api</artifactId><version>2.20.0</version>",
+		"gradle.lockfile": "org.apache.logging.log4j:log4j-core:2.17.1=runtimeClasspath",
+		"build.xml": `<project name="app" version="3.4.5">
+  <property name="log4j.jar" version="1.2.16"/>
+  <property name="log4j.config" version="9.9"/>
+</project>`,
+	}
+	for relPath, content := range poms {
+		path := filepath.Join(projectDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create module directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", relPath, err)
+		}
+	}
+
+	index, err := buildTestIndex(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to build file index: %v", err)
+	}
+	extractors := []camodels.VersionExtractor{{
+		FilePattern: "pom.xml",
+		Patterns:    []string{`<artifactId>log4j[\w-]*</artifactId>\s*<version>([^<]+)</version>`},
+		Multiple:    true,
+	}, {
+		// 未设置 Multiple 的宽松正则只使用第一个匹配，文件中的无关版本不会成为新的版本
+		FilePattern: "build.xml",
+		Patterns:    []string{`log4j.*version="([0-9.]+)"`},
+	}, {
+		FilePattern: "gradle.lockfile",
+		Patterns:    []string{`log4j-core:([\d.]+)`},
+		Source:      camodels.VersionSourceResolved,
+	}}
+
+	versions := extractorVersions(NewIndexMatcher(index), extractors, nil, make(map[string][]byte))
+	got := make(map[string][]string)
+	for _, info := range versions {
+		sort.Strings(info.Files)
+		got[info.Version+" "+info.Source] = info.Files
+	}
+	want := map[string][]string{
+		"1.2.17 pinned":   {"module-a/pom.xml"},
+		"2.17.1 pinned":   {"module-b/pom.xml", "module-c/pom.xml"},
+		"1.2.12 pinned":   {"module-d/pom.xml"},
+		"2.20.0 pinned":   {"module-d/pom.xml"},
+		"2.17.1 resolved": {"gradle.lockfile"},
+		"1.2.16 pinned":   {"build.xml"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractorVersions() = %v, want %v", got, want)
+	}
+}
+
 // buildTestIndex creates a file index for testing
 func buildTestIndex(rootDir string) (*camodels.FileIndex, error) {
 	index := camodels.NewFileIndex(rootDir)
@@ -236,3 +303,91 @@ func buildTestIndex(rootDir string) (*camodels.FileIndex, error) {
 	})
 	return index, err
 }
+
+// TestFilterRulesByLanguages tests multi-language and language-agnostic rule filtering.
+func TestFilterRulesByLanguages(t *testing.T) {
+	engine := &CanvasEngine{
+		frameworkRules: make(map[string]*camodels.Framework),
+		componentRules: make(map[string]*camodels.Framework),
+	}
+	engine.addRule(&camodels.Framework{Name: "JVM", Type: camodels.RuleTypeFramework, Languages: []string{"Java", "Kotlin", "Groovy"}})
+	engine.addRule(&camodels.Framework{Name: "Docker", Type: camodels.RuleTypeComponent, Language: camodels.LanguageAny})
+	engine.addRule(&camodels.Framework{Name: "Gin", Type: camodels.RuleTypeFramework, Language: "Go"})
+
+	tests := []struct {
+		languages []string
+		want      []string
+	}{
+		{languages: []string{"Kotlin"}, want: []string{"JVM", "Docker"}},
+		{languages: []string{"groovy"}, want: []string{"JVM", "Docker"}},
+		{languages: []string{"Go"}, want: []string{"Docker", "Gin"}},
+		{languages: nil, want: []string{"Docker"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, rule := range engine.filterRulesByLanguages(tt.languages) {
+			got = append(got, rule.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("filterRulesByLanguages(%v) = %v, want %v", tt.languages, got, tt.want)
+		}
+	}
+
+	// 只声明 languages 的规则使用第一个语言作为主语言
+	if lang := engine.frameworkRules["JVM"].Language; lang != "Java" {
+		t.Errorf("expected primary language Java, got %q", lang)
+	}
+}
+
+// TestDetectSymfonyCorePackages tests that transitive symfony polyfills and contracts do not report Symfony
+func TestDetectSymfonyCorePackages(t *testing.T) {
+	e, err := NewCanvasEngine("")
+	if err != nil {
+		t.Fatalf("Failed to initialize engine: %v", err)
+	}
+	lock := `{"packages": [
+		{"name": "guzzlehttp/guzzle", "version": "7.8.1"},
+		{"name": "symfony/deprecation-contracts", "version": "v3.4.0"},
+		{"name": "symfony/polyfill-mbstring", "version": "v1.28.0"}%s
+	]}`
+
+	detect := func(extra string) map[string][]string {
+		projectDir := t.TempDir()
+		files := map[string]string{
+			"composer.json": `{"require": {"guzzlehttp/guzzle": "^7.8"}}`,
+			"composer.lock": fmt.Sprintf(lock, extra),
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", name, err)
+			}
+		}
+		index, err := buildTestIndex(projectDir)
+		if err != nil {
+			t.Fatalf("Failed to build file index: %v", err)
+		}
+		result, err := e.DetectFrameworks(index, []string{"PHP"})
+		if err != nil {
+			t.Fatalf("DetectFrameworks failed: %v", err)
+		}
+		found := make(map[string][]string)
+		for _, item := range result.Components {
+			found[item.Name] = item.AllVersions()
+		}
+		return found
+	}
+
+	found := detect("")
+	if _, ok := found["guzzle"]; !ok {
+		t.Errorf("expected guzzle to be detected, got %v", found)
+	}
+	if versions, ok := found["symfony"]; ok {
+		t.Errorf("symfony detected from polyfills and contracts: %v", versions)
+	}
+
+	found = detect(`, {"name": "symfony/http-kernel", "version": "v6.4.1"}`)
+	if versions := found["symfony"]; !reflect.DeepEqual(versions, []string{"6.4.1"}) {
+		t.Errorf("expected symfony 6.4.1 from symfony/http-kernel, got %v", versions)
+	}
+}
@@ -18,6 +18,23 @@ func NewIndexMatcher(index *camodels.FileIndex) *IndexMatcher {
 	return &IndexMatcher{Index: index}
 }
 
+// RelPath 将 FindFiles 返回的绝对路径转换为相对于索引根目录的正斜杠路径
+func (m *IndexMatcher) RelPath(absPath string) string {
+	relPath, err := filepath.Rel(m.Index.RootDir, absPath)
+	if err != nil {
+		return filepath.ToSlash(absPath)
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// ReadFile 读取 FindFiles 返回的文件内容：索引设置了 FS 时从 FS 读取，否则从磁盘读取
+func (m *IndexMatcher) ReadFile(absPath string, cache map[string][]byte) ([]byte, error) {
+	if m.Index.FS != nil {
+		return GetFSFileContentWithCache(m.Index.FS, m.RelPath(absPath), absPath, cache)
+	}
+	return GetFileContentWithCache(absPath, cache)
+}
+
 // FindFiles 使用索引查找匹配的文件。
 // pattern 支持:
 // 1. 精确相对路径 (e.g., "/package.json")
@@ -99,6 +116,21 @@ func (m *IndexMatcher) FindFiles(pattern string) ([]string, error) {
 	return results, nil
 }
 
+// FindEvidence 查找可以作为检测依据的文件：vendored 为 false 时排除第三方（vendored）文件
+func (m *IndexMatcher) FindEvidence(pattern string, vendored bool) ([]string, error) {
+	files, err := m.FindFiles(pattern)
+	if err != nil || vendored || len(m.Index.Vendored) == 0 {
+		return files, err
+	}
+	result := files[:0]
+	for _, file := range files {
+		if !m.Index.IsVendored(m.RelPath(file)) {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
 // matchPath 简单的路径匹配，支持 **
 func matchPath(pattern, name string) (bool, error) {
 	// 确保模式使用正斜杠以匹配 FileIndex 约定
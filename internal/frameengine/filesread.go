@@ -2,9 +2,15 @@ package frameengine
 
 import (
 	"io"
+	"io/fs"
 	"os"
 )
 
+const (
+	largeFileSize = 5 * 1024 * 1024 // 超过该大小的文件只读取前 largeFileRead 字节
+	largeFileRead = 1 * 1024 * 1024
+)
+
 // GetFileContentWithCache 读取文件内容，带缓存和大文件截断（最大 5MB，只读前 1MB）
 // cache 是外部传入的 map[string][]byte，用于跨调用共享缓存
 func GetFileContentWithCache(path string, cache map[string][]byte) ([]byte, error) {
@@ -18,20 +24,40 @@ func GetFileContentWithCache(path string, cache map[string][]byte) ([]byte, erro
 	}
 	defer f.Close()
 
-	stat, err := f.Stat()
-	if err == nil && stat.Size() > 5*1024*1024 { // >5MB
-		content, err := io.ReadAll(io.LimitReader(f, 1*1024*1024)) // 读前1MB
-		if err != nil {
-			return nil, err
-		}
-		cache[path] = content
+	content, err := readLimited(f)
+	if err != nil {
+		return nil, err
+	}
+	cache[path] = content
+	return content, nil
+}
+
+// GetFSFileContentWithCache 从 fsys 读取相对路径 name 的文件内容，缓存和截断规则与 GetFileContentWithCache 相同，
+// cacheKey 为缓存键（与磁盘读取共用缓存时使用绝对路径）
+func GetFSFileContentWithCache(fsys fs.FS, name, cacheKey string, cache map[string][]byte) ([]byte, error) {
+	if content, ok := cache[cacheKey]; ok {
 		return content, nil
 	}
 
-	content, err := io.ReadAll(f)
+	f, err := fsys.Open(name)
 	if err != nil {
 		return nil, err
 	}
-	cache[path] = content
+	defer f.Close()
+
+	content, err := readLimited(f)
+	if err != nil {
+		return nil, err
+	}
+	cache[cacheKey] = content
 	return content, nil
 }
+
+// readLimited 读取文件内容，大文件只读取开头部分
+func readLimited(f fs.File) ([]byte, error) {
+	stat, err := f.Stat()
+	if err == nil && stat.Size() > largeFileSize {
+		return io.ReadAll(io.LimitReader(f, largeFileRead))
+	}
+	return io.ReadAll(f)
+}
@@ -2,8 +2,10 @@ package frameengine
 
 import (
 	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/winezer0/slogs"
@@ -11,51 +13,104 @@ import (
 	"github.com/winezer0/xcanvas/camodels"
 )
 
-// containsAllKeywords 检查文件内容是否包含所有必需的关键字。
-// 如果 ignoreCase 为 true，则进行大小写不敏感匹配；否则区分大小写。
-func containsAllKeywords(content []byte, keys []string, ignoreCase bool) bool {
-	if len(content) == 0 || len(keys) == 0 {
-		return false
+// extractVersion 使用给定的正则表达式列表从文件内容中提取版本号
+// 按顺序尝试每个正则表达式，第一个成功匹配且包含捕获组（优先命名捕获组 version）的结果将被用作版本号
+func extractVersion(content []byte, patterns []string) string {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		if matched, ok := matchVersionPattern(re, content); ok {
+			return matched.Version
+		}
 	}
+	return ""
+}
 
-	contentStr := string(content)
-	if ignoreCase {
-		contentStr = strings.ToLower(contentStr)
-		for i, kw := range keys {
-			keys[i] = strings.ToLower(kw)
-		}
+// maxCountedFiles 内容条件达到阈值后最多统计的命中文件数，达到后停止读取剩余文件
+const maxCountedFiles = 20
+
+// contentMatch 记录单个 FileContents 条件的命中统计
+type contentMatch struct {
+	Pattern   string // 文件模式
+	Files     int    // 同时包含全部关键字的文件数量
+	Matches   int    // 命中文件中关键字出现的总次数
+	Truncated bool   // 达到 maxCountedFiles 后停止统计，Files 和 Matches 为下限
+}
+
+// countKeywordMatches 统计文件内容中关键字出现的总次数（大小写不敏感）。
+// 只有当所有关键字都至少出现一次时才返回非零值。
+func countKeywordMatches(content []byte, keys []string) int {
+	if len(content) == 0 || len(keys) == 0 {
+		return 0
 	}
 
+	contentStr := strings.ToLower(string(content))
+	total := 0
 	for _, kw := range keys {
-		if !strings.Contains(contentStr, kw) {
-			return false
+		n := strings.Count(contentStr, strings.ToLower(kw))
+		if n == 0 {
+			return 0
 		}
+		total += n
 	}
-	return true
+	return total
 }
 
-// extractVersion 使用给定的正则表达式列表从文件内容中提取版本号
-// 按顺序尝试每个正则表达式，第一个成功匹配且包含捕获组的结果将被用作版本号
-func extractVersion(content []byte, patterns []string) string {
-	for _, pattern := range patterns {
-		re, err := regexp.Compile(pattern)
+// matchContents 统计匹配 filePattern 的文件中，包含全部关键字的文件数量和关键字出现总次数；
+// vendored 为 false 时不统计第三方（vendored）文件。
+// 达到阈值（minFiles、minMatches）且命中文件数达到 maxCountedFiles 后停止统计，剩余文件不足 minFiles 时提前结束
+func matchContents(matcher *IndexMatcher, filePattern string, fileKeys []string, vendored bool, minFiles, minMatches int, fileContentCache map[string][]byte) contentMatch {
+	result := contentMatch{Pattern: filePattern}
+	findFiles, _ := matcher.FindEvidence(filePattern, vendored)
+	countFiles := max(minFiles, maxCountedFiles)
+	for i, path := range findFiles {
+		if result.Files >= countFiles && result.Matches >= minMatches {
+			result.Truncated = true
+			break
+		}
+		if result.Files+len(findFiles)-i < minFiles {
+			break
+		}
+		content, err := matcher.ReadFile(path, fileContentCache)
 		if err != nil {
 			continue
 		}
-		matches := re.FindSubmatch(content)
-		if len(matches) > 1 {
-			return string(matches[1])
+		if n := countKeywordMatches(content, fileKeys); n > 0 {
+			result.Files++
+			result.Matches += n
 		}
 	}
-	return ""
+	return result
+}
+
+// formatEvidence 生成人类可读的检测依据，包含内容条件的实际命中数量
+func formatEvidence(name string, contents []contentMatch) string {
+	evidence := fmt.Sprintf("FrameRule matched for %s", name)
+	if len(contents) == 0 {
+		return evidence
+	}
+
+	details := make([]string, 0, len(contents))
+	for _, c := range contents {
+		if c.Truncated {
+			details = append(details, fmt.Sprintf("%s: %d+ files, %d+ matches", c.Pattern, c.Files, c.Matches))
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s: %d files, %d matches", c.Pattern, c.Files, c.Matches))
+	}
+	return evidence + " (" + strings.Join(details, "; ") + ")"
 }
 
 // matchFrame 检查 rules 中是否有任意一条规则被满足。
-// 规则满足条件 = 所有 Paths 存在 AND 所有 FileContents 条件满足。
-// 返回 true 表示至少有一条规则匹配成功。
-func matchFrame(matcher *IndexMatcher, rules []camodels.FrameRule, fileContentCache map[string][]byte) bool {
+// 规则满足条件 = 所有 Paths 存在 AND 所有 FileContents 条件满足 AND 所有 Dependencies 已声明。
+// 规则未设置 Vendored 时 Paths 和 FileContents 只匹配项目自身的文件。
+// FileContents 条件满足 = 至少 MinFiles 个文件包含全部关键字，且关键字总出现次数不少于 MinMatches。
+// 返回 true 表示至少有一条规则匹配成功，同时返回该规则各内容条件的命中统计。
+func matchFrame(matcher *IndexMatcher, rules []camodels.FrameRule, dependencies []camodels.Dependency, fileContentCache map[string][]byte) (bool, []contentMatch) {
 	for _, rule := range rules {
-		if len(rule.Paths) == 0 && len(rule.FileContents) == 0 {
+		if len(rule.Paths) == 0 && len(rule.FileContents) == 0 && len(rule.Dependencies) == 0 {
 			ruleJSON, _ := json.Marshal(rule)
 			slogs.Errorf("match rules not has any match content: %s", string(ruleJSON))
 			continue
@@ -66,7 +121,7 @@ func matchFrame(matcher *IndexMatcher, rules []camodels.FrameRule, fileContentCa
 		if len(rule.Paths) > 0 {
 			// 如果 Paths 不为空 需要先匹配paths列表 判断需要的文件路径是否都存在
 			for _, path := range rule.Paths {
-				matches, _ := matcher.FindFiles(filepath.ToSlash(path))
+				matches, _ := matcher.FindEvidence(filepath.ToSlash(path), rule.Vendored)
 				if len(matches) == 0 {
 					pathsMatch = false
 					break // 存在path缺失即失败
@@ -79,116 +134,91 @@ func matchFrame(matcher *IndexMatcher, rules []camodels.FrameRule, fileContentCa
 			}
 		}
 
-		// 2. 检查 FileContents（每个 pattern 必须有至少一个文件包含其所有关键字，AND across patterns）
-		fileMatch := true // 假设全部满足
-		if len(rule.FileContents) > 0 {
-			for filePattern, fileKeys := range rule.FileContents {
-				findFiles, _ := matcher.FindFiles(filePattern)
-				if len(findFiles) == 0 {
-					fileMatch = false
-					break // 没有文件匹配此 pattern，失败
-				}
+		// 2. 检查 FileContents（每个 pattern 必须满足最少文件数和最少命中次数，AND across patterns）
+		minFiles := max(rule.MinFiles, 1)
+		minMatches := max(rule.MinMatches, 1)
 
-				// 检查是否存在至少一个文件包含所有关键字
-				oneFileMatches := false
-				for _, path := range findFiles {
-					content, err := GetFileContentWithCache(path, fileContentCache)
-					if err != nil {
-						continue
-					}
-					if containsAllKeywords(content, fileKeys, true) {
-						oneFileMatches = true
-						break
-					}
-				}
+		// 按模式排序，保证检测依据输出稳定
+		patterns := make([]string, 0, len(rule.FileContents))
+		for filePattern := range rule.FileContents {
+			patterns = append(patterns, filePattern)
+		}
+		sort.Strings(patterns)
 
-				if !oneFileMatches {
-					fileMatch = false
-					break // 此 pattern 无文件满足，失败
-				}
-				// 否则继续检查下一个 pattern
+		fileMatch := true // 假设全部满足
+		contents := make([]contentMatch, 0, len(patterns))
+		for _, filePattern := range patterns {
+			counted := matchContents(matcher, filePattern, rule.FileContents[filePattern], rule.Vendored, minFiles, minMatches, fileContentCache)
+			if counted.Files < minFiles || counted.Matches < minMatches {
+				fileMatch = false
+				break // 此 pattern 未达到阈值，失败
 			}
+			contents = append(contents, counted)
+		}
+
+		if !fileMatch {
+			continue
 		}
 
-		// 如果当前规则完全匹配（Paths + FileContents），立即返回 true
-		if pathsMatch && fileMatch {
-			return true
+		// 3. 检查 Dependencies（所有依赖都必须在依赖清单中声明，AND）
+		if len(rule.Dependencies) > 0 {
+			depsMatch, counted := matchDependencies(dependencies, rule.Dependencies)
+			if !depsMatch {
+				continue
+			}
+			contents = append(contents, counted...)
 		}
+
+		// 当前规则完全匹配（Paths + FileContents + Dependencies），立即返回 true
+		return true, contents
 	}
 
 	// 所有规则都不匹配
-	return false
+	return false, nil
 }
 
-func extractorVersion(matcher *IndexMatcher, versionExtractors []camodels.VersionExtractor, fileContentCache map[string][]byte) string {
-	version := ""
+// extractorVersions 使用所有版本提取规则查找版本，返回去重后的版本详情列表，未找到时返回 nil
+// 同一版本和来源出现在多个文件中时合并到 Files，同一版本的不同来源（例如声明的范围和锁文件中的实际版本）分别保留；
+// 列表按提取规则和文件的顺序排列，第一个为主版本
+func extractorVersions(matcher *IndexMatcher, versionExtractors []camodels.VersionExtractor, dependencies []camodels.Dependency, fileContentCache map[string][]byte) []*camodels.VersionInfo {
+	var versions []*camodels.VersionInfo
+	seen := make(map[string]*camodels.VersionInfo)
+	addVersion := func(info *camodels.VersionInfo) {
+		key := info.Version + "|" + info.Source
+		if existing, ok := seen[key]; ok {
+			existing.AddFile(info.File)
+			return
+		}
+		info.AddFile(info.File)
+		seen[key] = info
+		versions = append(versions, info)
+	}
+
 	// 使用框架/组件级版本提取规则
 	for _, versionExtractor := range versionExtractors {
-		// 找到所有匹配该模式的文件
-		findFiles, _ := matcher.FindFiles(versionExtractor.FilePattern)
-		if len(findFiles) == 0 {
-			// 没有匹配的文件，跳过此提取规则
+		// 基于依赖清单的提取规则直接使用解析结果
+		if versionExtractor.Dependency != "" {
+			for _, info := range dependencyVersions(versionExtractor, dependencies) {
+				addVersion(info)
+			}
 			continue
 		}
 
-		// 检查所有匹配的文件，直到找到版本号
+		// 找到所有匹配该模式的文件，没有匹配的文件时跳过此提取规则
+		findFiles, _ := matcher.FindEvidence(versionExtractor.FilePattern, versionExtractor.Vendored)
+
+		// 检查所有匹配的文件，收集每个文件中的版本号
 		for _, path := range findFiles {
-			content, err := GetFileContentWithCache(path, fileContentCache)
+			content, err := matcher.ReadFile(path, fileContentCache)
 			if err != nil {
 				// 无法读取文件，尝试下一个文件
 				continue
 			}
 
-			// 按顺序尝试每个正则表达式，直到找到匹配的版本号
-			for _, pattern := range versionExtractor.Patterns {
-				// 使用正则表达式提取版本号
-				re, err := regexp.Compile(pattern)
-				if err != nil {
-					// 正则表达式无效，尝试下一个
-					continue
-				}
-
-				matches := re.FindSubmatch(content)
-				if len(matches) > 1 {
-					// 找到匹配 并格式化版本号
-					version = formatVersion(string(matches[1]))
-					if len(version) > 0 {
-						// 停止遍历匹配方法
-						break
-					}
-				}
+			for _, info := range extractVersionInfos(versionExtractor, content, matcher.RelPath(path)) {
+				addVersion(info)
 			}
-
-			// 如果从文件内容未找到版本号，尝试从文件名提取
-			for _, pattern := range versionExtractor.Patterns {
-				// 使用正则表达式从文件名提取版本号
-				re, err := regexp.Compile(pattern)
-				if err != nil {
-					// 正则表达式无效，尝试下一个
-					continue
-				}
-
-				matches := re.FindStringSubmatch(path)
-				if len(matches) > 1 {
-					// 找到匹配 并 格式化版本号，去除 ^、~、= 等前缀和空格
-					version = formatVersion(matches[1])
-					if len(version) > 0 {
-						// 停止遍历匹配方法
-						break
-					}
-				}
-			}
-
-			// 如果找到版本号，停止遍历
-			if len(version) > 0 {
-				break
-			}
-		}
-
-		// 如果找到版本号，停止遍历
-		if len(version) > 0 {
-			break
 		}
 	}
-	return version
+	return versions
 }
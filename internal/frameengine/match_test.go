@@ -1,7 +1,12 @@
 package frameengine
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
 )
 
 // TestExtractVersion tests the extractVersion function with various scenarios
@@ -145,3 +150,179 @@ func TestExtractVersionPerformance(t *testing.T) {
 		t.Errorf("Expected version 1.2.3, got %s", result)
 	}
 }
+
+// TestMatchFrameThresholds tests min_files / min_matches thresholds on content conditions
+func TestMatchFrameThresholds(t *testing.T) {
+	projectDir := t.TempDir()
+	files := map[string]string{
+		"app.py":          "import flask\nflask.Flask(__name__)\n",
+		"tools/helper.py": "import flask\n",
+		"tools/other.py":  "import os\n",
+	}
+	for name, content := range files {
+		fullPath := filepath.Join(projectDir, name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create dirs: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	index, err := buildTestIndex(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to build file index: %v", err)
+	}
+	matcher := NewIndexMatcher(index)
+
+	testCases := []struct {
+		name        string
+		rule        camodels.FrameRule
+		wantMatch   bool
+		wantFiles   int
+		wantMatches int
+	}{
+		{
+			name:        "Default thresholds",
+			rule:        camodels.FrameRule{FileContents: map[string][]string{"*.py": {"flask"}}},
+			wantMatch:   true,
+			wantFiles:   2,
+			wantMatches: 4,
+		},
+		{
+			name:        "MinFiles satisfied",
+			rule:        camodels.FrameRule{FileContents: map[string][]string{"*.py": {"import flask"}}, MinFiles: 2},
+			wantMatch:   true,
+			wantFiles:   2,
+			wantMatches: 2,
+		},
+		{
+			name:      "MinFiles not satisfied",
+			rule:      camodels.FrameRule{FileContents: map[string][]string{"*.py": {"import flask"}}, MinFiles: 3},
+			wantMatch: false,
+		},
+		{
+			name:        "MinMatches satisfied",
+			rule:        camodels.FrameRule{FileContents: map[string][]string{"*.py": {"flask"}}, MinMatches: 4},
+			wantMatch:   true,
+			wantFiles:   2,
+			wantMatches: 4,
+		},
+		{
+			name:      "MinMatches not satisfied",
+			rule:      camodels.FrameRule{FileContents: map[string][]string{"*.py": {"flask"}}, MinMatches: 5},
+			wantMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, contents := matchFrame(matcher, []camodels.FrameRule{tc.rule}, nil, make(map[string][]byte))
+			if matched != tc.wantMatch {
+				t.Fatalf("matchFrame() = %v, want %v", matched, tc.wantMatch)
+			}
+			if !matched {
+				return
+			}
+			if len(contents) != 1 {
+				t.Fatalf("expected 1 content match, got %d", len(contents))
+			}
+			if contents[0].Files != tc.wantFiles || contents[0].Matches != tc.wantMatches {
+				t.Errorf("got %d files / %d matches, want %d / %d", contents[0].Files, contents[0].Matches, tc.wantFiles, tc.wantMatches)
+			}
+		})
+	}
+}
+
+// TestMatchContentsStopsEarly tests that counting stops once thresholds are met and maxCountedFiles files matched
+func TestMatchContentsStopsEarly(t *testing.T) {
+	projectDir := t.TempDir()
+	for i := range maxCountedFiles + 5 {
+		name := filepath.Join(projectDir, fmt.Sprintf("app%02d.py", i))
+		if err := os.WriteFile(name, []byte("import flask\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	index, err := buildTestIndex(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to build file index: %v", err)
+	}
+	matcher := NewIndexMatcher(index)
+
+	cache := make(map[string][]byte)
+	counted := matchContents(matcher, "*.py", []string{"import flask"}, false, 3, 1, cache)
+	if counted.Files != maxCountedFiles || !counted.Truncated || len(cache) != maxCountedFiles {
+		t.Errorf("expected counting to stop after %d files, got %+v with %d files read", maxCountedFiles, counted, len(cache))
+	}
+
+	cache = make(map[string][]byte)
+	counted = matchContents(matcher, "*.py", []string{"import flask"}, false, maxCountedFiles+10, 1, cache)
+	if counted.Files != 0 || len(cache) != 0 {
+		t.Errorf("expected no files read when min_files cannot be reached, got %+v with %d files read", counted, len(cache))
+	}
+}
+
+// TestFormatEvidence tests that content match counts are reported in the evidence
+func TestFormatEvidence(t *testing.T) {
+	got := formatEvidence("Flask", []contentMatch{{Pattern: "*.py", Files: 3, Matches: 5}})
+	want := "FrameRule matched for Flask (*.py: 3 files, 5 matches)"
+	if got != want {
+		t.Errorf("formatEvidence() = %q, want %q", got, want)
+	}
+
+	got = formatEvidence("Flask", []contentMatch{{Pattern: "*.py", Files: 20, Matches: 20, Truncated: true}})
+	want = "FrameRule matched for Flask (*.py: 20+ files, 20+ matches)"
+	if got != want {
+		t.Errorf("formatEvidence() = %q, want %q", got, want)
+	}
+
+	got = formatEvidence("Flask", nil)
+	want = "FrameRule matched for Flask"
+	if got != want {
+		t.Errorf("formatEvidence() = %q, want %q", got, want)
+	}
+}
+
+// TestMatchFrameVendored tests that vendored files only count as evidence when the rule opts in
+func TestMatchFrameVendored(t *testing.T) {
+	projectDir := t.TempDir()
+	files := map[string]string{
+		"static/js/angular.min.js": "/* AngularJS v1.8.2 */",
+		"app.js":                   "console.log('app')\n",
+	}
+	for name, content := range files {
+		fullPath := filepath.Join(projectDir, name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create dirs: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	index, err := buildTestIndex(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to build file index: %v", err)
+	}
+	index.MarkVendored("static/js/angular.min.js")
+	matcher := NewIndexMatcher(index)
+
+	contents := map[string][]string{"**/angular.min.js": {"AngularJS v1."}}
+	if matched, _ := matchFrame(matcher, []camodels.FrameRule{{FileContents: contents}}, nil, make(map[string][]byte)); matched {
+		t.Error("vendored file matched without opting in")
+	}
+	if matched, _ := matchFrame(matcher, []camodels.FrameRule{{Paths: []string{"angular.min.js"}}}, nil, make(map[string][]byte)); matched {
+		t.Error("vendored path matched without opting in")
+	}
+	if matched, _ := matchFrame(matcher, []camodels.FrameRule{{FileContents: contents, Vendored: true}}, nil, make(map[string][]byte)); !matched {
+		t.Error("vendored file not matched with vendored: true")
+	}
+
+	extractors := []camodels.VersionExtractor{{FilePattern: "angular.min.js", Patterns: []string{`AngularJS v(1\.[\d.]+)`}}}
+	if versions := extractorVersions(matcher, extractors, nil, make(map[string][]byte)); len(versions) != 0 {
+		t.Errorf("version extracted from vendored file without opting in: %+v", versions)
+	}
+	extractors[0].Vendored = true
+	if versions := extractorVersions(matcher, extractors, nil, make(map[string][]byte)); len(versions) != 1 || versions[0].Version != "1.8.2" {
+		t.Errorf("unexpected versions with vendored: true: %+v", versions)
+	}
+}
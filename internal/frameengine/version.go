@@ -0,0 +1,205 @@
+package frameengine
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/manifest"
+)
+
+// placeholderRe 匹配 ${name} 和 @name@ 形式的属性占位符
+var placeholderRe = regexp.MustCompile(`\$\{([^}]+)\}|@([\w.-]+)@`)
+
+// versionMatch 单次正则匹配得到的版本和名称
+type versionMatch struct {
+	Version string
+	Name    string
+}
+
+// matchVersionPattern 使用正则从内容中提取第一个版本。
+// 优先使用命名捕获组 version，否则使用第一个非 name 的捕获组；命名捕获组 name 记录匹配的名称。
+func matchVersionPattern(re *regexp.Regexp, content []byte) (versionMatch, bool) {
+	return versionFromSubmatch(re, re.FindSubmatch(content))
+}
+
+// matchVersionPatternAll 与 matchVersionPattern 相同，返回内容中的所有匹配（例如多模块文件中的多个版本）
+func matchVersionPatternAll(re *regexp.Regexp, content []byte) []versionMatch {
+	var result []versionMatch
+	for _, matches := range re.FindAllSubmatch(content, -1) {
+		if matched, ok := versionFromSubmatch(re, matches); ok {
+			result = append(result, matched)
+		}
+	}
+	return result
+}
+
+// versionFromSubmatch 从单次匹配的捕获组中取出版本和名称
+func versionFromSubmatch(re *regexp.Regexp, matches [][]byte) (versionMatch, bool) {
+	if len(matches) <= 1 {
+		return versionMatch{}, false
+	}
+
+	result := versionMatch{}
+	versionIdx := re.SubexpIndex("version")
+	nameIdx := re.SubexpIndex("name")
+	if versionIdx < 0 {
+		for i := 1; i < len(matches); i++ {
+			if i != nameIdx {
+				versionIdx = i
+				break
+			}
+		}
+	}
+	if versionIdx > 0 {
+		result.Version = strings.TrimSpace(string(matches[versionIdx]))
+	}
+	if nameIdx > 0 {
+		result.Name = strings.TrimSpace(string(matches[nameIdx]))
+	}
+	return result, result.Version != ""
+}
+
+// applyTransforms 按顺序执行版本转换，返回转换后的版本和用于判断范围的声明值。
+// content 为版本所在文件的内容，用于属性查找。
+func applyTransforms(version string, transforms []string, content []byte) (string, string) {
+	declared := version
+	for _, transform := range transforms {
+		switch strings.ToLower(transform) {
+		case camodels.TransformStripV:
+			version = strings.TrimLeft(strings.TrimSpace(version), "vV")
+		case camodels.TransformProperty:
+			version = resolveProperties(version, content)
+			declared = version
+		case camodels.TransformLowerBound:
+			version = manifest.LowerBound(version)
+		}
+	}
+	return version, declared
+}
+
+// resolveProperties 将版本中的属性占位符替换为同一文件中定义的属性值，存在无法解析的占位符时返回空字符串
+func resolveProperties(version string, content []byte) string {
+	unresolved := false
+	resolved := placeholderRe.ReplaceAllStringFunc(version, func(placeholder string) string {
+		groups := placeholderRe.FindStringSubmatch(placeholder)
+		name := groups[1]
+		if name == "" {
+			name = groups[2]
+		}
+		value := lookupProperty(content, name)
+		if value == "" {
+			unresolved = true
+		}
+		return value
+	})
+	if unresolved {
+		return ""
+	}
+	return resolved
+}
+
+// lookupProperty 在文件内容中查找属性值，支持 Maven <name>value</name> 与 name=value / name: value 形式
+func lookupProperty(content []byte, name string) string {
+	quoted := regexp.QuoteMeta(name)
+	patterns := []string{
+		`<` + quoted + `>\s*([^<\s]+)\s*</` + quoted + `>`,
+		`(?m)^\s*` + quoted + `\s*[=:]\s*["']?([^"'\s]+)["']?`,
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		if matches := re.FindSubmatch(content); len(matches) > 1 {
+			return string(matches[1])
+		}
+	}
+	return ""
+}
+
+// classifyVersionSource 判断版本来源。规则显式声明来源时直接使用，否则根据声明值判断是范围还是固定版本。
+func classifyVersionSource(declared string, source string) string {
+	switch strings.ToLower(source) {
+	case camodels.VersionSourceResolved:
+		return camodels.VersionSourceResolved
+	case camodels.VersionSourcePinned:
+		return camodels.VersionSourcePinned
+	case camodels.VersionSourceRange:
+		return camodels.VersionSourceRange
+	}
+	if isVersionRange(declared) {
+		return camodels.VersionSourceRange
+	}
+	return camodels.VersionSourcePinned
+}
+
+// isVersionRange 判断声明值是否为版本范围，例如 "^4.17.0"、"~2.1"、">=1.0,<2"、"[1.0,2.0)"、"1.x"
+func isVersionRange(declared string) bool {
+	declared = strings.TrimSpace(declared)
+	declared = strings.TrimPrefix(declared, "==")
+	declared = strings.TrimPrefix(declared, "=")
+	declared = strings.TrimSpace(declared)
+	if declared == "" {
+		return false
+	}
+	if strings.ContainsAny(declared, "^~<>*|,[]() !") {
+		return true
+	}
+	for _, part := range strings.Split(declared, ".") {
+		if part == "x" || part == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+// extractVersionInfos 使用单条版本提取规则从文件内容中提取版本，内容中未找到时尝试从文件路径提取。
+// 按顺序使用第一个有匹配的正则表达式，返回它的第一个匹配；规则设置 Multiple 时返回它在内容中的所有匹配。
+// 从文件路径（如 jar 文件名）提取的版本视为已解析版本。
+func extractVersionInfos(versionExtractor camodels.VersionExtractor, content []byte, relPath string) []*camodels.VersionInfo {
+	for _, fromPath := range []bool{false, true} {
+		target := content
+		source := versionExtractor.Source
+		if fromPath {
+			target = []byte(relPath)
+			source = camodels.VersionSourceResolved
+		}
+
+		// 按顺序尝试每个正则表达式，直到找到匹配的版本号
+		for _, pattern := range versionExtractor.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				// 正则表达式无效，尝试下一个
+				continue
+			}
+
+			var matches []versionMatch
+			if versionExtractor.Multiple {
+				matches = matchVersionPatternAll(re, target)
+			} else if matched, ok := matchVersionPattern(re, target); ok {
+				matches = []versionMatch{matched}
+			}
+
+			var infos []*camodels.VersionInfo
+			for _, matched := range matches {
+				version, declared := applyTransforms(matched.Version, versionExtractor.Transforms, content)
+				version = formatVersion(version)
+				if version == "" {
+					continue
+				}
+				infos = append(infos, &camodels.VersionInfo{
+					Version: version,
+					Raw:     matched.Version,
+					Source:  classifyVersionSource(declared, source),
+					Name:    matched.Name,
+					File:    relPath,
+				})
+			}
+			if len(infos) > 0 {
+				return infos
+			}
+		}
+	}
+	return nil
+}
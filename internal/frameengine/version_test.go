@@ -0,0 +1,176 @@
+package frameengine
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestMatchVersionPattern tests named capture groups for version and name
+func TestMatchVersionPattern(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		pattern     string
+		wantVersion string
+		wantName    string
+	}{
+		{
+			name:        "First group",
+			content:     `"react": "^18.2.0"`,
+			pattern:     `"react"\s*:\s*"([^"]+)"`,
+			wantVersion: "^18.2.0",
+		},
+		{
+			name:        "Named version group",
+			content:     `<artifactId>fastjson</artifactId><version>1.2.83</version>`,
+			pattern:     `<artifactId>(fastjson)</artifactId><version>(?P<version>[^<]+)</version>`,
+			wantVersion: "1.2.83",
+		},
+		{
+			name:        "Named name group before version",
+			content:     `<artifactId>log4j-core</artifactId><version>2.17.1</version>`,
+			pattern:     `<artifactId>(?P<name>log4j[\w-]*)</artifactId><version>([^<]+)</version>`,
+			wantVersion: "2.17.1",
+			wantName:    "log4j-core",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, ok := matchVersionPattern(regexp.MustCompile(tc.pattern), []byte(tc.content))
+			if !ok {
+				t.Fatalf("matchVersionPattern() found no version")
+			}
+			if matched.Version != tc.wantVersion || matched.Name != tc.wantName {
+				t.Errorf("matchVersionPattern() = %+v, want version %q name %q", matched, tc.wantVersion, tc.wantName)
+			}
+		})
+	}
+}
+
+// TestApplyTransforms tests version post-processing transforms
+func TestApplyTransforms(t *testing.T) {
+	content := []byte(`<properties><fastjson.version>1.2.83</fastjson.version></properties>
+springVersion=5.3.31
+`)
+	testCases := []struct {
+		name       string
+		version    string
+		transforms []string
+		want       string
+	}{
+		{name: "Strip v prefix", version: "v1.9.1", transforms: []string{camodels.TransformStripV}, want: "1.9.1"},
+		{name: "Maven property", version: "${fastjson.version}", transforms: []string{camodels.TransformProperty}, want: "1.2.83"},
+		{name: "Properties file", version: "${springVersion}", transforms: []string{camodels.TransformProperty}, want: "5.3.31"},
+		{name: "Unresolved property", version: "${missing.version}", transforms: []string{camodels.TransformProperty}, want: ""},
+		{name: "Lower bound", version: ">=2.0,<3", transforms: []string{camodels.TransformLowerBound}, want: "2.0"},
+		{name: "No transforms", version: "^4.17.0", want: "^4.17.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _ := applyTransforms(tc.version, tc.transforms, content)
+			if got != tc.want {
+				t.Errorf("applyTransforms(%q) = %q, want %q", tc.version, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestFormatVersion tests that operators are stripped, compound specifiers keep only the first constraint
+// and wildcard or placeholder constraints do not pass through as versions
+func TestFormatVersion(t *testing.T) {
+	testCases := map[string]string{
+		"^4.17.0":       "4.17.0",
+		"~= 2.1":        "2.1",
+		">=2.0,<3":      "2.0",
+		">=1.0 <2.0":    "1.0",
+		"[1.0,2.0)":     "1.0",
+		"1.x || 2.x":    "1",
+		"1.2.3+build.5": "1.2.3",
+		"1.x":           "1",
+		"2.1.*":         "2.1",
+		"v1.9.x":        "v1.9",
+		"*":             "",
+		"x":             "",
+		"latest":        "",
+		"dev-master":    "",
+		"":              "",
+	}
+	for version, want := range testCases {
+		if got := formatVersion(version); got != want {
+			t.Errorf("formatVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+// TestClassifyVersionSource tests range / pinned / resolved classification
+func TestClassifyVersionSource(t *testing.T) {
+	testCases := []struct {
+		declared string
+		source   string
+		want     string
+	}{
+		{declared: "^4.17.0", want: camodels.VersionSourceRange},
+		{declared: "~2.1", want: camodels.VersionSourceRange},
+		{declared: ">=1.0,<2", want: camodels.VersionSourceRange},
+		{declared: "[1.0,2.0)", want: camodels.VersionSourceRange},
+		{declared: "1.x", want: camodels.VersionSourceRange},
+		{declared: "==2.0.1", want: camodels.VersionSourcePinned},
+		{declared: "1.2.83", want: camodels.VersionSourcePinned},
+		{declared: "^4.17.0", source: camodels.VersionSourceResolved, want: camodels.VersionSourceResolved},
+	}
+
+	for _, tc := range testCases {
+		if got := classifyVersionSource(tc.declared, tc.source); got != tc.want {
+			t.Errorf("classifyVersionSource(%q, %q) = %q, want %q", tc.declared, tc.source, got, tc.want)
+		}
+	}
+}
+
+// TestExtractVersionInfo tests full version extraction with transforms and path fallback
+func TestExtractVersionInfo(t *testing.T) {
+	extractor := camodels.VersionExtractor{
+		FilePattern: "pom.xml",
+		Patterns:    []string{`<artifactId>(?P<name>fastjson)</artifactId>\s*<version>(?P<version>[^<]+)</version>`},
+		Transforms:  []string{camodels.TransformProperty},
+	}
+	content := []byte(`<fastjson.version>1.2.83</fastjson.version>
+<artifactId>fastjson</artifactId>
+<version>${fastjson.version}</version>`)
+
+	infos := extractVersionInfos(extractor, content, "pom.xml")
+	if len(infos) != 1 {
+		t.Fatalf("extractVersionInfos() = %+v, want 1 version", infos)
+	}
+	info := infos[0]
+	want := camodels.VersionInfo{Version: "1.2.83", Raw: "${fastjson.version}", Source: camodels.VersionSourcePinned, Name: "fastjson", File: "pom.xml"}
+	if !reflect.DeepEqual(*info, want) {
+		t.Errorf("extractVersionInfos() = %+v, want %+v", *info, want)
+	}
+
+	// 复合范围只保留第一个约束的版本，并按范围记录
+	rangeExtractor := camodels.VersionExtractor{FilePattern: "requirements.txt", Patterns: []string{`(?m)^flask\s*(\S+)$`}}
+	infos = extractVersionInfos(rangeExtractor, []byte("flask>=2.0,<3\n"), "requirements.txt")
+	if len(infos) != 1 {
+		t.Fatalf("extractVersionInfos() for compound range = %+v", infos)
+	}
+	info = infos[0]
+	if info.Version != "2.0" || info.Raw != ">=2.0,<3" || info.Source != camodels.VersionSourceRange {
+		t.Fatalf("extractVersionInfos() for compound range = %+v", info)
+	}
+	info.ParseFor(camodels.EcosystemPyPI)
+	if info.Range == nil || !info.Range.Contains("2.5") || info.Range.Contains("3.0") {
+		t.Errorf("expected range >=2.0,<3 to be parsed, got %+v", info.Range)
+	}
+
+	// 从文件路径提取的版本视为已解析版本
+	jarExtractor := camodels.VersionExtractor{FilePattern: "fastjson-*.jar", Patterns: []string{`fastjson-([0-9.]+)\.jar`}}
+	infos = extractVersionInfos(jarExtractor, []byte("PK binary"), "lib/fastjson-1.2.83.jar")
+	if len(infos) != 1 || infos[0].Version != "1.2.83" || infos[0].Source != camodels.VersionSourceResolved {
+		t.Errorf("extractVersionInfos() from path = %+v", infos)
+	}
+}
@@ -0,0 +1,473 @@
+// Package gitfs 将本地 git 仓库（包括裸仓库）某个修订版本的文件树以只读 fs.FS 的形式提供，
+// 文件内容直接从 git 对象读取，不需要检出工作区。依赖系统中的 git 命令。
+package gitfs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// git 树对象中的文件模式
+const (
+	modeSymlink   = "120000"
+	modeSubmodule = "160000"
+)
+
+// blobWindow 打开的文件每次从 git 读取的最大字节数，读取更多内容时按窗口继续读取，大文件不会整体加载到内存
+const blobWindow = 1024 * 1024
+
+// Revision 解析后的修订版本
+type Revision struct {
+	Ref    string    // 用户指定的引用，例如 "v1.2.0"、"HEAD~3"
+	Commit string    // 完整的提交哈希
+	Time   time.Time // 提交时间
+}
+
+// FS git 修订版本的只读文件系统，并发安全；使用完毕后需要调用 Close
+type FS struct {
+	repo     string
+	revision Revision
+	entries  map[string]*entry   // 路径 -> 文件或目录
+	children map[string][]*entry // 目录 -> 按名称排序的子项
+
+	mu    sync.Mutex
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	out   *bufio.Reader
+}
+
+// entry 文件树中的一项
+type entry struct {
+	name string
+	mode fs.FileMode
+	oid  string // blob 对象哈希，目录为空
+	size int64
+}
+
+// Open 解析仓库 repo 中的修订版本 ref 并读取其完整文件树
+func Open(repo, ref string) (*FS, error) {
+	revision, err := Resolve(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys := &FS{
+		repo:     repo,
+		revision: revision,
+		entries:  map[string]*entry{".": {name: ".", mode: fs.ModeDir | 0555}},
+		children: make(map[string][]*entry),
+	}
+	out, err := git(repo, "ls-tree", "-r", "-l", "-z", "--full-tree", revision.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("list git tree %s error: %w", revision.Commit, err)
+	}
+	if err := fsys.parseTree(out); err != nil {
+		return nil, err
+	}
+	return fsys, nil
+}
+
+// Resolve 将仓库 repo 中的引用 ref 解析为提交
+func Resolve(repo, ref string) (Revision, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return Revision{}, fmt.Errorf("git executable not found: %w", err)
+	}
+	out, err := git(repo, "log", "-1", "--format=%H %ct", "--end-of-options", ref+"^{commit}", "--")
+	if err != nil {
+		return Revision{}, fmt.Errorf("resolve git ref %q in %s error: %w", ref, repo, err)
+	}
+	revisions := parseRevisions(out)
+	if len(revisions) != 1 {
+		return Revision{}, fmt.Errorf("resolve git ref %q in %s error: unexpected output %q", ref, repo, out)
+	}
+	revisions[0].Ref = ref
+	return revisions[0], nil
+}
+
+// Tags 返回仓库中指向提交的全部标签，按提交时间从早到晚排序（时间相同时按标签名）
+func Tags(repo string) ([]Revision, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git executable not found: %w", err)
+	}
+	out, err := git(repo, "for-each-ref", "--format=%(refname:short)", "refs/tags")
+	if err != nil {
+		return nil, fmt.Errorf("list git tags in %s error: %w", repo, err)
+	}
+	var tags []Revision
+	for _, name := range strings.Fields(string(out)) {
+		revision, err := Resolve(repo, "refs/tags/"+name)
+		if err != nil {
+			// 指向树或 blob 的标签没有对应的提交
+			continue
+		}
+		revision.Ref = name
+		tags = append(tags, revision)
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if !tags[i].Time.Equal(tags[j].Time) {
+			return tags[i].Time.Before(tags[j].Time)
+		}
+		return tags[i].Ref < tags[j].Ref
+	})
+	return tags, nil
+}
+
+// Commits 返回 ref 第一父提交历史中均匀抽样的 count 个提交（总是包含最早和最新的提交），按提交时间从早到晚排序；
+// count <= 0 时返回全部提交。抽样的提交以 12 位短哈希作为 Ref
+func Commits(repo, ref string, count int) ([]Revision, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git executable not found: %w", err)
+	}
+	out, err := git(repo, "log", "--first-parent", "--reverse", "--format=%H %ct", "--end-of-options", ref, "--")
+	if err != nil {
+		return nil, fmt.Errorf("list git commits of %q in %s error: %w", ref, repo, err)
+	}
+	commits := sample(parseRevisions(out), count)
+	for i := range commits {
+		commits[i].Ref = commits[i].Commit[:min(12, len(commits[i].Commit))]
+	}
+	return commits, nil
+}
+
+// sample 从列表中均匀选取 count 项，保留首尾
+func sample(list []Revision, count int) []Revision {
+	if count <= 0 || len(list) <= count {
+		return list
+	}
+	if count == 1 {
+		return list[len(list)-1:]
+	}
+	result := make([]Revision, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, list[i*(len(list)-1)/(count-1)])
+	}
+	return result
+}
+
+// parseRevisions 解析每行 "<commit> <unix time>" 格式的输出
+func parseRevisions(out []byte) []Revision {
+	var revisions []Revision
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		seconds, _ := strconv.ParseInt(fields[1], 10, 64)
+		revisions = append(revisions, Revision{Commit: fields[0], Time: time.Unix(seconds, 0).UTC()})
+	}
+	return revisions
+}
+
+// Revision 返回文件系统对应的修订版本
+func (f *FS) Revision() Revision {
+	return f.revision
+}
+
+// parseTree 解析 "git ls-tree -r -l -z" 的输出：<mode> <type> <object> <size>\t<path>\0
+func (f *FS) parseTree(out []byte) error {
+	for _, record := range bytes.Split(out, []byte{0}) {
+		if len(record) == 0 {
+			continue
+		}
+		meta, name, ok := strings.Cut(string(record), "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 4 || !fs.ValidPath(name) {
+			return fmt.Errorf("unexpected git ls-tree output: %q", record)
+		}
+		e := &entry{name: path.Base(name), oid: fields[2], mode: 0444}
+		switch fields[0] {
+		case modeSubmodule:
+			// 子模块指向其他仓库的提交，内容不在当前仓库中
+			continue
+		case modeSymlink:
+			e.mode = fs.ModeSymlink | 0777
+		}
+		e.size, _ = strconv.ParseInt(fields[3], 10, 64)
+		f.addEntry(name, e)
+	}
+	for _, list := range f.children {
+		sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	}
+	return nil
+}
+
+// addEntry 添加文件并补全其上级目录
+func (f *FS) addEntry(name string, e *entry) {
+	f.entries[name] = e
+	for {
+		dir := path.Dir(name)
+		f.children[dir] = append(f.children[dir], f.entries[name])
+		if dir == "." {
+			return
+		}
+		if _, exists := f.entries[dir]; exists {
+			return
+		}
+		f.entries[dir] = &entry{name: path.Base(dir), mode: fs.ModeDir | 0555}
+		name = dir
+	}
+}
+
+// Open 实现 fs.FS
+func (f *FS) Open(name string) (fs.File, error) {
+	e, err := f.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.mode.IsDir() {
+		return &dirFile{info: e, entries: f.children[name]}, nil
+	}
+	return &lazyFile{fsys: f, info: e}, nil
+}
+
+// ReadFile 实现 fs.ReadFileFS
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	e, err := f.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	content, err := f.readBlob(e.oid, 0, -1)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return content, nil
+}
+
+// ReadDir 实现 fs.ReadDirFS
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := f.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return dirEntries(f.children[name]), nil
+}
+
+// Stat 实现 fs.StatFS
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	e, err := f.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (f *FS) lookup(op, name string) (*entry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := f.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return e, nil
+}
+
+// readBlob 通过常驻的 "git cat-file --batch" 进程读取 blob 从 offset 开始的最多 limit 字节，limit < 0 时读取到末尾
+func (f *FS) readBlob(oid string, offset, limit int64) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cmd == nil {
+		if err := f.startBatch(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := io.WriteString(f.stdin, oid+"\n"); err != nil {
+		f.resetBatch()
+		return nil, err
+	}
+	header, err := f.out.ReadString('\n')
+	if err != nil {
+		f.resetBatch()
+		return nil, err
+	}
+	// <oid> <type> <size>\n，对象不存在时为 <oid> missing\n
+	fields := strings.Fields(header)
+	if len(fields) == 2 && fields[1] == "missing" {
+		return nil, fmt.Errorf("read git object %s error: %s", oid, strings.TrimSpace(header))
+	}
+	size, err := strconv.ParseInt(fields[len(fields)-1], 10, 64)
+	if len(fields) != 3 || err != nil {
+		// 无法确定记录长度，后续输出不再对齐
+		f.resetBatch()
+		return nil, fmt.Errorf("read git object %s error: %s", oid, strings.TrimSpace(header))
+	}
+	if fields[1] != "blob" {
+		// 跳过整条记录
+		offset, limit = size, 0
+	}
+	content, err := f.readRecord(size, offset, limit)
+	if err != nil {
+		f.resetBatch()
+		return nil, fmt.Errorf("read git object %s error: %w", oid, err)
+	}
+	if fields[1] != "blob" {
+		return nil, fmt.Errorf("read git object %s error: %s", oid, strings.TrimSpace(header))
+	}
+	return content, nil
+}
+
+// readRecord 读取 cat-file 输出中长度为 size 的对象内容的 [offset, offset+limit) 部分，
+// 其余内容和结尾的换行被丢弃，保证下一次请求从记录边界开始；limit < 0 时读取到对象末尾
+func (f *FS) readRecord(size, offset, limit int64) ([]byte, error) {
+	offset = min(max(offset, 0), size)
+	if limit < 0 || limit > size-offset {
+		limit = size - offset
+	}
+	if _, err := io.CopyN(io.Discard, f.out, offset); err != nil {
+		return nil, err
+	}
+	content := make([]byte, limit)
+	if _, err := io.ReadFull(io.LimitReader(f.out, limit), content); err != nil {
+		return nil, err
+	}
+	// 剩余内容和记录结尾的换行
+	if _, err := io.CopyN(io.Discard, f.out, size-offset-limit+1); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (f *FS) startBatch() error {
+	cmd := exec.Command("git", "-C", f.repo, "cat-file", "--batch")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start git cat-file error: %w", err)
+	}
+	f.cmd, f.stdin, f.out = cmd, stdin, bufio.NewReader(stdout)
+	return nil
+}
+
+// resetBatch 结束读取中途出错的 git 进程：输出已无法对齐到记录边界，下次读取时重新启动
+func (f *FS) resetBatch() {
+	_ = f.stdin.Close()
+	_ = f.cmd.Process.Kill()
+	_ = f.cmd.Wait()
+	f.cmd, f.stdin, f.out = nil, nil, nil
+}
+
+// Close 结束读取文件内容的 git 进程
+func (f *FS) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cmd == nil {
+		return nil
+	}
+	_ = f.stdin.Close()
+	err := f.cmd.Wait()
+	f.cmd = nil
+	return err
+}
+
+// git 在仓库目录中执行 git 命令并返回标准输出，失败时错误中包含标准错误输出
+func git(repo string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// entry 同时实现 fs.FileInfo 和 fs.DirEntry
+func (e *entry) Name() string               { return e.name }
+func (e *entry) Size() int64                { return e.size }
+func (e *entry) Mode() fs.FileMode          { return e.mode }
+func (e *entry) ModTime() time.Time         { return time.Time{} }
+func (e *entry) IsDir() bool                { return e.mode.IsDir() }
+func (e *entry) Sys() any                   { return nil }
+func (e *entry) Type() fs.FileMode          { return e.mode.Type() }
+func (e *entry) Info() (fs.FileInfo, error) { return e, nil }
+
+func dirEntries(list []*entry) []fs.DirEntry {
+	entries := make([]fs.DirEntry, len(list))
+	for i, e := range list {
+		entries[i] = e
+	}
+	return entries
+}
+
+// lazyFile 读取时才从 git 加载内容的文件，每次最多加载 blobWindow 字节
+type lazyFile struct {
+	fsys   *FS
+	info   *entry
+	offset int64  // 已读取的字节数
+	buf    []byte // 当前窗口中尚未读取的内容
+}
+
+func (l *lazyFile) Stat() (fs.FileInfo, error) { return l.info, nil }
+func (l *lazyFile) Close() error               { return nil }
+
+func (l *lazyFile) Read(p []byte) (int, error) {
+	if len(l.buf) == 0 {
+		if l.offset >= l.info.size {
+			return 0, io.EOF
+		}
+		content, err := l.fsys.readBlob(l.info.oid, l.offset, blobWindow)
+		if err != nil {
+			return 0, &fs.PathError{Op: "read", Path: l.info.name, Err: err}
+		}
+		if len(content) == 0 {
+			return 0, io.EOF
+		}
+		l.buf = content
+	}
+	n := copy(p, l.buf)
+	l.buf = l.buf[n:]
+	l.offset += int64(n)
+	return n, nil
+}
+
+// dirFile 实现 fs.ReadDirFile
+type dirFile struct {
+	info    *entry
+	entries []*entry
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n > 0 && len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(rest) {
+		rest = rest[:n]
+	}
+	d.offset += len(rest)
+	return dirEntries(rest), nil
+}
@@ -0,0 +1,225 @@
+package gitfs
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// runGit 在目录中执行 git 命令，使用固定的提交者信息
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE=2024-01-02T03:04:05Z", "GIT_AUTHOR_DATE=2024-01-02T03:04:05Z")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	file := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestRepo 创建包含两个提交的仓库，第一个提交打上 v1 标签
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q")
+	writeFile(t, repo, "go.mod", "module example.com/app\n")
+	writeFile(t, repo, "cmd/app/main.go", "package main\n\nfunc main() {}\n")
+	writeFile(t, repo, "web/src/index.js", "console.log('v1')\n")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "v1")
+	runGit(t, repo, "tag", "v1")
+
+	writeFile(t, repo, "web/src/index.js", "console.log('v2')\n")
+	writeFile(t, repo, "README.md", "# app\n")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "v2")
+	// 工作区中未提交的修改不应出现在任何修订版本中
+	writeFile(t, repo, "untracked.txt", "dirty\n")
+	return repo
+}
+
+// TestOpen tests reading a tagged revision of a work tree repository
+func TestOpen(t *testing.T) {
+	repo := newTestRepo(t)
+	fsys, err := Open(repo, "v1")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer fsys.Close()
+
+	if err := fstest.TestFS(fsys, "go.mod", "cmd/app/main.go", "web/src/index.js"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := fs.ReadFile(fsys, "web/src/index.js")
+	if err != nil || string(content) != "console.log('v1')\n" {
+		t.Errorf("ReadFile() = %q, %v", content, err)
+	}
+	for _, name := range []string{"README.md", "untracked.txt"} {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			t.Errorf("Stat(%s) error = nil, want not exist", name)
+		}
+	}
+	revision := fsys.Revision()
+	if revision.Ref != "v1" || len(revision.Commit) != 40 || revision.Time.Format("2006-01-02") != "2024-01-02" {
+		t.Errorf("Revision() = %+v", revision)
+	}
+}
+
+// TestOpenBare tests reading a bare repository and an unknown revision
+func TestOpenBare(t *testing.T) {
+	repo := newTestRepo(t)
+	bare := filepath.Join(t.TempDir(), "app.git")
+	runGit(t, repo, "clone", "-q", "--bare", repo, bare)
+
+	fsys, err := Open(bare, "HEAD")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer fsys.Close()
+	content, err := fsys.ReadFile("web/src/index.js")
+	if err != nil || string(content) != "console.log('v2')\n" {
+		t.Errorf("ReadFile() = %q, %v", content, err)
+	}
+	info, err := fsys.Stat("README.md")
+	if err != nil || info.Size() != int64(len("# app\n")) {
+		t.Errorf("Stat() = %v, %v", info, err)
+	}
+
+	if _, err := Open(bare, "no-such-ref"); err == nil {
+		t.Error("Open(no-such-ref) error = nil, want error")
+	}
+}
+
+// TestReadLargeBlob tests that large blobs are read in windows and partial reads keep the batch process in sync
+func TestReadLargeBlob(t *testing.T) {
+	repo := newTestRepo(t)
+	large := strings.Repeat("0123456789abcdef", blobWindow/16*2+100)
+	writeFile(t, repo, "lib/large.bin", large)
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "large")
+
+	fsys, err := Open(repo, "HEAD")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer fsys.Close()
+
+	// 只读取开头部分，剩余内容不应影响后续读取
+	file, err := fsys.Open("lib/large.bin")
+	if err != nil {
+		t.Fatalf("Open(lib/large.bin) error = %v", err)
+	}
+	head, err := io.ReadAll(io.LimitReader(file, 100))
+	file.Close()
+	if err != nil || string(head) != large[:100] {
+		t.Errorf("partial read = %q, %v", head, err)
+	}
+	if content, err := fs.ReadFile(fsys, "README.md"); err != nil || string(content) != "# app\n" {
+		t.Errorf("ReadFile(README.md) after partial read = %q, %v", content, err)
+	}
+
+	file, err = fsys.Open("lib/large.bin")
+	if err != nil {
+		t.Fatalf("Open(lib/large.bin) error = %v", err)
+	}
+	defer file.Close()
+	buf := make([]byte, 2*blobWindow)
+	n, err := file.Read(buf)
+	if err != nil || n != blobWindow {
+		t.Errorf("Read() = %d, %v, want one window of %d bytes", n, err, blobWindow)
+	}
+	rest, err := io.ReadAll(file)
+	if err != nil || string(buf[:n])+string(rest) != large {
+		t.Errorf("ReadAll() returned %d bytes, %v, want %d bytes", n+len(rest), err, len(large))
+	}
+}
+
+// TestReadRecord tests that the unread part of a cat-file record and its trailing newline are discarded
+func TestReadRecord(t *testing.T) {
+	f := &FS{out: bufio.NewReader(strings.NewReader("abcdef\nnext\n"))}
+	content, err := f.readRecord(6, 1, 2)
+	if err != nil || string(content) != "bc" {
+		t.Errorf("readRecord() = %q, %v", content, err)
+	}
+	if next, err := f.out.ReadString('\n'); err != nil || next != "next\n" {
+		t.Errorf("next record = %q, %v", next, err)
+	}
+
+	// 记录被截断时返回错误
+	f = &FS{out: bufio.NewReader(strings.NewReader("abc"))}
+	if _, err := f.readRecord(6, 0, -1); err == nil {
+		t.Error("readRecord() of a short record error = nil")
+	}
+}
+
+// TestTagsAndCommits tests listing tags and sampling the first-parent history
+func TestTagsAndCommits(t *testing.T) {
+	repo := newTestRepo(t)
+	runGit(t, repo, "tag", "v2")
+
+	tags, err := Tags(repo)
+	if err != nil {
+		t.Fatalf("Tags() error = %v", err)
+	}
+	if len(tags) != 2 || tags[0].Ref != "v1" || tags[1].Ref != "v2" || tags[0].Commit == tags[1].Commit {
+		t.Errorf("Tags() = %+v", tags)
+	}
+
+	commits, err := Commits(repo, "HEAD", 0)
+	if err != nil {
+		t.Fatalf("Commits() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0].Commit != tags[0].Commit || commits[1].Ref != tags[1].Commit[:12] {
+		t.Errorf("Commits() = %+v", commits)
+	}
+	if _, err := Commits(repo, "no-such-ref", 0); err == nil {
+		t.Error("Commits(no-such-ref) error = nil, want error")
+	}
+}
+
+// TestSample tests that sampling keeps the first and last revisions
+func TestSample(t *testing.T) {
+	var list []Revision
+	for i := 0; i < 10; i++ {
+		list = append(list, Revision{Commit: string(rune('a' + i))})
+	}
+	testCases := []struct {
+		count int
+		want  string
+	}{
+		{0, "abcdefghij"},
+		{20, "abcdefghij"},
+		{1, "j"},
+		{2, "aj"},
+		{4, "adgj"},
+	}
+	for _, tc := range testCases {
+		got := ""
+		for _, revision := range sample(list, tc.count) {
+			got += revision.Commit
+		}
+		if got != tc.want {
+			t.Errorf("sample(%d) = %s, want %s", tc.count, got, tc.want)
+		}
+	}
+}
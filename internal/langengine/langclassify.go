@@ -1,7 +1,10 @@
 package langengine
 
 import (
+	"io/fs"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/winezer0/slogs"
 
@@ -16,12 +19,21 @@ type LangClassify struct {
 	langMap map[string]camodels.Language
 }
 
-// LanguageRules 加载embeds的默认规则
-var LanguageRules = embeds.EmbeddedLangRules()
+// LanguageRules 加载embeds的默认规则，同名语言已合并
+// LanguageConflicts 加载默认规则时发现的冲突
+var LanguageRules, LanguageConflicts = embeds.EmbeddedLangRules()
+
+// warnConflictsOnce 语言规则冲突只在第一次创建分类器时输出警告（此时日志器已初始化）
+var warnConflictsOnce sync.Once
 
 // NewLangClassifier 创建一个新的语言分类器实例
 // 初始化分类器并加载所有语言规则
 func NewLangClassifier() *LangClassify {
+	warnConflictsOnce.Do(func() {
+		for _, conflict := range LanguageConflicts {
+			slogs.Warnf("language rule conflict: %s", conflict)
+		}
+	})
 	c := &LangClassify{
 		langMap: LanguageRules,
 	}
@@ -39,13 +51,21 @@ func NewLangClassifier() *LangClassify {
 // - other: 其他语言列表
 // - all: 所有语言列表（去重）
 func (c *LangClassify) DetectCategories(root string, langs []camodels.LangInfo) (frontend, backend, desktop, other, all, expand []string) {
+	return c.DetectCategoriesFS(os.DirFS(root), langs)
+}
+
+// DetectCategoriesFS 与 DetectCategories 相同，项目文件（依赖清单、动态分类的文件模式）从 fsys 的根目录读取
+func (c *LangClassify) DetectCategoriesFS(fsys fs.FS, langs []camodels.LangInfo) (frontend, backend, desktop, other, all, expand []string) {
 	frontedSet := make(map[string]bool)
 	backendSet := make(map[string]bool)
 	desktopSet := make(map[string]bool)
 	otherSet := make(map[string]bool)
 	allSet := make(map[string]bool) // 用于去重所有语言
 
-	deps := readPackageJSONDeps(root)
+	deps := readPackageJSONDeps(fsys)
+	for name := range readPythonDeps(fsys) {
+		deps[name] = true
+	}
 	for _, langInfo := range langs {
 		name := strings.ToLower(langInfo.Name)
 		allSet[langInfo.Name] = true
@@ -57,7 +77,7 @@ func (c *LangClassify) DetectCategories(root string, langs []camodels.LangInfo)
 			continue
 		} else {
 			// 应用动态分类规则
-			cats := ApplyDynamicHeuristics(root, langRule, deps)
+			cats := ApplyDynamicHeuristics(fsys, langRule, deps)
 			for _, cat := range cats {
 				// 根据分类结果添加到相应的集合
 				switch cat {
@@ -24,6 +24,7 @@ func TestDetectCategories(t *testing.T) {
 		packageJson  map[string]any
 		wantFrontend []string
 		wantBackend  []string
+		wantDesktop  []string
 	}{
 		{
 			name: "Basic Backend",
@@ -67,6 +68,14 @@ func TestDetectCategories(t *testing.T) {
 			wantFrontend: []string{"TypeScript"},
 			wantBackend:  []string{"Go"},
 		},
+		{
+			// the desktop.yml declaration of Python only applies through its dynamic rules
+			name: "Python without desktop dependencies",
+			languages: []camodels.LangInfo{
+				{Name: "Python"},
+			},
+			wantBackend: []string{"Python"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -79,10 +88,11 @@ func TestDetectCategories(t *testing.T) {
 				os.Remove(filepath.Join(tmpDir, "package.json"))
 			}
 
-			frontend, backend, _, _, _, _ := c.DetectCategories(tmpDir, tt.languages)
+			frontend, backend, desktop, _, _, _ := c.DetectCategories(tmpDir, tt.languages)
 
 			checkList(t, "Frontend", frontend, tt.wantFrontend)
 			checkList(t, "Backend", backend, tt.wantBackend)
+			checkList(t, "Desktop", desktop, tt.wantDesktop)
 		})
 	}
 }
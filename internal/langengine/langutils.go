@@ -2,21 +2,24 @@ package langengine
 
 import (
 	"encoding/json"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"path"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/winezer0/xcanvas/camodels"
+	"github.com/winezer0/xcanvas/internal/manifest"
 )
 
 // ApplyDynamicHeuristics 应用动态分类规则对语言进行分类
 // 参数:
-// - root: 项目根目录路径
+// - fsys: 项目根目录
 // - lang: 统一语言模型
 // - deps: 项目依赖映射
 // 返回值:
 // - string: 分类结果（frontend/backend/desktop/other）
-func ApplyDynamicHeuristics(root string, lang camodels.Language, deps map[string]bool) []string {
+func ApplyDynamicHeuristics(fsys fs.FS, lang camodels.Language, deps map[string]bool) []string {
 	baseRes := []string{lang.Category}
 	if len(lang.Dynamic) == 0 {
 		return baseRes
@@ -27,7 +30,7 @@ func ApplyDynamicHeuristics(root string, lang camodels.Language, deps map[string
 		// 检查依赖条件
 		if len(dynamic.Dependencies) > 0 {
 			for _, dep := range dynamic.Dependencies {
-				if deps[strings.ToLower(dep)] {
+				if deps[strings.ToLower(dep)] || deps[manifest.NormalizePythonName(dep)] {
 					baseRes = append(baseRes, dynamic.Category)
 				}
 			}
@@ -35,7 +38,7 @@ func ApplyDynamicHeuristics(root string, lang camodels.Language, deps map[string
 		// 检查文件模式条件
 		if len(dynamic.FilePatterns) > 0 {
 			for _, pattern := range dynamic.FilePatterns {
-				matches, _ := filepath.Glob(filepath.Join(root, pattern))
+				matches, _ := fs.Glob(fsys, path.Clean(pattern))
 				if len(matches) > 0 {
 					baseRes = append(baseRes, dynamic.Category)
 				}
@@ -48,16 +51,56 @@ func ApplyDynamicHeuristics(root string, lang camodels.Language, deps map[string
 }
 
 // readPackageJSONDeps 从package.json读取项目依赖，用于JavaScript/TypeScript分类
+// 除根目录外，还会读取 workspaces（package.json）和 pnpm-workspace.yaml 声明的工作区包
 // 参数:
-// - root: 项目根目录路径
+// - fsys: 项目根目录
 // 返回值:
 // - map[string]bool: 依赖包名称映射（小写）
-func readPackageJSONDeps(root string) map[string]bool {
+func readPackageJSONDeps(fsys fs.FS) map[string]bool {
 	res := map[string]bool{}
-	p := filepath.Join(root, "package.json")
-	b, err := os.ReadFile(p)
+	m := readPackageJSON(fsys, "package.json", res)
+	if m == nil {
+		m = map[string]any{}
+	}
+
+	// 收集工作区声明：数组形式或 {"packages": [...]} 形式
+	var patterns []string
+	switch ws := m["workspaces"].(type) {
+	case []any:
+		patterns = appendStrings(patterns, ws)
+	case map[string]any:
+		if pkgs, ok := ws["packages"].([]any); ok {
+			patterns = appendStrings(patterns, pkgs)
+		}
+	}
+	if b, err := fs.ReadFile(fsys, "pnpm-workspace.yaml"); err == nil {
+		var pnpm struct {
+			Packages []string `yaml:"packages"`
+		}
+		if yaml.Unmarshal(b, &pnpm) == nil {
+			patterns = append(patterns, pnpm.Packages...)
+		}
+	}
+
+	for _, pattern := range patterns {
+		// 排除规则忽略；fs.Glob 不支持 **，按单层目录处理
+		if pattern == "" || strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		pattern = strings.ReplaceAll(strings.TrimSuffix(pattern, "/"), "**", "*")
+		matches, _ := fs.Glob(fsys, path.Join(path.Clean(pattern), "package.json"))
+		for _, match := range matches {
+			readPackageJSON(fsys, match, res)
+		}
+	}
+	return res
+}
+
+// readPackageJSON 读取单个package.json，将依赖名称写入res，返回解析后的内容（失败时为nil）
+func readPackageJSON(fsys fs.FS, p string, res map[string]bool) map[string]any {
+	b, err := fs.ReadFile(fsys, p)
 	if err != nil {
-		return res
+		return nil
 	}
 	var m map[string]any
 	_ = json.Unmarshal(b, &m)
@@ -71,11 +114,54 @@ func readPackageJSONDeps(root string) map[string]bool {
 			}
 		}
 	}
+	return m
+}
+
+// readPythonDeps 从根目录的Python依赖清单读取项目依赖，用于Python分类
+// 支持 requirements*.txt（含 requirements/ 目录）、pyproject.toml、setup.cfg、setup.py、Pipfile 及锁文件
+// 参数:
+// - fsys: 项目根目录
+// 返回值:
+// - map[string]bool: 依赖包名称映射（PEP 503 规范化）
+func readPythonDeps(fsys fs.FS) map[string]bool {
+	res := map[string]bool{}
+	var files []string
+	for _, dir := range []string{".", "requirements"} {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			file := path.Join(dir, entry.Name())
+			if manifest.IsPythonManifest(file) || manifest.IsPythonLock(file) {
+				files = append(files, file)
+			}
+		}
+	}
+	read := func(relPath string) ([]byte, error) {
+		return fs.ReadFile(fsys, relPath)
+	}
+	for _, dependency := range manifest.ParsePythonProject(files, read) {
+		res[dependency.Name] = true
+	}
 	return res
 }
 
-// ExpandLanguages 在给定的语言列表中，自动补充关联语言，以确保语义完整性。
-// 例如：
+// appendStrings 将列表中的字符串元素追加到dst
+func appendStrings(dst []string, list []any) []string {
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			dst = append(dst, s)
+		}
+	}
+	return dst
+}
+
+// ExpandLanguages 在给定的语言列表中，根据语言规则中的 implies 自动补充关联语言，以确保语义完整性。
+// 隐含关系支持传递，例如：
 // - TypeScript/TSX/JSX/Vue -> JavaScript (确保能匹配 JS 生态的规则)
 // - SCSS/Less -> CSS (确保能匹配 CSS 规则)
 // - Kotlin -> Java (确保能匹配 Java/JVM 生态规则)
@@ -86,36 +172,18 @@ func ExpandLanguages(langs []string) []string {
 		seen[l] = true
 	}
 
-	// 辅助函数：如果语言不存在则添加
-	add := func(newLang string) {
-		if !seen[newLang] {
-			langs = append(langs, newLang)
-			seen[newLang] = true
+	// 逐个展开，新加入的语言也会继续展开其隐含语言
+	for i := 0; i < len(langs); i++ {
+		langRule, ok := LanguageRules[strings.ToLower(langs[i])]
+		if !ok {
+			continue
+		}
+		for _, implied := range langRule.Implies {
+			if !seen[implied] {
+				langs = append(langs, implied)
+				seen[implied] = true
+			}
 		}
-	}
-
-	// 1. JavaScript 生态系统
-	// Vue, React (JSX/TSX), TypeScript 都属于 JS 生态
-	if seen["TypeScript"] || seen["TSX"] || seen["JSX"] || seen["Vue"] {
-		add("JavaScript")
-	}
-
-	// 2. CSS 生态系统
-	// 预处理器文件通常也意味着 CSS 规则适用
-	if seen["SCSS"] || seen["Less"] {
-		add("CSS")
-	}
-
-	// 3. Java/JVM 生态系统
-	// Kotlin 通常与 Java 库/框架（如 Spring）混用
-	if seen["Kotlin"] {
-		add("Java")
-	}
-
-	// 4. C/C++ 生态系统
-	// C++ 往往包含或链接 C 代码
-	if seen["C++"] {
-		add("C")
 	}
 
 	return langs
@@ -1,6 +1,8 @@
 package langengine
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
@@ -78,3 +80,57 @@ func TestExpandLanguages(t *testing.T) {
 		})
 	}
 }
+
+func TestReadPackageJSONDepsWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"package.json":              `{"workspaces": {"packages": ["apps/*"]}, "devDependencies": {"typescript": "^5.0.0"}}`,
+		"apps/web/package.json":     `{"dependencies": {"React": "^18.2.0"}}`,
+		"pnpm-workspace.yaml":       "packages:\n  - 'services/**'\n  - '!**/test/**'\n",
+		"services/api/package.json": `{"dependencies": {"express": "^4.18.0"}}`,
+		"unlisted/package.json":     `{"dependencies": {"electron": "^25.0.0"}}`,
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps := readPackageJSONDeps(os.DirFS(root))
+	for _, name := range []string{"typescript", "react", "express"} {
+		if !deps[name] {
+			t.Errorf("dependency %s not found in %v", name, deps)
+		}
+	}
+	if deps["electron"] {
+		t.Errorf("dependency from non-workspace package reported: %v", deps)
+	}
+}
+
+func TestReadPythonDeps(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"requirements/base.txt": "PyQt5>=5.15\n",
+		"pyproject.toml":        "[project]\ndependencies = [\"Flask>=3.0\"]\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps := readPythonDeps(os.DirFS(root))
+	for _, name := range []string{"pyqt5", "flask"} {
+		if !deps[name] {
+			t.Errorf("dependency %s not found in %v", name, deps)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package lifecycle 根据离线的生命周期数据标注检测项所属的发布周期、停止支持状态和周期内最新版本。
+package lifecycle
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// dateLayout 生命周期数据中的日期格式
+const dateLayout = "2006-01-02"
+
+// Dataset 按规则名称（小写）索引的生命周期数据
+type Dataset map[string]camodels.Lifecycle
+
+// New 由生命周期列表构建数据集，同名条目后者覆盖前者
+func New(lifecycles ...[]camodels.Lifecycle) Dataset {
+	dataset := make(Dataset)
+	for _, list := range lifecycles {
+		for _, lifecycle := range list {
+			if lifecycle.Name != "" {
+				dataset[strings.ToLower(lifecycle.Name)] = lifecycle
+			}
+		}
+	}
+	return dataset
+}
+
+// Load 从 YAML 文件或目录（读取其中的 *.yml / *.yaml）加载生命周期列表，格式与内置数据相同
+func Load(path string) ([]camodels.Lifecycle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("open lifecycle data %s error: %w", path, err)
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			ext := strings.ToLower(filepath.Ext(file))
+			if !d.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read lifecycle dir %s error: %w", path, err)
+		}
+	}
+
+	var lifecycles []camodels.Lifecycle
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read lifecycle file %s error: %w", file, err)
+		}
+		var items []camodels.Lifecycle
+		if err := yaml.Unmarshal(content, &items); err != nil {
+			return nil, fmt.Errorf("parse lifecycle file %s error: %w", file, err)
+		}
+		lifecycles = append(lifecycles, items...)
+	}
+	return lifecycles, nil
+}
+
+// Annotate 为检测结果中的框架和组件填充 Cycle、EOL、SupportedUntil 和 LatestInCycle，now 用于判断是否已停止支持。
+// 主版本按最长前缀匹配发布周期；只知道声明范围（来源为 range）时视为版本未知，
+// 无法确定版本时，只有全部周期都已停止支持才标记为 EOL
+func (d Dataset) Annotate(info *camodels.DetectionInfo, now time.Time) {
+	for _, items := range [][]camodels.DetectedItem{info.Frameworks, info.Components} {
+		for i := range items {
+			d.annotateItem(&items[i], now)
+		}
+	}
+}
+
+func (d Dataset) annotateItem(item *camodels.DetectedItem, now time.Time) {
+	item.Cycle, item.EOL, item.SupportedUntil, item.LatestInCycle = "", false, "", ""
+	lifecycle, ok := d[strings.ToLower(item.Name)]
+	if !ok || len(lifecycle.Cycles) == 0 {
+		return
+	}
+
+	version := exactVersion(item)
+	if version == "" {
+		latest := ""
+		for _, cycle := range lifecycle.Cycles {
+			eol, until := cycleEOL(cycle, now)
+			if !eol {
+				return
+			}
+			if until > latest {
+				latest = until
+			}
+		}
+		item.EOL, item.SupportedUntil = true, latest
+		return
+	}
+
+	cycle, ok := matchCycle(lifecycle.Cycles, version)
+	if !ok {
+		return
+	}
+	item.Cycle = cycle.Cycle
+	item.EOL, item.SupportedUntil = cycleEOL(cycle, now)
+	item.LatestInCycle = cycle.Latest
+}
+
+// exactVersion 返回检测项的确定版本：优先使用主版本，主版本只是声明范围的下界时（例如 "^10.0" 取到的 10.0，
+// 实际可能是更新的版本）使用其他固定或已解析的版本，都没有时返回空
+func exactVersion(item *camodels.DetectedItem) string {
+	if item.VersionInfo == nil || item.VersionInfo.Source != camodels.VersionSourceRange {
+		return item.Version
+	}
+	for _, info := range item.Versions {
+		if info != nil && info.Version != "" && info.Source != camodels.VersionSourceRange {
+			return info.Version
+		}
+	}
+	return ""
+}
+
+// matchCycle 返回与版本前缀匹配的最长发布周期，例如 1.11.29 优先匹配 "1.11" 而不是 "1"
+func matchCycle(cycles []camodels.ReleaseCycle, version string) (camodels.ReleaseCycle, bool) {
+	version = strings.TrimPrefix(strings.ToLower(version), "v")
+	var best camodels.ReleaseCycle
+	found := false
+	for _, cycle := range cycles {
+		prefix := strings.TrimPrefix(strings.ToLower(cycle.Cycle), "v")
+		if prefix == "" || (version != prefix && !strings.HasPrefix(version, prefix+".")) {
+			continue
+		}
+		if !found || len(prefix) > len(best.Cycle) {
+			best, found = cycle, true
+		}
+	}
+	return best, found
+}
+
+// cycleEOL 返回发布周期在 now 时是否已停止支持，以及支持截止日期（日期未知时为空）
+func cycleEOL(cycle camodels.ReleaseCycle, now time.Time) (bool, string) {
+	switch eol := strings.TrimSpace(cycle.EOL); eol {
+	case "", "false":
+		return false, ""
+	case "true":
+		return true, ""
+	default:
+		date, err := time.Parse(dateLayout, eol)
+		if err != nil {
+			return false, ""
+		}
+		return !now.Before(date.AddDate(0, 0, 1)), eol
+	}
+}
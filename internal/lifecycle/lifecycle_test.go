@@ -0,0 +1,141 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+var testLifecycles = []camodels.Lifecycle{
+	{Name: "Spring Boot", Cycles: []camodels.ReleaseCycle{
+		{Cycle: "1", EOL: "true"},
+		{Cycle: "1.5", EOL: "2019-08-06", Latest: "1.5.22.RELEASE"},
+		{Cycle: "3.2", EOL: "2024-12-31", Latest: "3.2.12"},
+		{Cycle: "3.3", EOL: "false"},
+	}},
+	{Name: "AngularJS", Cycles: []camodels.ReleaseCycle{{Cycle: "1", EOL: "2021-12-31", Latest: "1.8.3"}}},
+}
+
+// TestAnnotate tests cycle matching and end-of-life evaluation relative to the report time
+func TestAnnotate(t *testing.T) {
+	now := time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)
+	info := &camodels.DetectionInfo{
+		Frameworks: []camodels.DetectedItem{
+			{Name: "spring boot", Version: "1.5.9.RELEASE"},
+			{Name: "Spring Boot", Version: "1.3.0"},
+			{Name: "Spring Boot", Version: "3.2.5"},
+			{Name: "Spring Boot", Version: "3.3.0"},
+			{Name: "Spring Boot", Version: "2.7.0"},
+			{Name: "Gin", Version: "1.9.1"},
+		},
+		Components: []camodels.DetectedItem{{Name: "AngularJS"}},
+	}
+	New(testLifecycles).Annotate(info, now)
+
+	testCases := []struct {
+		item                          camodels.DetectedItem
+		cycle, supportedUntil, latest string
+		eol                           bool
+	}{
+		{info.Frameworks[0], "1.5", "2019-08-06", "1.5.22.RELEASE", true},
+		{info.Frameworks[1], "1", "", "", true},
+		// 支持截止日期当天仍在支持
+		{info.Frameworks[2], "3.2", "2024-12-31", "3.2.12", false},
+		{info.Frameworks[3], "3.3", "", "", false},
+		// 数据中没有对应的周期
+		{info.Frameworks[4], "", "", "", false},
+		{info.Frameworks[5], "", "", "", false},
+		// 没有版本且全部周期已停止支持
+		{info.Components[0], "", "2021-12-31", "", true},
+	}
+	for _, tc := range testCases {
+		item := tc.item
+		if item.Cycle != tc.cycle || item.EOL != tc.eol || item.SupportedUntil != tc.supportedUntil || item.LatestInCycle != tc.latest {
+			t.Errorf("%s %s = cycle=%q eol=%v until=%q latest=%q, want cycle=%q eol=%v until=%q latest=%q",
+				item.Name, item.Version, item.Cycle, item.EOL, item.SupportedUntil, item.LatestInCycle,
+				tc.cycle, tc.eol, tc.supportedUntil, tc.latest)
+		}
+	}
+
+	New(testLifecycles).Annotate(info, now.AddDate(0, 0, 1))
+	if !info.Frameworks[2].EOL {
+		t.Errorf("Spring Boot 3.2.5 should be EOL after 2024-12-31: %+v", info.Frameworks[2])
+	}
+	if items := info.EndOfLifeItems(); len(items) != 4 {
+		t.Errorf("EndOfLifeItems() = %d items, want 4", len(items))
+	}
+}
+
+// TestAnnotateRangeVersions tests that range lower bounds are treated as unknown versions
+func TestAnnotateRangeVersions(t *testing.T) {
+	rangeInfo := func(version, raw string) *camodels.VersionInfo {
+		return &camodels.VersionInfo{Version: version, Raw: raw, Source: camodels.VersionSourceRange}
+	}
+	resolved := &camodels.VersionInfo{Version: "3.2.5", Source: camodels.VersionSourceResolved}
+	bootRange := rangeInfo("1.5", "[1.5,4.0)")
+	angularRange := rangeInfo("1.6.0", "^1.6.0")
+	info := &camodels.DetectionInfo{
+		Frameworks: []camodels.DetectedItem{
+			{Name: "Spring Boot", Version: "1.5", VersionInfo: bootRange, Versions: []*camodels.VersionInfo{bootRange}},
+			{Name: "Spring Boot", Version: "1.5", VersionInfo: bootRange, Versions: []*camodels.VersionInfo{bootRange, resolved}},
+		},
+		Components: []camodels.DetectedItem{
+			{Name: "AngularJS", Version: "1.6.0", VersionInfo: angularRange, Versions: []*camodels.VersionInfo{angularRange}},
+		},
+	}
+	New(testLifecycles).Annotate(info, time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC))
+
+	// 范围允许仍在支持的 3.3，不按下界 1.5 标记为 EOL
+	if boot := info.Frameworks[0]; boot.Cycle != "" || boot.EOL || boot.LatestInCycle != "" {
+		t.Errorf("Spring Boot with range = %+v, want unknown cycle and not EOL", boot)
+	}
+	if boot := info.Frameworks[1]; boot.Cycle != "3.2" || boot.EOL || boot.SupportedUntil != "2024-12-31" {
+		t.Errorf("Spring Boot with resolved 3.2.5 = %+v, want cycle 3.2", boot)
+	}
+	// 全部周期已停止支持时，版本未知也标记为 EOL
+	if angular := info.Components[0]; angular.Cycle != "" || !angular.EOL || angular.SupportedUntil != "2021-12-31" {
+		t.Errorf("AngularJS with range = %+v, want EOL without cycle", angular)
+	}
+}
+
+// TestLoadOverride tests that user lifecycle data replaces bundled entries with the same name
+func TestLoadOverride(t *testing.T) {
+	dir := t.TempDir()
+	content := `- name: spring boot
+  cycles:
+    - { cycle: "1.5", eol: "2030-01-01" }
+- name: Gin
+  cycles:
+    - { cycle: "1", eol: 2020-01-01 }
+`
+	if err := os.WriteFile(filepath.Join(dir, "custom.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	dataset := New(testLifecycles, overrides)
+	if len(dataset) != 3 {
+		t.Errorf("dataset has %d entries, want 3", len(dataset))
+	}
+
+	info := &camodels.DetectionInfo{Frameworks: []camodels.DetectedItem{
+		{Name: "Spring Boot", Version: "1.5.22"},
+		{Name: "Gin", Version: "1.9.1"},
+	}}
+	dataset.Annotate(info, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if boot := info.Frameworks[0]; boot.EOL || boot.SupportedUntil != "2030-01-01" || boot.LatestInCycle != "" {
+		t.Errorf("overridden Spring Boot = %+v", boot)
+	}
+	if gin := info.Frameworks[1]; !gin.EOL || gin.SupportedUntil != "2020-01-01" {
+		t.Errorf("custom Gin = %+v", gin)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yml")); err == nil {
+		t.Errorf("Load of missing file should fail")
+	}
+}
@@ -0,0 +1,225 @@
+package manifest
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// composerExactVersionRe 精确版本约束，例如 "5.4.0"、"v2.1"、"=1.0.0"
+var composerExactVersionRe = regexp.MustCompile(`^[=v]*\d+(?:\.\d+)*(?:-[\w.]+)?$`)
+
+// composerJSON composer.json 中与依赖相关的字段
+type composerJSON struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// composerPackage composer.lock / installed.json 中的包
+type composerPackage struct {
+	Name    string          `json:"name"`
+	Version string          `json:"version"`
+	License json.RawMessage `json:"license"`
+}
+
+// composerResolved 已锁定或已安装的包
+type composerResolved struct {
+	version string
+	scope   string
+	license string
+}
+
+// IsComposerManifest 判断文件是否为支持的 Composer 文件（composer.json、composer.lock、vendor/composer/installed.json）
+func IsComposerManifest(file string) bool {
+	switch path.Base(file) {
+	case "composer.json", "composer.lock":
+		return !isVendorPath(file)
+	case "installed.json":
+		return strings.HasSuffix(file, "vendor/composer/installed.json")
+	}
+	return false
+}
+
+// ParseComposerProject 解析 composer.json 的 require 和 require-dev，实际版本优先取自
+// vendor/composer/installed.json（已安装），其次为同目录的 composer.lock；
+// 已安装或已锁定但未在 composer.json 中声明的包（间接依赖）以对应文件作为来源输出。
+// php、ext-*、lib-* 等平台依赖会被忽略
+func ParseComposerProject(files []string, read ReadFunc) []camodels.Dependency {
+	type resolvedPackages struct {
+		file     string
+		packages map[string]composerResolved
+	}
+	// 项目目录 -> 按优先级排列的已解析包（installed.json 在前）
+	resolved := make(map[string][]resolvedPackages)
+	var dirs []string
+	for _, name := range []string{"installed.json", "composer.lock"} {
+		for _, file := range files {
+			if path.Base(file) != name || !IsComposerManifest(file) {
+				continue
+			}
+			content, err := read(file)
+			if err != nil {
+				continue
+			}
+			dir := path.Dir(file)
+			if name == "installed.json" {
+				dir = path.Dir(path.Dir(path.Dir(file)))
+			}
+			packages := parseComposerPackages(file, content)
+			if len(packages) == 0 {
+				continue
+			}
+			if resolved[dir] == nil {
+				dirs = append(dirs, dir)
+			}
+			resolved[dir] = append(resolved[dir], resolvedPackages{file: file, packages: packages})
+		}
+	}
+	lookup := func(dir, name string) (composerResolved, bool) {
+		for _, packages := range resolved[dir] {
+			if pkg, ok := packages.packages[name]; ok {
+				return pkg, true
+			}
+		}
+		return composerResolved{}, false
+	}
+
+	var result []camodels.Dependency
+	declared := make(map[string]bool) // dir/name
+	for _, file := range files {
+		if path.Base(file) != "composer.json" || !IsComposerManifest(file) {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		var composer composerJSON
+		if err := json.Unmarshal(content, &composer); err != nil {
+			slogs.Debugf("parse composer.json (%s) error: %v", file, err)
+			continue
+		}
+		dir := path.Dir(file)
+		for _, group := range []struct {
+			require map[string]string
+			scope   string
+		}{{composer.Require, "prod"}, {composer.RequireDev, "dev"}} {
+			for _, name := range sortedKeys(group.require) {
+				if isComposerPlatformPackage(name) {
+					continue
+				}
+				spec := strings.TrimSpace(group.require[name])
+				name = strings.ToLower(name)
+				dependency := camodels.Dependency{
+					Ecosystem: camodels.EcosystemComposer,
+					Name:      name,
+					Raw:       spec,
+					Scope:     group.scope,
+					File:      file,
+				}
+				if pkg, ok := lookup(dir, name); ok {
+					dependency.Version = pkg.version
+					dependency.Source = camodels.VersionSourceResolved
+					dependency.License = pkg.license
+				} else {
+					dependency.Version, dependency.Source = classifyComposerSpec(spec)
+				}
+				declared[dir+"/"+name] = true
+				result = append(result, dependency)
+			}
+		}
+	}
+
+	for _, dir := range dirs {
+		for _, packages := range resolved[dir] {
+			for _, name := range sortedKeys(packages.packages) {
+				if declared[dir+"/"+name] {
+					continue
+				}
+				declared[dir+"/"+name] = true
+				pkg := packages.packages[name]
+				result = append(result, camodels.Dependency{
+					Ecosystem: camodels.EcosystemComposer,
+					Name:      name,
+					Version:   pkg.version,
+					Source:    camodels.VersionSourceResolved,
+					Scope:     pkg.scope,
+					File:      packages.file,
+					License:   pkg.license,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// parseComposerPackages 解析 composer.lock（packages / packages-dev）或 installed.json
+// （Composer 1 为包数组，Composer 2 为 {"packages": [...], "dev-package-names": [...]}），
+// 返回小写包名到已解析包（版本、依赖范围和许可证）的映射；dev-* 等分支版本会被忽略
+func parseComposerPackages(file string, content []byte) map[string]composerResolved {
+	var lock struct {
+		Packages        []composerPackage `json:"packages"`
+		PackagesDev     []composerPackage `json:"packages-dev"`
+		DevPackageNames []string          `json:"dev-package-names"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		if err := json.Unmarshal(content, &lock.Packages); err != nil {
+			slogs.Debugf("parse composer packages (%s) error: %v", file, err)
+			return nil
+		}
+	}
+	devNames := make(map[string]bool)
+	for _, name := range lock.DevPackageNames {
+		devNames[strings.ToLower(name)] = true
+	}
+
+	packages := make(map[string]composerResolved)
+	for _, group := range []struct {
+		packages []composerPackage
+		scope    string
+	}{{lock.Packages, "prod"}, {lock.PackagesDev, "dev"}} {
+		for _, pkg := range group.packages {
+			name := strings.ToLower(pkg.Name)
+			version := strings.TrimPrefix(pkg.Version, "v")
+			if name == "" || !isNPMVersion(version) {
+				continue
+			}
+			resolved := composerResolved{version: version, scope: group.scope, license: parseLicense(pkg.License)}
+			if devNames[name] {
+				resolved.scope = "dev"
+			}
+			packages[name] = resolved
+		}
+	}
+	return packages
+}
+
+// isComposerPlatformPackage 判断是否为平台依赖（PHP 版本、扩展、系统库和 Composer 自身）
+func isComposerPlatformPackage(name string) bool {
+	name = strings.ToLower(name)
+	return name == "php" || name == "php-64bit" || name == "hhvm" || name == "composer" ||
+		name == "composer-plugin-api" || name == "composer-runtime-api" ||
+		strings.HasPrefix(name, "ext-") || strings.HasPrefix(name, "lib-") || !strings.Contains(name, "/")
+}
+
+// classifyComposerSpec 根据版本约束判断来源：精确版本为 pinned，范围取下界视为 range，
+// dev-* 分支、* 等无法确定版本的约束返回空版本；稳定性标记（@dev、@stable）会被忽略
+func classifyComposerSpec(spec string) (string, string) {
+	spec, _, _ = strings.Cut(spec, "@")
+	spec = strings.TrimSpace(spec)
+	if spec == "" || strings.HasPrefix(spec, "dev-") {
+		return "", ""
+	}
+	if composerExactVersionRe.MatchString(spec) {
+		return strings.TrimLeft(spec, "=v"), camodels.VersionSourcePinned
+	}
+	if version := LowerBound(spec); version != "" {
+		return version, camodels.VersionSourceRange
+	}
+	return "", ""
+}
@@ -0,0 +1,95 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestParseComposerProject tests composer.json constraints resolved from composer.lock and vendor/composer/installed.json
+func TestParseComposerProject(t *testing.T) {
+	files := map[string]string{
+		"composer.json": `{"require": {"php": "^8.1", "ext-json": "*", "Laravel/Framework": "^10.10", "guzzlehttp/guzzle": "^7.2"},
+			"require-dev": {"phpunit/phpunit": "^10.1"}}`,
+		"composer.lock": `{"packages": [
+			{"name": "laravel/framework", "version": "v10.48.4", "license": ["MIT"]},
+			{"name": "guzzlehttp/guzzle", "version": "7.8.1"},
+			{"name": "monolog/monolog", "version": "3.5.0"}],
+			"packages-dev": [{"name": "phpunit/phpunit", "version": "10.5.13"}]}`,
+		// Composer 2 installed.json 优先于 composer.lock
+		"vendor/composer/installed.json": `{"packages": [
+			{"name": "guzzlehttp/guzzle", "version": "7.8.2", "version_normalized": "7.8.2.0"},
+			{"name": "symfony/console", "version": "v6.4.4", "license": ["MIT", "Apache-2.0"]},
+			{"name": "phpunit/phpunit", "version": "10.5.13"}],
+			"dev": true, "dev-package-names": ["phpunit/phpunit"]}`,
+		"vendor/guzzlehttp/guzzle/composer.json": `{"require": {"psr/http-client": "^1.0"}}`,
+		// 没有锁文件的子项目，Composer 1 installed.json
+		"legacy/composer.json":                  `{"require": {"topthink/framework": "5.1.41", "topthink/think-orm": "~2.0@dev", "foo/bar": "dev-master"}}`,
+		"legacy/vendor/composer/installed.json": `[{"name": "topthink/think-orm", "version": "v2.0.61"}]`,
+	}
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	dependencies := ParseComposerProject(paths, mapReader(files))
+
+	testCases := []struct {
+		name, file, version, raw, source, scope string
+	}{
+		{"laravel/framework", "composer.json", "10.48.4", "^10.10", camodels.VersionSourceResolved, "prod"},
+		{"guzzlehttp/guzzle", "composer.json", "7.8.2", "^7.2", camodels.VersionSourceResolved, "prod"},
+		{"phpunit/phpunit", "composer.json", "10.5.13", "^10.1", camodels.VersionSourceResolved, "dev"},
+		{"symfony/console", "vendor/composer/installed.json", "6.4.4", "", camodels.VersionSourceResolved, "prod"},
+		{"monolog/monolog", "composer.lock", "3.5.0", "", camodels.VersionSourceResolved, "prod"},
+		{"topthink/framework", "legacy/composer.json", "5.1.41", "5.1.41", camodels.VersionSourcePinned, "prod"},
+		{"topthink/think-orm", "legacy/composer.json", "2.0.61", "~2.0@dev", camodels.VersionSourceResolved, "prod"},
+		{"foo/bar", "legacy/composer.json", "", "dev-master", "", "prod"},
+	}
+	for _, tc := range testCases {
+		dependency := findDependency(dependencies, tc.name, tc.file)
+		if dependency == nil {
+			t.Errorf("dependency %s in %s not found", tc.name, tc.file)
+			continue
+		}
+		if dependency.Version != tc.version || dependency.Raw != tc.raw || dependency.Source != tc.source ||
+			dependency.Scope != tc.scope || dependency.Ecosystem != camodels.EcosystemComposer {
+			t.Errorf("dependency %s = %+v, want version=%q raw=%q source=%q scope=%q", tc.name, *dependency, tc.version, tc.raw, tc.source, tc.scope)
+		}
+	}
+
+	for _, dependency := range dependencies {
+		switch {
+		case dependency.Name == "php", dependency.Name == "ext-json", dependency.Name == "psr/http-client":
+			t.Errorf("unexpected dependency reported: %+v", dependency)
+		case dependency.Name == "guzzlehttp/guzzle" && dependency.File != "composer.json":
+			t.Errorf("declared dependency reported again: %+v", dependency)
+		}
+	}
+	for name, license := range map[string]string{"laravel/framework": "MIT", "symfony/console": "MIT OR Apache-2.0", "monolog/monolog": ""} {
+		for _, dependency := range dependencies {
+			if dependency.Name == name && dependency.License != license {
+				t.Errorf("dependency %s license = %q, want %q", name, dependency.License, license)
+			}
+		}
+	}
+}
+
+// TestClassifyComposerSpec tests declared constraints without installed packages
+func TestClassifyComposerSpec(t *testing.T) {
+	testCases := []struct {
+		spec, version, source string
+	}{
+		{"5.4.0", "5.4.0", camodels.VersionSourcePinned},
+		{"v2.1", "2.1", camodels.VersionSourcePinned},
+		{"^8.0|^9.0", "8.0", camodels.VersionSourceRange},
+		{">=7.4 <8.0", "7.4", camodels.VersionSourceRange},
+		{"1.0.*@beta", "1.0", camodels.VersionSourceRange},
+		{"dev-main", "", ""},
+		{"*", "", ""},
+	}
+	for _, tc := range testCases {
+		if version, source := classifyComposerSpec(tc.spec); version != tc.version || source != tc.source {
+			t.Errorf("classifyComposerSpec(%q) = %q, %q, want %q, %q", tc.spec, version, source, tc.version, tc.source)
+		}
+	}
+}
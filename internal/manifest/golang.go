@@ -0,0 +1,317 @@
+package manifest
+
+import (
+	"path"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// goModule go.mod 中的模块声明
+type goModule struct {
+	file     string              // go.mod 相对路径
+	path     string              // module 路径
+	requires []goRequire         // require 列表，保持声明顺序
+	replaces map[string]goTarget // 被替换模块（path 或 path@version）-> 替换目标
+}
+
+// goRequire require 条目
+type goRequire struct {
+	path     string
+	version  string // 声明的版本，例如 v1.9.1、v0.0.0-20230101120000-abcdef123456
+	indirect bool
+}
+
+// goTarget replace 的目标，本地路径替换时 version 为空
+type goTarget struct {
+	path    string
+	version string
+}
+
+// IsGoManifest 判断文件是否为支持的 Go 模块文件（go.mod、go.work、vendor/modules.txt）
+func IsGoManifest(file string) bool {
+	switch path.Base(file) {
+	case "go.mod", "go.work":
+		return true
+	case "modules.txt":
+		return path.Base(path.Dir(file)) == "vendor"
+	}
+	return false
+}
+
+// ParseGoProject 解析 go.mod 中的 require，按 replace 计算实际生效的版本（go.work 中的 replace 优先），
+// 依赖范围区分 direct 和 indirect；go.work use 的工作区模块之间的引用不输出。
+// 存在 vendor/modules.txt 时以其中记录的版本为准，并补充 go.mod 中未列出的间接依赖
+func ParseGoProject(files []string, read ReadFunc) []camodels.Dependency {
+	modules := make(map[string]*goModule) // go.mod 目录 -> 模块
+	var dirs []string
+	for _, file := range files {
+		if path.Base(file) != "go.mod" || isVendorPath(file) {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		module := parseGoMod(file, content)
+		modules[path.Dir(file)] = module
+		dirs = append(dirs, path.Dir(file))
+	}
+
+	// go.work：工作区模块和全局 replace
+	workspaceReplaces := make(map[string]map[string]goTarget) // 模块目录 -> go.work 中的 replace
+	workspaceModules := make(map[string]map[string]bool)      // 模块目录 -> 工作区全部模块路径
+	for _, file := range files {
+		if path.Base(file) != "go.work" || isVendorPath(file) {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		uses, replaces := parseGoWork(content)
+		members := make(map[string]bool)
+		var useDirs []string
+		for _, use := range uses {
+			dir := path.Join(path.Dir(file), use)
+			if module, ok := modules[dir]; ok {
+				members[module.path] = true
+				useDirs = append(useDirs, dir)
+			}
+		}
+		for _, dir := range useDirs {
+			workspaceModules[dir] = members
+			workspaceReplaces[dir] = replaces
+		}
+	}
+
+	fileSet := make(map[string]bool, len(files))
+	for _, file := range files {
+		fileSet[file] = true
+	}
+
+	var result []camodels.Dependency
+	for _, dir := range dirs {
+		module := modules[dir]
+		vendored := make(map[string]goVendorModule)
+		vendorFile := path.Join(dir, "vendor", "modules.txt")
+		if fileSet[vendorFile] {
+			if content, err := read(vendorFile); err == nil {
+				vendored = parseVendorModules(content)
+			}
+		}
+
+		listed := make(map[string]bool)
+		for _, require := range module.requires {
+			listed[require.path] = true
+			if workspaceModules[dir][require.path] {
+				continue
+			}
+			dependency := camodels.Dependency{
+				Ecosystem: camodels.EcosystemGo,
+				Name:      require.path,
+				Raw:       require.version,
+				Scope:     goScope(require.indirect),
+				File:      module.file,
+			}
+			target, replaced := lookupGoReplace(workspaceReplaces[dir], require)
+			if !replaced {
+				target, replaced = lookupGoReplace(module.replaces, require)
+			}
+			switch {
+			case vendored[require.path].version != "":
+				dependency.Version = strings.TrimPrefix(vendored[require.path].version, "v")
+				dependency.Source = camodels.VersionSourceResolved
+			case replaced && target.version == "":
+				// 替换为本地目录，无法确定版本
+			case replaced:
+				dependency.Version = strings.TrimPrefix(target.version, "v")
+				dependency.Source = camodels.VersionSourceResolved
+			default:
+				dependency.Version = strings.TrimPrefix(require.version, "v")
+				dependency.Source = camodels.VersionSourcePinned
+			}
+			result = append(result, dependency)
+		}
+
+		// vendor/modules.txt 中存在而 go.mod 未列出的模块（Go 1.17 之前不记录全部间接依赖）
+		for _, name := range sortedKeys(vendored) {
+			vendor := vendored[name]
+			if listed[name] || vendor.version == "" {
+				continue
+			}
+			result = append(result, camodels.Dependency{
+				Ecosystem: camodels.EcosystemGo,
+				Name:      name,
+				Version:   strings.TrimPrefix(vendor.version, "v"),
+				Raw:       vendor.declared,
+				Source:    camodels.VersionSourceResolved,
+				Scope:     goScope(!vendor.explicit),
+				File:      vendorFile,
+			})
+		}
+	}
+	return result
+}
+
+// goScope 返回 require 的依赖范围
+func goScope(indirect bool) string {
+	if indirect {
+		return "indirect"
+	}
+	return "direct"
+}
+
+// isVendorPath 判断路径是否位于 vendor 目录中（vendor/modules.txt 本身除外）
+func isVendorPath(file string) bool {
+	return strings.HasPrefix(file, "vendor/") || strings.Contains(file, "/vendor/")
+}
+
+// lookupGoReplace 查找 require 对应的 replace，指定版本的替换优先于不带版本的替换
+func lookupGoReplace(replaces map[string]goTarget, require goRequire) (goTarget, bool) {
+	if target, ok := replaces[require.path+"@"+require.version]; ok {
+		return target, true
+	}
+	target, ok := replaces[require.path]
+	return target, ok
+}
+
+// goDirectives 按指令名称遍历 go.mod / go.work 的内容，支持单行写法和括号块写法，
+// 回调参数为去除行尾注释后的字段和注释内容
+func goDirectives(content []byte, handle func(directive string, fields []string, comment string)) {
+	block := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		line, comment, _ := strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		comment = strings.TrimSpace(comment)
+		if block != "" {
+			if len(fields) == 1 && fields[0] == ")" {
+				block = ""
+				continue
+			}
+			if len(fields) > 0 {
+				handle(block, fields, comment)
+			}
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		handle(fields[0], fields[1:], comment)
+	}
+}
+
+// parseGoMod 解析 go.mod 的 module、require 和 replace 指令
+func parseGoMod(file string, content []byte) *goModule {
+	module := &goModule{file: file, replaces: make(map[string]goTarget)}
+	goDirectives(content, func(directive string, fields []string, comment string) {
+		switch directive {
+		case "module":
+			if len(fields) > 0 {
+				module.path = strings.Trim(fields[0], `"`)
+			}
+		case "require":
+			if len(fields) >= 2 {
+				module.requires = append(module.requires, goRequire{
+					path:     strings.Trim(fields[0], `"`),
+					version:  fields[1],
+					indirect: strings.HasPrefix(comment, "indirect"),
+				})
+			}
+		case "replace":
+			addGoReplace(module.replaces, fields)
+		}
+	})
+	return module
+}
+
+// parseGoWork 解析 go.work 的 use 目录和 replace 指令
+func parseGoWork(content []byte) ([]string, map[string]goTarget) {
+	var uses []string
+	replaces := make(map[string]goTarget)
+	goDirectives(content, func(directive string, fields []string, _ string) {
+		switch directive {
+		case "use":
+			if len(fields) > 0 {
+				uses = append(uses, strings.Trim(fields[0], `"`))
+			}
+		case "replace":
+			addGoReplace(replaces, fields)
+		}
+	})
+	return uses, replaces
+}
+
+// addGoReplace 解析 replace 指令字段：old [version] => new [version]
+func addGoReplace(replaces map[string]goTarget, fields []string) {
+	arrow := -1
+	for i, field := range fields {
+		if field == "=>" {
+			arrow = i
+		}
+	}
+	if arrow < 1 || arrow == len(fields)-1 {
+		return
+	}
+	key := strings.Trim(fields[0], `"`)
+	if arrow == 2 {
+		key += "@" + fields[1]
+	}
+	target := goTarget{path: strings.Trim(fields[arrow+1], `"`)}
+	if arrow+2 < len(fields) {
+		target.version = fields[arrow+2]
+	}
+	replaces[key] = target
+}
+
+// goVendorModule vendor/modules.txt 中记录的模块
+type goVendorModule struct {
+	declared string // 声明的版本
+	version  string // 实际使用的版本（存在替换时为替换目标的版本，本地替换为空）
+	explicit bool   // 是否在 go.mod 中显式声明（## explicit）
+}
+
+// parseVendorModules 解析 vendor/modules.txt：
+// "# path version [=> replacement [version]]" 为模块行，"## explicit" 表示 go.mod 中显式声明
+func parseVendorModules(content []byte) map[string]goVendorModule {
+	modules := make(map[string]goVendorModule)
+	current := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "## ") {
+			if module, ok := modules[current]; ok && strings.HasPrefix(strings.TrimPrefix(line, "## "), "explicit") {
+				module.explicit = true
+				modules[current] = module
+			}
+			continue
+		}
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "# "))
+		current = ""
+		if len(fields) < 2 {
+			continue
+		}
+		module := goVendorModule{}
+		// "# path => replacement" 形式的行只记录替换，不对应 require
+		if fields[1] == "=>" {
+			continue
+		}
+		module.declared = fields[1]
+		module.version = fields[1]
+		if len(fields) >= 4 && fields[2] == "=>" {
+			module.version = ""
+			if len(fields) >= 5 {
+				module.version = fields[4]
+			}
+		}
+		current = fields[0]
+		modules[current] = module
+	}
+	return modules
+}
@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestParseGoProject tests require/replace directives, indirect markers, go.work workspaces and vendor/modules.txt
+func TestParseGoProject(t *testing.T) {
+	files := map[string]string{
+		"go.work": `go 1.21
+
+use (
+	./api
+	./shared
+)
+
+replace github.com/labstack/echo/v4 v4.10.0 => github.com/labstack/echo/v4 v4.11.4`,
+		"api/go.mod": `module example.com/api
+
+go 1.21
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/labstack/echo/v4 v4.10.0
+	example.com/shared v0.0.0-00010101000000-000000000000
+	golang.org/x/net v0.0.0-20230224155838-3ccf5e67e4b1 // indirect
+	github.com/example/forked v1.0.0
+	github.com/example/local v1.2.0 // indirect; comment
+)
+
+replace (
+	github.com/gin-gonic/gin => github.com/gin-gonic/gin v1.9.2-rc.1
+	github.com/example/forked v1.0.0 => github.com/someone/forked v1.0.1
+	github.com/example/local => ../local
+)`,
+		"shared/go.mod": `module example.com/shared
+
+require google.golang.org/grpc v1.59.0 // indirect`,
+		"legacy/go.mod": `module example.com/legacy
+
+require github.com/gofiber/fiber/v2 v2.48.0`,
+		"legacy/vendor/modules.txt": `# github.com/gofiber/fiber/v2 v2.48.0 => github.com/gofiber/fiber/v2 v2.49.0
+## explicit; go 1.20
+github.com/gofiber/fiber/v2
+# github.com/valyala/fasthttp v1.48.0
+github.com/valyala/fasthttp
+# github.com/gofiber/fiber/v2 => github.com/gofiber/fiber/v2 v2.49.0`,
+		"legacy/vendor/github.com/valyala/fasthttp/go.mod": `module github.com/valyala/fasthttp`,
+	}
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	dependencies := ParseGoProject(paths, mapReader(files))
+
+	testCases := []struct {
+		name, file, version, raw, source, scope string
+	}{
+		{"github.com/gin-gonic/gin", "api/go.mod", "1.9.2-rc.1", "v1.9.1", camodels.VersionSourceResolved, "direct"},
+		{"github.com/labstack/echo/v4", "api/go.mod", "4.11.4", "v4.10.0", camodels.VersionSourceResolved, "direct"},
+		{"golang.org/x/net", "api/go.mod", "0.0.0-20230224155838-3ccf5e67e4b1", "v0.0.0-20230224155838-3ccf5e67e4b1", camodels.VersionSourcePinned, "indirect"},
+		{"github.com/example/forked", "api/go.mod", "1.0.1", "v1.0.0", camodels.VersionSourceResolved, "direct"},
+		{"github.com/example/local", "api/go.mod", "", "v1.2.0", "", "indirect"},
+		{"google.golang.org/grpc", "shared/go.mod", "1.59.0", "v1.59.0", camodels.VersionSourcePinned, "indirect"},
+		{"github.com/gofiber/fiber/v2", "legacy/go.mod", "2.49.0", "v2.48.0", camodels.VersionSourceResolved, "direct"},
+		{"github.com/valyala/fasthttp", "legacy/vendor/modules.txt", "1.48.0", "v1.48.0", camodels.VersionSourceResolved, "indirect"},
+	}
+	for _, tc := range testCases {
+		dependency := findDependency(dependencies, tc.name, tc.file)
+		if dependency == nil {
+			t.Errorf("dependency %s in %s not found", tc.name, tc.file)
+			continue
+		}
+		if dependency.Version != tc.version || dependency.Raw != tc.raw || dependency.Source != tc.source ||
+			dependency.Scope != tc.scope || dependency.Ecosystem != camodels.EcosystemGo {
+			t.Errorf("dependency %s = %+v, want version=%q raw=%q source=%q scope=%q", tc.name, *dependency, tc.version, tc.raw, tc.source, tc.scope)
+		}
+	}
+
+	for _, dependency := range dependencies {
+		if dependency.Name == "example.com/shared" {
+			t.Errorf("workspace module reported as dependency: %+v", dependency)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package manifest
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// Gradle 构建脚本中的依赖和变量写法（Groovy DSL 与 Kotlin DSL）
+var (
+	// gradleCommentRe 块注释和行注释（要求注释符号前为行首或空白，避免误删 URL 和 **/*.java 等通配符）
+	gradleCommentRe = regexp.MustCompile(`(^|\s)/\*(?s:.*?)\*/|(?m:(^|\s)//.*$)`)
+	// gradleStringDepRe 字符串写法：implementation 'g:a:v'、implementation(platform("g:a:${property("v")}"))
+	gradleStringDepRe = regexp.MustCompile(`\b([A-Za-z]\w*)\s*\(?\s*(?:(?:platform|enforcedPlatform)\s*\(\s*)?["']((?:\$\{[^}]*\}|[^"'\s$]|\$)+)["']`)
+	// gradleMapDepRe Map 写法：implementation group: 'g', name: 'a', version: 'v'（Kotlin 使用 =）
+	gradleMapDepRe = regexp.MustCompile(`\b([A-Za-z]\w*)\s*\(?\s*group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["'](?:\s*,\s*version\s*[:=]\s*["']([^"']+)["'])?`)
+	// gradleCatalogDepRe 版本目录引用：implementation(libs.spring.boot.starter)、implementation libs.bundles.jackson
+	gradleCatalogDepRe = regexp.MustCompile(`\b([A-Za-z]\w*)\s*\(?\s*(?:(?:platform|enforcedPlatform)\s*\(\s*)?([A-Za-z]\w*)\.([A-Za-z][\w.]*?)(?:\.get\(\))?\s*\)*\s*$`)
+	// gradlePluginRe 插件声明：id 'org.springframework.boot' version '3.1.0'、id("x") version "1.0"
+	gradlePluginRe = regexp.MustCompile(`\bid\s*\(?\s*["']([\w.-]+)["']\s*\)?(?:\s*version\s*\(?\s*["']([^"']+)["']\s*\)?)?`)
+	// gradleKotlinPluginRe Kotlin 插件简写：kotlin("jvm") version "1.9.0"
+	gradleKotlinPluginRe = regexp.MustCompile(`\bkotlin\s*\(\s*["']([\w.-]+)["']\s*\)(?:\s*version\s*["']([^"']+)["'])?`)
+	// gradleCatalogPluginRe 版本目录插件：alias(libs.plugins.spring.boot)
+	gradleCatalogPluginRe = regexp.MustCompile(`\balias\s*\(\s*([A-Za-z]\w*)\.plugins\.([\w.]+)\s*\)`)
+
+	// gradleVarRes 变量定义，第一个捕获组为名称，第二个为值
+	gradleVarRes = []*regexp.Regexp{
+		regexp.MustCompile(`\b(?:def|val|var|String)\s+(\w+)\s*=\s*["']([^"'$]+)["']`),
+		regexp.MustCompile(`\b(?:project\.|rootProject\.)?ext\.([\w.]+)\s*=\s*["']([^"'$]+)["']`),
+		regexp.MustCompile(`\b(?:ext|extra)\s*\[\s*["']([\w.-]+)["']\s*\]\s*=\s*["']([^"'$]+)["']`),
+		regexp.MustCompile(`\b(?:ext|extra)\.set\(\s*["']([\w.-]+)["']\s*,\s*["']([^"'$]+)["']\s*\)`),
+		regexp.MustCompile(`\bval\s+(\w+)\s+by\s+extra\(\s*["']([^"'$]+)["']\s*\)`),
+	}
+	// gradleExtBlockRe ext { ... } 块的起始位置
+	gradleExtBlockRe = regexp.MustCompile(`\bext\s*\{`)
+	// gradleBlockAssignRe ext 块中的赋值：springVersion = '5.3.20'
+	gradleBlockAssignRe = regexp.MustCompile(`(?m)^\s*(?:set\(\s*["'])?([\w.]+)["']?\s*(?:=|,)\s*["']([^"'$]+)["']`)
+	// gradleBlockMapRe ext 块中的 Map：versions = [spring: '5.3.20', jackson: '2.13.0']
+	gradleBlockMapRe = regexp.MustCompile(`(?s)\b(\w+)\s*=\s*\[(.*?)\]`)
+	// gradleMapEntryRe Map 条目：spring: '5.3.20'
+	gradleMapEntryRe = regexp.MustCompile(`["']?([\w.-]+)["']?\s*:\s*["']([^"'$]+)["']`)
+
+	// gradleIncludeRe settings 中的 include 语句
+	gradleIncludeRe = regexp.MustCompile(`(?m)^\s*include\s*\(?([^)\n]+)\)?`)
+	// gradleProjectDirRe settings 中自定义的项目目录：project(':app').projectDir = file('modules/app')
+	gradleProjectDirRe = regexp.MustCompile(`project\(\s*["']([^"']+)["']\s*\)\.projectDir\s*=\s*(?:file|new\s+File)\s*\(\s*(?:\w+\s*,\s*)?["']([^"']+)["']`)
+	// gradleQuotedRe 引号中的字符串
+	gradleQuotedRe = regexp.MustCompile(`["']([^"']+)["']`)
+	// gradleInterpolationRe 字符串插值：${expr} 或 $name
+	gradleInterpolationRe = regexp.MustCompile(`\$\{([^}]+)\}|\$([A-Za-z_][\w.]*)`)
+	// gradlePropertyCallRe 插值中的属性访问：property("x")、findProperty("x")、extra["x"]
+	gradlePropertyCallRe = regexp.MustCompile(`(?:property|findProperty|extra|ext|get)\s*[(\[]\s*["']([^"']+)["']\s*[)\]]`)
+)
+
+// gradleConfigurationSuffixes 依赖配置名称（小写）的常见后缀，用于排除非依赖的方法调用
+var gradleConfigurationSuffixes = []string{
+	"implementation", "api", "compile", "compileonly", "runtime", "runtimeonly",
+	"annotationprocessor", "classpath", "kapt", "ksp", "developmentonly", "providedcompile", "providedruntime",
+}
+
+// isGradleConfiguration 判断名称是否为依赖配置，例如 implementation、testImplementation、kapt
+func isGradleConfiguration(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range gradleConfigurationSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// gradleBuild Gradle 多项目构建中的文件分布
+type gradleBuild struct {
+	scripts    map[string][]string          // 目录 -> 构建脚本
+	properties map[string]map[string]string // 目录 -> gradle.properties
+	catalogs   map[string][]*gradleCatalog  // 根目录 -> 版本目录
+	roots      map[string]string            // settings 中声明的项目目录 -> 根目录
+	settings   map[string]bool              // 包含 settings.gradle(.kts) 的目录
+	variables  map[string]map[string]string // 构建脚本 -> 脚本中定义的变量
+}
+
+// ParseGradleProject 解析 Gradle 构建：build.gradle(.kts) 中的依赖和插件，
+// gradle.properties 与 ext/extra 中定义的版本变量（子项目继承上级目录），
+// settings.gradle(.kts) 中的多项目布局，以及 gradle/*.versions.toml 版本目录。
+func ParseGradleProject(files []string, read ReadFunc) []camodels.Dependency {
+	build := &gradleBuild{
+		scripts:    make(map[string][]string),
+		properties: make(map[string]map[string]string),
+		catalogs:   make(map[string][]*gradleCatalog),
+		roots:      make(map[string]string),
+		settings:   make(map[string]bool),
+		variables:  make(map[string]map[string]string),
+	}
+	contents := make(map[string]string)
+	var result []camodels.Dependency
+
+	for _, file := range files {
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		dir := path.Dir(file)
+		name := strings.ToLower(path.Base(file))
+		switch {
+		case name == "build.gradle" || name == "build.gradle.kts":
+			text := gradleCommentRe.ReplaceAllString(string(content), "$1$2")
+			contents[file] = text
+			build.scripts[dir] = append(build.scripts[dir], file)
+			build.variables[file] = parseGradleVariables(text)
+		case name == "gradle.properties":
+			build.properties[dir] = parseProperties(string(content))
+		case name == "settings.gradle" || name == "settings.gradle.kts":
+			build.settings[dir] = true
+			build.parseSettings(dir, gradleCommentRe.ReplaceAllString(string(content), "$1$2"))
+		case strings.HasSuffix(name, ".versions.toml") && path.Base(dir) == "gradle":
+			catalog := parseGradleCatalog(file, content)
+			build.catalogs[path.Dir(dir)] = append(build.catalogs[path.Dir(dir)], catalog)
+			result = append(result, catalog.dependencies()...)
+		}
+	}
+
+	for _, dir := range sortedKeys(build.scripts) {
+		for _, file := range build.scripts[dir] {
+			root := build.rootFor(dir)
+			variables := build.variablesFor(dir, root)
+			result = append(result, build.scriptDependencies(file, contents[file], variables, build.catalogs[root])...)
+		}
+	}
+	return result
+}
+
+// parseSettings 解析 settings 中 include 的子项目及其目录
+func (b *gradleBuild) parseSettings(root, content string) {
+	for _, m := range gradleIncludeRe.FindAllStringSubmatch(content, -1) {
+		for _, q := range gradleQuotedRe.FindAllStringSubmatch(m[1], -1) {
+			projectDir := strings.ReplaceAll(strings.Trim(q[1], ":"), ":", "/")
+			b.roots[path.Join(root, projectDir)] = root
+		}
+	}
+	for _, m := range gradleProjectDirRe.FindAllStringSubmatch(content, -1) {
+		b.roots[path.Join(root, m[2])] = root
+	}
+}
+
+// rootFor 返回项目所属构建的根目录：settings 中声明的项目使用对应根目录，
+// 否则使用最近的包含 settings 或版本目录的上级目录，都没有时为项目自身目录
+func (b *gradleBuild) rootFor(dir string) string {
+	if root, ok := b.roots[dir]; ok {
+		return root
+	}
+	for current := dir; ; current = path.Dir(current) {
+		if b.settings[current] || len(b.catalogs[current]) > 0 {
+			return current
+		}
+		if current == "." || current == "/" {
+			return dir
+		}
+	}
+}
+
+// variablesFor 合并变量：从根目录到项目目录逐级叠加 gradle.properties 和构建脚本中的变量，越靠近项目优先级越高
+func (b *gradleBuild) variablesFor(dir, root string) map[string]string {
+	chain := []string{dir}
+	for current := dir; current != root && current != "." && current != "/"; {
+		current = path.Dir(current)
+		chain = append([]string{current}, chain...)
+	}
+	if chain[0] != root {
+		chain = append([]string{root}, chain...)
+	}
+
+	variables := make(map[string]string)
+	for _, d := range chain {
+		for k, v := range b.properties[d] {
+			variables[k] = v
+		}
+		for _, script := range b.scripts[d] {
+			for k, v := range b.variables[script] {
+				variables[k] = v
+			}
+		}
+	}
+	return variables
+}
+
+// scriptDependencies 解析单个构建脚本中声明的依赖和插件
+func (b *gradleBuild) scriptDependencies(file, content string, variables map[string]string, catalogs []*gradleCatalog) []camodels.Dependency {
+	var result []camodels.Dependency
+	add := func(name, raw, scope string) {
+		version, source := classifyMavenVersion(interpolateGradle(raw, variables, catalogs))
+		result = append(result, camodels.Dependency{
+			Ecosystem: camodels.EcosystemMaven,
+			Name:      interpolateGradle(name, variables, catalogs),
+			Version:   version,
+			Raw:       raw,
+			Source:    source,
+			Scope:     scope,
+			File:      file,
+		})
+	}
+	catalogByAccessor := make(map[string]*gradleCatalog)
+	for _, catalog := range catalogs {
+		catalogByAccessor[catalog.accessor] = catalog
+	}
+
+	for _, m := range gradleStringDepRe.FindAllStringSubmatch(content, -1) {
+		if !isGradleConfiguration(m[1]) || !strings.Contains(m[2], ":") {
+			continue
+		}
+		parts := strings.Split(m[2], ":")
+		raw := ""
+		if len(parts) > 2 {
+			raw = strings.SplitN(parts[2], "@", 2)[0]
+		}
+		add(parts[0]+":"+parts[1], raw, m[1])
+	}
+	for _, m := range gradleMapDepRe.FindAllStringSubmatch(content, -1) {
+		if isGradleConfiguration(m[1]) {
+			add(m[2]+":"+m[3], m[4], m[1])
+		}
+	}
+	for _, line := range strings.Split(content, "\n") {
+		m := gradleCatalogDepRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || !isGradleConfiguration(m[1]) {
+			continue
+		}
+		catalog, ok := catalogByAccessor[m[2]]
+		if !ok {
+			continue
+		}
+		alias := normalizeCatalogAlias(m[3])
+		var aliases []string
+		if bundle, isBundle := strings.CutPrefix(alias, "bundles."); isBundle {
+			aliases = catalog.bundles[bundle]
+		} else {
+			aliases = []string{alias}
+		}
+		for _, a := range aliases {
+			if lib, ok := catalog.libraries[a]; ok {
+				result = append(result, catalog.dependency(lib, m[1], file))
+			}
+		}
+	}
+
+	for _, m := range gradlePluginRe.FindAllStringSubmatch(content, -1) {
+		// 跳过 java、application 等 Gradle 内置插件
+		if !strings.Contains(m[1], ".") {
+			continue
+		}
+		add(m[1]+":"+m[1]+".gradle.plugin", m[2], "plugin")
+	}
+	for _, m := range gradleKotlinPluginRe.FindAllStringSubmatch(content, -1) {
+		id := "org.jetbrains.kotlin." + m[1]
+		add(id+":"+id+".gradle.plugin", m[2], "plugin")
+	}
+	for _, m := range gradleCatalogPluginRe.FindAllStringSubmatch(content, -1) {
+		if catalog, ok := catalogByAccessor[m[1]]; ok {
+			if lib, ok := catalog.plugins[normalizeCatalogAlias(m[2])]; ok {
+				result = append(result, catalog.dependency(lib, "plugin", file))
+			}
+		}
+	}
+	return result
+}
+
+// parseGradleVariables 提取构建脚本中定义的版本变量
+func parseGradleVariables(content string) map[string]string {
+	variables := make(map[string]string)
+	for _, re := range gradleVarRes {
+		for _, m := range re.FindAllStringSubmatch(content, -1) {
+			variables[m[1]] = m[2]
+		}
+	}
+	for _, loc := range gradleExtBlockRe.FindAllStringIndex(content, -1) {
+		block := bracedBlock(content[loc[1]-1:])
+		for _, m := range gradleBlockMapRe.FindAllStringSubmatch(block, -1) {
+			for _, entry := range gradleMapEntryRe.FindAllStringSubmatch(m[2], -1) {
+				variables[m[1]+"."+entry[1]] = entry[2]
+			}
+		}
+		for _, m := range gradleBlockAssignRe.FindAllStringSubmatch(block, -1) {
+			variables[m[1]] = m[2]
+		}
+	}
+	return variables
+}
+
+// bracedBlock 返回以 { 开头的代码块内容（不含外层括号），括号不匹配时返回剩余全部内容
+func bracedBlock(s string) string {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return s[1:i]
+			}
+		}
+	}
+	return strings.TrimPrefix(s, "{")
+}
+
+// interpolateGradle 替换 $name、${name}、${project.ext.name}、${property("name")}、${libs.versions.x.get()} 等插值
+func interpolateGradle(value string, variables map[string]string, catalogs []*gradleCatalog) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+	return gradleInterpolationRe.ReplaceAllStringFunc(value, func(expr string) string {
+		m := gradleInterpolationRe.FindStringSubmatch(expr)
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		name = strings.TrimSpace(name)
+		if call := gradlePropertyCallRe.FindStringSubmatch(name); call != nil {
+			name = call[1]
+		}
+		name = strings.TrimSuffix(name, ".get()")
+		for _, prefix := range []string{"rootProject.", "project.", "ext.", "extra."} {
+			name = strings.TrimPrefix(name, prefix)
+		}
+		if v, ok := variables[name]; ok {
+			return v
+		}
+		for _, catalog := range catalogs {
+			if ref, ok := strings.CutPrefix(name, catalog.accessor+".versions."); ok {
+				if v, ok := catalog.versions[normalizeCatalogAlias(ref)]; ok {
+					return v
+				}
+			}
+		}
+		return expr
+	})
+}
+
+// parseProperties 解析 .properties 文件（key=value 或 key: value）
+func parseProperties(content string) map[string]string {
+	properties := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		sep := strings.IndexAny(line, "=:")
+		if sep <= 0 {
+			continue
+		}
+		properties[strings.TrimSpace(line[:sep])] = strings.TrimSpace(line[sep+1:])
+	}
+	return properties
+}
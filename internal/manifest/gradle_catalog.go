@@ -0,0 +1,169 @@
+package manifest
+
+import (
+	"path"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// gradleCatalog Gradle 版本目录（gradle/libs.versions.toml 等）
+type gradleCatalog struct {
+	file      string                      // 目录文件相对路径
+	accessor  string                      // 构建脚本中的访问名，例如 libs
+	versions  map[string]string           // [versions] 别名 -> 版本
+	libraries map[string]gradleCatalogLib // [libraries] 规范化别名 -> 依赖
+	bundles   map[string][]string         // [bundles] 规范化别名 -> 依赖别名列表
+	plugins   map[string]gradleCatalogLib // [plugins] 规范化别名 -> 插件
+}
+
+// gradleCatalogLib 版本目录中的依赖或插件
+type gradleCatalogLib struct {
+	name    string // groupId:artifactId，插件为 id:id.gradle.plugin
+	version string // 已解析的版本，可能为空
+	raw     string // 声明的原始版本或版本引用，例如 "spring-boot"
+}
+
+// normalizeCatalogAlias 规范化版本目录别名，Gradle 将 - _ . 视为等价的分隔符
+func normalizeCatalogAlias(alias string) string {
+	alias = strings.Trim(strings.TrimSpace(alias), `"'`)
+	return strings.ToLower(strings.NewReplacer("-", ".", "_", ".").Replace(alias))
+}
+
+// catalogAccessor 根据文件名推断访问名，例如 gradle/libs.versions.toml -> libs
+func catalogAccessor(file string) string {
+	return strings.TrimSuffix(path.Base(file), ".versions.toml")
+}
+
+// parseGradleCatalog 解析版本目录
+func parseGradleCatalog(file string, content []byte) *gradleCatalog {
+	catalog := &gradleCatalog{
+		file:      file,
+		accessor:  catalogAccessor(file),
+		versions:  make(map[string]string),
+		libraries: make(map[string]gradleCatalogLib),
+		bundles:   make(map[string][]string),
+		plugins:   make(map[string]gradleCatalogLib),
+	}
+
+	sections := make(map[string][]tomlEntry)
+	for _, table := range parseTOMLTables(content) {
+		sections[table.name] = append(sections[table.name], table.entries...)
+	}
+
+	for _, e := range sections["versions"] {
+		catalog.versions[normalizeCatalogAlias(e.key)] = catalogVersion(e.value, nil)
+	}
+	for _, e := range sections["libraries"] {
+		if lib, ok := catalog.parseLibrary(e.value, false); ok {
+			catalog.libraries[normalizeCatalogAlias(e.key)] = lib
+		}
+	}
+	for _, e := range sections["plugins"] {
+		if lib, ok := catalog.parseLibrary(e.value, true); ok {
+			catalog.plugins[normalizeCatalogAlias(e.key)] = lib
+		}
+	}
+	for _, e := range sections["bundles"] {
+		var aliases []string
+		for _, item := range parseTOMLArray(e.value) {
+			aliases = append(aliases, normalizeCatalogAlias(item))
+		}
+		catalog.bundles[normalizeCatalogAlias(e.key)] = aliases
+	}
+	return catalog
+}
+
+// parseLibrary 解析 [libraries] 或 [plugins] 中的条目，支持字符串写法和内联表写法
+func (c *gradleCatalog) parseLibrary(value string, plugin bool) (gradleCatalogLib, bool) {
+	var lib gradleCatalogLib
+	if strings.HasPrefix(value, "{") {
+		table := parseInlineTable(value)
+		switch {
+		case plugin:
+			lib.name = table["id"]
+		case table["module"] != "":
+			lib.name = table["module"]
+		case table["group"] != "" && table["name"] != "":
+			lib.name = table["group"] + ":" + table["name"]
+		}
+		if ref := table["version.ref"]; ref != "" {
+			lib.raw = ref
+			lib.version = c.versions[normalizeCatalogAlias(ref)]
+		} else {
+			lib.raw = catalogVersion(table["version"], table)
+			lib.version = lib.raw
+		}
+	} else {
+		notation := unquote(value)
+		if plugin {
+			lib.name, lib.raw, _ = strings.Cut(notation, ":")
+		} else {
+			parts := strings.Split(notation, ":")
+			if len(parts) < 2 {
+				return lib, false
+			}
+			lib.name = parts[0] + ":" + parts[1]
+			if len(parts) > 2 {
+				lib.raw = parts[2]
+			}
+		}
+		lib.version = lib.raw
+	}
+	if lib.name == "" {
+		return lib, false
+	}
+	if plugin {
+		lib.name = lib.name + ":" + lib.name + ".gradle.plugin"
+	}
+	return lib, true
+}
+
+// dependencies 返回版本目录中声明的全部依赖和插件
+func (c *gradleCatalog) dependencies() []camodels.Dependency {
+	var result []camodels.Dependency
+	for _, group := range []struct {
+		libs  map[string]gradleCatalogLib
+		scope string
+	}{{c.libraries, ""}, {c.plugins, "plugin"}} {
+		for _, alias := range sortedKeys(group.libs) {
+			result = append(result, c.dependency(group.libs[alias], group.scope, c.file))
+		}
+	}
+	return result
+}
+
+// dependency 将版本目录条目转换为依赖
+func (c *gradleCatalog) dependency(lib gradleCatalogLib, scope, file string) camodels.Dependency {
+	version, source := classifyMavenVersion(lib.version)
+	return camodels.Dependency{
+		Ecosystem: camodels.EcosystemMaven,
+		Name:      lib.name,
+		Version:   version,
+		Raw:       lib.raw,
+		Source:    source,
+		Scope:     scope,
+		File:      file,
+	}
+}
+
+// catalogVersion 解析版本值：字符串，或 { strictly, require, prefer } 富版本（优先 prefer）
+func catalogVersion(value string, table map[string]string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "{") {
+		table = parseInlineTable(value)
+		value = ""
+	}
+	if value != "" {
+		return unquote(value)
+	}
+	for _, key := range []string{"prefer", "require", "strictly"} {
+		if v := table[key]; v != "" {
+			return v
+		}
+		if v := table["version."+key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
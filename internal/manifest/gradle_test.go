@@ -0,0 +1,112 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestParseGradleProject tests Groovy/Kotlin scripts, gradle.properties, settings layout and version catalogs
+func TestParseGradleProject(t *testing.T) {
+	files := map[string]string{
+		"settings.gradle.kts": `rootProject.name = "demo"
+include(":app", ":lib")
+project(":lib").projectDir = file("libs/core")`,
+		"gradle.properties": `log4jVersion=2.17.1
+org.gradle.jvmargs=-Xmx2g`,
+		"build.gradle": `// 根项目
+ext {
+    springVersion = '5.3.20'
+    versions = [jackson: '2.13.0']
+}
+/* 块注释 implementation 'commented:out:1.0' */`,
+		"gradle/libs.versions.toml": `[versions]
+spring-boot = "3.1.0"
+fastjson = { strictly = "[1.2,2.0)", prefer = "1.2.83" }
+
+[libraries]
+spring-boot-starter-web = { module = "org.springframework.boot:spring-boot-starter-web", version.ref = "spring-boot" }
+fastjson = { group = "com.alibaba", name = "fastjson", version.ref = "fastjson" }
+commons-io = "commons-io:commons-io:2.11.0" # 注释
+
+[bundles]
+web = [
+    "spring-boot-starter-web",
+    "commons-io",
+]
+
+[plugins]
+spring-boot = { id = "org.springframework.boot", version.ref = "spring-boot" }`,
+		"app/build.gradle.kts": `plugins {
+    java
+    alias(libs.plugins.spring.boot)
+    kotlin("jvm") version "1.9.0"
+}
+val junitVersion = "5.10.0"
+dependencies {
+    implementation(libs.spring.boot.starter.web)
+    implementation(libs.bundles.web)
+    implementation("org.apache.logging.log4j:log4j-core:${property("log4jVersion")}")
+    implementation("org.springframework:spring-core:$springVersion")
+    implementation(group = "com.fasterxml.jackson.core", name = "jackson-databind", version = "${versions.jackson}")
+    testImplementation("org.junit.jupiter:junit-jupiter:$junitVersion")
+    implementation(platform("org.springframework.cloud:spring-cloud-dependencies:2022.0.4"))
+    implementation("org.slf4j:slf4j-api")
+    runtimeOnly("mysql:mysql-connector-java:8.+")
+}`,
+		"libs/core/build.gradle": `plugins {
+    id 'io.spring.dependency-management' version '1.1.0'
+}
+dependencies {
+    api group: 'commons-beanutils', name: 'commons-beanutils', version: '1.9.4'
+    compileOnly "org.projectlombok:lombok:${lombokVersion}"
+}`,
+		"libs/core/gradle.properties": `lombokVersion=1.18.30`,
+	}
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	dependencies := ParseGradleProject(paths, mapReader(files))
+
+	testCases := []struct {
+		name, file, version, source, scope string
+	}{
+		{"org.springframework.boot:spring-boot-starter-web", "app/build.gradle.kts", "3.1.0", camodels.VersionSourcePinned, "implementation"},
+		{"commons-io:commons-io", "app/build.gradle.kts", "2.11.0", camodels.VersionSourcePinned, "implementation"},
+		{"org.springframework.boot:org.springframework.boot.gradle.plugin", "app/build.gradle.kts", "3.1.0", camodels.VersionSourcePinned, "plugin"},
+		{"org.jetbrains.kotlin.jvm:org.jetbrains.kotlin.jvm.gradle.plugin", "app/build.gradle.kts", "1.9.0", camodels.VersionSourcePinned, "plugin"},
+		{"org.apache.logging.log4j:log4j-core", "app/build.gradle.kts", "2.17.1", camodels.VersionSourcePinned, "implementation"},
+		{"org.springframework:spring-core", "app/build.gradle.kts", "5.3.20", camodels.VersionSourcePinned, "implementation"},
+		{"com.fasterxml.jackson.core:jackson-databind", "app/build.gradle.kts", "2.13.0", camodels.VersionSourcePinned, "implementation"},
+		{"org.junit.jupiter:junit-jupiter", "app/build.gradle.kts", "5.10.0", camodels.VersionSourcePinned, "testImplementation"},
+		{"org.springframework.cloud:spring-cloud-dependencies", "app/build.gradle.kts", "2022.0.4", camodels.VersionSourcePinned, "implementation"},
+		{"org.slf4j:slf4j-api", "app/build.gradle.kts", "", "", "implementation"},
+		{"mysql:mysql-connector-java", "app/build.gradle.kts", "8", camodels.VersionSourceRange, "runtimeOnly"},
+		// settings 中自定义目录的子项目继承根项目变量，同时使用自身的 gradle.properties
+		{"commons-beanutils:commons-beanutils", "libs/core/build.gradle", "1.9.4", camodels.VersionSourcePinned, "api"},
+		{"org.projectlombok:lombok", "libs/core/build.gradle", "1.18.30", camodels.VersionSourcePinned, "compileOnly"},
+		{"io.spring.dependency-management:io.spring.dependency-management.gradle.plugin", "libs/core/build.gradle", "1.1.0", camodels.VersionSourcePinned, "plugin"},
+		// 版本目录中的条目，富版本优先使用 prefer
+		{"com.alibaba:fastjson", "gradle/libs.versions.toml", "1.2.83", camodels.VersionSourcePinned, ""},
+	}
+	for _, tc := range testCases {
+		dependency := findDependency(dependencies, tc.name, tc.file)
+		if dependency == nil {
+			t.Errorf("dependency %s in %s not found", tc.name, tc.file)
+			continue
+		}
+		if dependency.Version != tc.version || dependency.Source != tc.source || dependency.Scope != tc.scope {
+			t.Errorf("dependency %s = %+v, want version=%q source=%q scope=%q", tc.name, *dependency, tc.version, tc.source, tc.scope)
+		}
+	}
+
+	if dependency := findDependency(dependencies, "commented:out", "build.gradle"); dependency != nil {
+		t.Errorf("commented dependency reported: %+v", *dependency)
+	}
+	for _, dependency := range dependencies {
+		if dependency.Name == "java:java.gradle.plugin" {
+			t.Errorf("core plugin reported: %+v", dependency)
+		}
+	}
+}
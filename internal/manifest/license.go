@@ -0,0 +1,58 @@
+package manifest
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// parseLicense 解析包元数据中的许可证字段，返回 SPDX 表达式，无法解析时返回空字符串。
+// 支持字符串（"MIT"、"(MIT OR Apache-2.0)"）、字符串数组（Composer，多个许可证任选其一），
+// 以及旧版 npm 的 {"type": "MIT"} 和对象数组写法
+func parseLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var license string
+	if json.Unmarshal(raw, &license) == nil {
+		return normalizeLicense(license)
+	}
+	var typed struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(raw, &typed) == nil && typed.Type != "" {
+		return normalizeLicense(typed.Type)
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil {
+		return ""
+	}
+	var licenses []string
+	for _, item := range list {
+		if license := parseLicense(item); license != "" && !slices.Contains(licenses, license) {
+			licenses = append(licenses, license)
+		}
+	}
+	switch len(licenses) {
+	case 0:
+		return ""
+	case 1:
+		return licenses[0]
+	}
+	for i, license := range licenses {
+		if strings.Contains(license, " ") {
+			licenses[i] = "(" + license + ")"
+		}
+	}
+	return strings.Join(licenses, " OR ")
+}
+
+// normalizeLicense 去除空白，忽略 UNLICENSED、proprietary 和 SEE LICENSE IN 等非 SPDX 写法
+func normalizeLicense(license string) string {
+	license = strings.TrimSpace(license)
+	lower := strings.ToLower(license)
+	if lower == "unlicensed" || lower == "proprietary" || strings.HasPrefix(lower, "see license") {
+		return ""
+	}
+	return license
+}
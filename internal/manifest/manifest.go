@@ -0,0 +1,93 @@
+// Package manifest 解析依赖清单文件（pom.xml、build.gradle、package.json、requirements.txt、go.mod、composer.json 及锁文件等），输出统一的依赖列表。
+package manifest
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// ReadFunc 按相对路径（正斜杠分隔）读取清单文件内容
+type ReadFunc func(relPath string) ([]byte, error)
+
+// firstVersionRe 匹配版本范围中的第一个版本号
+var firstVersionRe = regexp.MustCompile(`\d+(?:\.[\w-]+)*`)
+
+// Collect 从文件列表中找出支持的依赖清单并解析，files 为相对于项目根目录的路径
+func Collect(files []string, read ReadFunc) []camodels.Dependency {
+	var poms, gradleFiles, npmFiles, pythonFiles, goFiles, composerFiles []string
+	for _, file := range files {
+		name := strings.ToLower(path.Base(file))
+		switch {
+		case name == "pom.xml":
+			poms = append(poms, file)
+		case name == "build.gradle", name == "build.gradle.kts", name == "settings.gradle", name == "settings.gradle.kts",
+			name == "gradle.properties", strings.HasSuffix(name, ".versions.toml"):
+			gradleFiles = append(gradleFiles, file)
+		case name == "package.json", name == "package-lock.json", name == "npm-shrinkwrap.json", name == "pnpm-lock.yaml", name == "yarn.lock":
+			npmFiles = append(npmFiles, file)
+		case IsPythonManifest(file), IsPythonLock(file):
+			pythonFiles = append(pythonFiles, file)
+		case IsGoManifest(file):
+			goFiles = append(goFiles, file)
+		case IsComposerManifest(file):
+			composerFiles = append(composerFiles, file)
+		}
+	}
+
+	var dependencies []camodels.Dependency
+	if len(poms) > 0 {
+		dependencies = append(dependencies, ParseMavenProject(poms, read)...)
+	}
+	if len(gradleFiles) > 0 {
+		dependencies = append(dependencies, ParseGradleProject(gradleFiles, read)...)
+	}
+	if len(npmFiles) > 0 {
+		dependencies = append(dependencies, ParseNPMProject(npmFiles, read)...)
+	}
+	if len(pythonFiles) > 0 {
+		dependencies = append(dependencies, ParsePythonProject(pythonFiles, read)...)
+	}
+	if len(goFiles) > 0 {
+		dependencies = append(dependencies, ParseGoProject(goFiles, read)...)
+	}
+	if len(composerFiles) > 0 {
+		dependencies = append(dependencies, ParseComposerProject(composerFiles, read)...)
+	}
+	return dependencies
+}
+
+// classifyMavenVersion 判断 Maven/Gradle 版本的来源，范围和动态版本取下界，未解析的占位符视为无版本
+// 例如 "[1.2,2.0)" -> ("1.2", range)，"1.+" -> ("1", range)，"2.17.1" -> ("2.17.1", pinned)
+func classifyMavenVersion(version string) (string, string) {
+	version = strings.TrimSpace(version)
+	if version == "" || strings.Contains(version, "${") || strings.Contains(version, "$") {
+		return "", ""
+	}
+	if strings.ContainsAny(version, "[]()+,") || strings.HasPrefix(strings.ToLower(version), "latest.") {
+		version = LowerBound(version)
+		if version == "" {
+			return "", ""
+		}
+		return version, camodels.VersionSourceRange
+	}
+	return version, camodels.VersionSourcePinned
+}
+
+// LowerBound 从版本范围中取第一个版本号，例如 "[1.2,2.0)" -> "1.2"、">=2.0,<3" -> "2.0"
+func LowerBound(versionRange string) string {
+	return firstVersionRe.FindString(versionRange)
+}
+
+// sortedKeys 返回排序后的 map 键，保证输出顺序稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
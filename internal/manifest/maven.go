@@ -0,0 +1,320 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// mavenDefaultPluginGroup 插件未声明 groupId 时的默认值
+const mavenDefaultPluginGroup = "org.apache.maven.plugins"
+
+// mavenPlaceholderRe 匹配 ${name} 形式的属性占位符
+var mavenPlaceholderRe = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// pomXML pom.xml 中与依赖解析相关的部分
+type pomXML struct {
+	Parent       *pomParent      `xml:"parent"`
+	GroupID      string          `xml:"groupId"`
+	ArtifactID   string          `xml:"artifactId"`
+	Version      string          `xml:"version"`
+	Properties   pomProperties   `xml:"properties"`
+	Managed      []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+	Plugins      []pomDependency `xml:"build>plugins>plugin"`
+}
+
+type pomParent struct {
+	GroupID      string  `xml:"groupId"`
+	ArtifactID   string  `xml:"artifactId"`
+	Version      string  `xml:"version"`
+	RelativePath *string `xml:"relativePath"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Scope      string `xml:"scope"`
+}
+
+// pomProperties <properties> 中的任意子元素
+type pomProperties map[string]string
+
+// UnmarshalXML 将 <properties> 的子元素解析为 名称 -> 值
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = make(pomProperties)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// mavenProject 单个 pom.xml 及其解析后的有效模型
+type mavenProject struct {
+	file string
+	pom  *pomXML
+
+	resolved   bool
+	resolving  bool
+	parent     *mavenProject
+	properties map[string]string // 有效属性：继承父 POM 后被当前 POM 覆盖
+	managed    map[string]string // 有效依赖管理：groupId:artifactId -> 版本（可能包含占位符）
+}
+
+// groupID 返回项目的 groupId，未声明时继承父 POM
+func (p *mavenProject) groupID() string {
+	if p.pom.GroupID == "" && p.pom.Parent != nil {
+		return strings.TrimSpace(p.pom.Parent.GroupID)
+	}
+	return strings.TrimSpace(p.pom.GroupID)
+}
+
+// version 返回项目的版本，未声明时继承父 POM
+func (p *mavenProject) version() string {
+	if p.pom.Version == "" && p.pom.Parent != nil {
+		return strings.TrimSpace(p.pom.Parent.Version)
+	}
+	return strings.TrimSpace(p.pom.Version)
+}
+
+func (p *mavenProject) coordinate() string {
+	return p.groupID() + ":" + strings.TrimSpace(p.pom.ArtifactID)
+}
+
+// mavenResolver 在同一项目的多个 pom.xml 之间解析父 POM、属性和依赖管理
+type mavenResolver struct {
+	projects     []*mavenProject
+	byFile       map[string]*mavenProject
+	byCoordinate map[string]*mavenProject
+}
+
+// ParseMavenProject 解析项目中的所有 pom.xml，返回每个 POM 声明的依赖和插件。
+// 版本中的 ${...} 属性、父 POM 链（本地模块）、<dependencyManagement> 和 import 范围的 BOM 都会被解析；
+// 仓库中不存在的父 POM 和 BOM 无法解析，相关版本保持为空。
+func ParseMavenProject(files []string, read ReadFunc) []camodels.Dependency {
+	resolver := &mavenResolver{
+		byFile:       make(map[string]*mavenProject),
+		byCoordinate: make(map[string]*mavenProject),
+	}
+	for _, file := range files {
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		pom, err := parsePOM(content)
+		if err != nil {
+			slogs.Debugf("parse maven pom (%s) error: %v", file, err)
+			continue
+		}
+		project := &mavenProject{file: file, pom: pom}
+		resolver.projects = append(resolver.projects, project)
+		resolver.byFile[file] = project
+		if _, exists := resolver.byCoordinate[project.coordinate()]; !exists {
+			resolver.byCoordinate[project.coordinate()] = project
+		}
+	}
+
+	var dependencies []camodels.Dependency
+	for _, project := range resolver.projects {
+		resolver.resolve(project)
+		dependencies = append(dependencies, resolver.dependencies(project)...)
+	}
+	return dependencies
+}
+
+// parsePOM 解析 pom.xml 内容，忽略声明的字符集（按 UTF-8 兼容方式读取）
+func parsePOM(content []byte) (*pomXML, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	var pom pomXML
+	if err := decoder.Decode(&pom); err != nil {
+		return nil, err
+	}
+	return &pom, nil
+}
+
+// findParent 按 relativePath（默认 ../pom.xml）查找本地父 POM，找不到时按坐标查找
+func (r *mavenResolver) findParent(project *mavenProject) *mavenProject {
+	parent := project.pom.Parent
+	if parent == nil {
+		return nil
+	}
+	coordinate := strings.TrimSpace(parent.GroupID) + ":" + strings.TrimSpace(parent.ArtifactID)
+
+	relativePath := "../pom.xml"
+	if parent.RelativePath != nil {
+		relativePath = strings.TrimSpace(*parent.RelativePath)
+	}
+	if relativePath != "" {
+		candidate := path.Join(path.Dir(project.file), relativePath)
+		if !strings.HasSuffix(strings.ToLower(candidate), ".xml") {
+			candidate = path.Join(candidate, "pom.xml")
+		}
+		if found, ok := r.byFile[candidate]; ok && found.coordinate() == coordinate {
+			return found
+		}
+	}
+	if found, ok := r.byCoordinate[coordinate]; ok && found != project {
+		return found
+	}
+	return nil
+}
+
+// resolve 计算项目的有效属性和依赖管理，父 POM 优先解析，循环引用时停止继承
+func (r *mavenResolver) resolve(project *mavenProject) {
+	if project.resolved || project.resolving {
+		return
+	}
+	project.resolving = true
+	defer func() {
+		project.resolving = false
+		project.resolved = true
+	}()
+
+	project.properties = make(map[string]string)
+	project.managed = make(map[string]string)
+
+	if parent := r.findParent(project); parent != nil {
+		r.resolve(parent)
+		if parent.resolved {
+			project.parent = parent
+			for k, v := range parent.properties {
+				project.properties[k] = v
+			}
+			for k, v := range parent.managed {
+				project.managed[k] = v
+			}
+		}
+	}
+
+	for k, v := range project.pom.Properties {
+		project.properties[k] = v
+	}
+	builtins := map[string]string{
+		"project.groupId":    project.groupID(),
+		"project.artifactId": strings.TrimSpace(project.pom.ArtifactID),
+		"project.version":    project.version(),
+	}
+	if project.pom.Parent != nil {
+		builtins["project.parent.groupId"] = strings.TrimSpace(project.pom.Parent.GroupID)
+		builtins["project.parent.artifactId"] = strings.TrimSpace(project.pom.Parent.ArtifactID)
+		builtins["project.parent.version"] = strings.TrimSpace(project.pom.Parent.Version)
+	}
+	for k, v := range builtins {
+		project.properties[k] = v
+		project.properties[strings.Replace(k, "project.", "pom.", 1)] = v
+	}
+
+	// 当前 POM 显式声明的依赖管理覆盖继承的条目，import 范围的 BOM 只补充尚未管理的依赖
+	var imports []pomDependency
+	for _, managed := range project.pom.Managed {
+		if strings.EqualFold(managed.Scope, "import") && strings.EqualFold(managed.Type, "pom") {
+			imports = append(imports, managed)
+			continue
+		}
+		project.managed[project.dependencyName(managed, "")] = strings.TrimSpace(managed.Version)
+	}
+	for _, imported := range imports {
+		bom, ok := r.byCoordinate[project.dependencyName(imported, "")]
+		if !ok || bom == project {
+			continue
+		}
+		r.resolve(bom)
+		for name, version := range bom.managed {
+			if _, exists := project.managed[name]; !exists {
+				project.managed[name] = interpolate(version, bom.properties)
+			}
+		}
+	}
+}
+
+// dependencyName 返回依赖的 groupId:artifactId，占位符使用项目属性替换
+func (p *mavenProject) dependencyName(dependency pomDependency, defaultGroup string) string {
+	group := strings.TrimSpace(dependency.GroupID)
+	if group == "" {
+		group = defaultGroup
+	}
+	return interpolate(group, p.properties) + ":" + interpolate(strings.TrimSpace(dependency.ArtifactID), p.properties)
+}
+
+// dependencies 返回项目声明的依赖、构建插件和外部父 POM，版本按属性和依赖管理解析
+func (r *mavenResolver) dependencies(project *mavenProject) []camodels.Dependency {
+	var result []camodels.Dependency
+	add := func(dependency pomDependency, defaultGroup, defaultScope string) {
+		name := project.dependencyName(dependency, defaultGroup)
+		raw := strings.TrimSpace(dependency.Version)
+		version := interpolate(raw, project.properties)
+		if version == "" {
+			version = interpolate(project.managed[name], project.properties)
+		}
+		version, source := classifyMavenVersion(version)
+
+		scope := strings.TrimSpace(dependency.Scope)
+		if scope == "" {
+			scope = defaultScope
+		}
+		result = append(result, camodels.Dependency{
+			Ecosystem: camodels.EcosystemMaven,
+			Name:      name,
+			Version:   version,
+			Raw:       raw,
+			Source:    source,
+			Scope:     scope,
+			File:      project.file,
+		})
+	}
+
+	// 仓库外的父 POM（例如 spring-boot-starter-parent）作为 parent 范围的依赖输出
+	if parent := project.pom.Parent; parent != nil && r.findParent(project) == nil {
+		add(pomDependency{GroupID: parent.GroupID, ArtifactID: parent.ArtifactID, Version: parent.Version}, "", "parent")
+	}
+	for _, dependency := range project.pom.Dependencies {
+		add(dependency, "", "compile")
+	}
+	for _, plugin := range project.pom.Plugins {
+		add(plugin, mavenDefaultPluginGroup, "plugin")
+	}
+	return result
+}
+
+// interpolate 将 ${name} 替换为属性值，支持嵌套引用，未定义的属性保持原样
+func interpolate(value string, properties map[string]string) string {
+	for i := 0; i < 10 && strings.Contains(value, "${"); i++ {
+		replaced := mavenPlaceholderRe.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := placeholder[2 : len(placeholder)-1]
+			if v, ok := properties[name]; ok {
+				return v
+			}
+			return placeholder
+		})
+		if replaced == value {
+			break
+		}
+		value = replaced
+	}
+	return value
+}
@@ -0,0 +1,195 @@
+package manifest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// mapReader 返回从内存读取清单文件的 ReadFunc
+func mapReader(files map[string]string) ReadFunc {
+	return func(relPath string) ([]byte, error) {
+		content, ok := files[relPath]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+}
+
+func findDependency(dependencies []camodels.Dependency, name, file string) *camodels.Dependency {
+	for i := range dependencies {
+		if dependencies[i].Name == name && dependencies[i].File == file {
+			return &dependencies[i]
+		}
+	}
+	return nil
+}
+
+// TestParseMavenProject tests property, parent chain, dependencyManagement and BOM resolution
+func TestParseMavenProject(t *testing.T) {
+	files := map[string]string{
+		"pom.xml": `<?xml version="1.0" encoding="ISO-8859-1"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <groupId>com.example</groupId>
+  <artifactId>parent</artifactId>
+  <version>1.0.0</version>
+  <packaging>pom</packaging>
+  <properties>
+    <fastjson.version>1.2.83</fastjson.version>
+    <log4j.version>2.14.1</log4j.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.apache.logging.log4j</groupId>
+        <artifactId>log4j-core</artifactId>
+        <version>${log4j.version}</version>
+      </dependency>
+      <dependency>
+        <groupId>com.example</groupId>
+        <artifactId>bom</artifactId>
+        <version>1.0.0</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>com.alibaba</groupId>
+      <artifactId>fastjson</artifactId>
+      <version>${fastjson.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+		"bom/pom.xml": `<project>
+  <groupId>com.example</groupId>
+  <artifactId>bom</artifactId>
+  <version>1.0.0</version>
+  <properties><jackson.version>2.13.0</jackson.version></properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.fasterxml.jackson.core</groupId>
+        <artifactId>jackson-databind</artifactId>
+        <version>${jackson.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+		"module-a/pom.xml": `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <artifactId>module-a</artifactId>
+  <properties><log4j.version>2.17.1</log4j.version></properties>
+  <dependencies>
+    <dependency>
+      <groupId>org.apache.logging.log4j</groupId>
+      <artifactId>log4j-core</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <scope>test</scope>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>module-b</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>mysql</groupId>
+      <artifactId>mysql-connector-java</artifactId>
+      <version>[5.1,6.0)</version>
+    </dependency>
+    <dependency>
+      <groupId>commons-io</groupId>
+      <artifactId>commons-io</artifactId>
+      <version>${undefined.version}</version>
+    </dependency>
+  </dependencies>
+  <build>
+    <plugins>
+      <plugin>
+        <artifactId>maven-surefire-plugin</artifactId>
+        <version>3.0.0</version>
+      </plugin>
+    </plugins>
+  </build>
+</project>`,
+	}
+	paths := []string{"pom.xml", "bom/pom.xml", "module-a/pom.xml"}
+	dependencies := ParseMavenProject(paths, mapReader(files))
+
+	testCases := []struct {
+		name, file    string
+		version, raw  string
+		source, scope string
+	}{
+		{"com.alibaba:fastjson", "pom.xml", "1.2.83", "${fastjson.version}", camodels.VersionSourcePinned, "compile"},
+		// 父 POM 的依赖管理使用子模块覆盖后的属性
+		{"org.apache.logging.log4j:log4j-core", "module-a/pom.xml", "2.17.1", "", camodels.VersionSourcePinned, "compile"},
+		// 父 POM 导入的 BOM
+		{"com.fasterxml.jackson.core:jackson-databind", "module-a/pom.xml", "2.13.0", "", camodels.VersionSourcePinned, "test"},
+		// 继承父 POM 的 groupId 和 version
+		{"com.example:module-b", "module-a/pom.xml", "1.0.0", "${project.version}", camodels.VersionSourcePinned, "compile"},
+		{"mysql:mysql-connector-java", "module-a/pom.xml", "5.1", "[5.1,6.0)", camodels.VersionSourceRange, "compile"},
+		{"commons-io:commons-io", "module-a/pom.xml", "", "${undefined.version}", "", "compile"},
+		{"org.apache.maven.plugins:maven-surefire-plugin", "module-a/pom.xml", "3.0.0", "3.0.0", camodels.VersionSourcePinned, "plugin"},
+	}
+	for _, tc := range testCases {
+		dependency := findDependency(dependencies, tc.name, tc.file)
+		if dependency == nil {
+			t.Errorf("dependency %s in %s not found", tc.name, tc.file)
+			continue
+		}
+		if dependency.Version != tc.version || dependency.Raw != tc.raw || dependency.Source != tc.source || dependency.Scope != tc.scope {
+			t.Errorf("dependency %s = %+v, want version=%q raw=%q source=%q scope=%q",
+				tc.name, *dependency, tc.version, tc.raw, tc.source, tc.scope)
+		}
+	}
+
+	// 依赖管理中的条目不是实际依赖
+	if dependency := findDependency(dependencies, "org.apache.logging.log4j:log4j-core", "pom.xml"); dependency != nil {
+		t.Errorf("managed dependency reported as declared: %+v", *dependency)
+	}
+}
+
+// TestParseMavenProjectParentCycle tests that cyclic parents do not hang
+func TestParseMavenProjectParentCycle(t *testing.T) {
+	files := map[string]string{
+		"a/pom.xml": `<project><parent><groupId>g</groupId><artifactId>b</artifactId><relativePath>../b/pom.xml</relativePath></parent>
+<artifactId>a</artifactId><dependencies><dependency><groupId>x</groupId><artifactId>y</artifactId><version>${v}</version></dependency></dependencies></project>`,
+		"b/pom.xml": `<project><parent><groupId>g</groupId><artifactId>a</artifactId><relativePath>../a/pom.xml</relativePath></parent>
+<groupId>g</groupId><artifactId>b</artifactId><properties><v>1.0</v></properties></project>`,
+	}
+	dependencies := ParseMavenProject([]string{"a/pom.xml", "b/pom.xml"}, mapReader(files))
+	if len(dependencies) != 1 || dependencies[0].Version != "1.0" {
+		t.Errorf("ParseMavenProject() = %+v", dependencies)
+	}
+}
+
+// TestParseMavenProjectExternalParent tests that parents outside the repository are reported
+func TestParseMavenProjectExternalParent(t *testing.T) {
+	files := map[string]string{
+		"pom.xml": `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>2.7.18</version>
+    <relativePath/>
+  </parent>
+  <artifactId>demo</artifactId>
+</project>`,
+	}
+	dependencies := ParseMavenProject([]string{"pom.xml"}, mapReader(files))
+	dependency := findDependency(dependencies, "org.springframework.boot:spring-boot-starter-parent", "pom.xml")
+	if dependency == nil || dependency.Version != "2.7.18" || dependency.Scope != "parent" {
+		t.Errorf("ParseMavenProject() = %+v", dependencies)
+	}
+}
@@ -0,0 +1,399 @@
+package manifest
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/winezer0/slogs"
+	"gopkg.in/yaml.v3"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// npm 锁文件名称，按优先级排列
+var npmLockFiles = []string{"package-lock.json", "npm-shrinkwrap.json", "pnpm-lock.yaml", "yarn.lock"}
+
+// npmExactVersionRe 精确版本，例如 "18.2.0"、"=1.0.0"、"v2.0.0-beta.1"
+var npmExactVersionRe = regexp.MustCompile(`^[=v]*\d+\.\d+\.\d+(?:[-+][\w.-]+)?$`)
+
+// packageJSON package.json 中与依赖相关的字段
+type packageJSON struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	License              json.RawMessage   `json:"license"`
+	Licenses             json.RawMessage   `json:"licenses"` // 旧版写法：[{"type": "MIT"}]
+}
+
+// license 返回 package.json 中声明的许可证
+func (p *packageJSON) license() string {
+	if license := parseLicense(p.License); license != "" {
+		return license
+	}
+	return parseLicense(p.Licenses)
+}
+
+// npmLock 锁文件中已解析的版本
+type npmLock interface {
+	// resolve 返回 importer（相对锁文件目录的 package.json 目录）中依赖 name@spec 的实际版本，找不到时返回空字符串
+	resolve(importer, name, spec string) string
+}
+
+// ParseNPMProject 解析项目中所有 package.json（不含 node_modules）声明的依赖，
+// 并从最近的上级目录中的 package-lock.json（v1-v3）、npm-shrinkwrap.json、pnpm-lock.yaml 或 yarn.lock（classic 与 berry）
+// 中查找实际安装的版本；没有锁文件时回退到 node_modules/<name>/package.json 中的版本。
+// 许可证取自 package-lock.json（v2/v3）或已安装的 node_modules/<name>/package.json
+func ParseNPMProject(files []string, read ReadFunc) []camodels.Dependency {
+	fileSet := make(map[string]bool, len(files))
+	for _, file := range files {
+		fileSet[file] = true
+	}
+	locks := make(map[string]npmLock)
+	lockFor := func(dir string) (npmLock, string) {
+		for current := dir; ; current = path.Dir(current) {
+			for _, name := range npmLockFiles {
+				lockFile := path.Join(current, name)
+				if !fileSet[lockFile] {
+					continue
+				}
+				lock, ok := locks[lockFile]
+				if !ok {
+					lock = parseNPMLock(lockFile, read)
+					locks[lockFile] = lock
+				}
+				if lock != nil {
+					return lock, current
+				}
+			}
+			if current == "." || current == "/" {
+				return nil, ""
+			}
+		}
+	}
+
+	var result []camodels.Dependency
+	for _, file := range files {
+		if path.Base(file) != "package.json" || isNodeModulesPath(file) {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		var pkg packageJSON
+		if err := json.Unmarshal(content, &pkg); err != nil {
+			slogs.Debugf("parse package.json (%s) error: %v", file, err)
+			continue
+		}
+
+		dir := path.Dir(file)
+		lock, lockDir := lockFor(dir)
+		importer := "."
+		if lock != nil {
+			importer = relativeDir(lockDir, dir)
+		}
+
+		for _, group := range []struct {
+			deps  map[string]string
+			scope string
+		}{
+			{pkg.Dependencies, "prod"},
+			{pkg.DevDependencies, "dev"},
+			{pkg.PeerDependencies, "peer"},
+			{pkg.OptionalDependencies, "optional"},
+		} {
+			for _, name := range sortedKeys(group.deps) {
+				spec := strings.TrimSpace(group.deps[name])
+				dependency := camodels.Dependency{
+					Ecosystem: camodels.EcosystemNPM,
+					Name:      name,
+					Raw:       spec,
+					Scope:     group.scope,
+					File:      file,
+				}
+				resolved, license := "", ""
+				if lock != nil {
+					resolved = lock.resolve(importer, name, spec)
+					if packageLock, ok := lock.(*packageLock); ok {
+						license = packageLock.license(importer, name)
+					}
+				}
+				installed := installedNPMPackage(dir, name, fileSet, read)
+				if resolved == "" && installed != nil {
+					resolved = installed.Version
+				}
+				if license == "" && installed != nil && installed.Version == resolved {
+					license = installed.license()
+				}
+				dependency.License = license
+				if resolved != "" {
+					dependency.Version = resolved
+					dependency.Source = camodels.VersionSourceResolved
+				} else {
+					dependency.Version, dependency.Source = classifyNPMSpec(spec)
+				}
+				result = append(result, dependency)
+			}
+		}
+	}
+	return result
+}
+
+// classifyNPMSpec 根据声明的版本判断来源：精确版本为 pinned，范围取下界为 range，
+// tag、git、file、workspace 等非版本写法返回空版本
+func classifyNPMSpec(spec string) (string, string) {
+	if npmExactVersionRe.MatchString(spec) {
+		return strings.TrimLeft(spec, "=v"), camodels.VersionSourcePinned
+	}
+	if strings.Contains(spec, ":") || strings.Contains(spec, "/") {
+		return "", ""
+	}
+	if version := LowerBound(spec); version != "" {
+		return version, camodels.VersionSourceRange
+	}
+	return "", ""
+}
+
+// installedNPMPackage 从 node_modules/<name>/package.json 读取已安装的包，逐级向上查找，未安装时返回 nil
+func installedNPMPackage(dir, name string, fileSet map[string]bool, read ReadFunc) *packageJSON {
+	for current := dir; ; current = path.Dir(current) {
+		file := path.Join(current, "node_modules", name, "package.json")
+		if fileSet[file] {
+			if content, err := read(file); err == nil {
+				var pkg packageJSON
+				if json.Unmarshal(content, &pkg) == nil && pkg.Version != "" {
+					return &pkg
+				}
+			}
+		}
+		if current == "." || current == "/" {
+			return nil
+		}
+	}
+}
+
+// isNodeModulesPath 判断路径是否位于 node_modules 中
+func isNodeModulesPath(file string) bool {
+	return strings.HasPrefix(file, "node_modules/") || strings.Contains(file, "/node_modules/")
+}
+
+// relativeDir 返回 dir 相对 base 的路径，两者相同时返回 "."
+func relativeDir(base, dir string) string {
+	if base == dir {
+		return "."
+	}
+	if base == "." {
+		return dir
+	}
+	return strings.TrimPrefix(dir, base+"/")
+}
+
+// parseNPMLock 根据文件名解析锁文件，解析失败时返回 nil
+func parseNPMLock(file string, read ReadFunc) npmLock {
+	content, err := read(file)
+	if err != nil {
+		return nil
+	}
+	var lock npmLock
+	switch path.Base(file) {
+	case "package-lock.json", "npm-shrinkwrap.json":
+		lock, err = parsePackageLock(content)
+	case "pnpm-lock.yaml":
+		lock, err = parsePNPMLock(content)
+	case "yarn.lock":
+		lock = parseYarnLock(content)
+	}
+	if err != nil {
+		slogs.Debugf("parse npm lock file (%s) error: %v", file, err)
+		return nil
+	}
+	return lock
+}
+
+// --- package-lock.json ---
+
+// packageLock package-lock.json / npm-shrinkwrap.json
+// v1 使用嵌套的 dependencies，v2 同时包含 packages 和 dependencies，v3 只有 packages
+type packageLock struct {
+	LockfileVersion int `json:"lockfileVersion"`
+	Packages        map[string]struct {
+		Version string          `json:"version"`
+		License json.RawMessage `json:"license"`
+	} `json:"packages"`
+	Dependencies map[string]struct {
+		Version string `json:"version"`
+	} `json:"dependencies"`
+}
+
+func parsePackageLock(content []byte) (*packageLock, error) {
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// packageKeys 返回 v2/v3 packages 中依赖可能的键：优先为工作区自身的 node_modules，然后是根目录提升后的 node_modules
+func (l *packageLock) packageKeys(importer, name string) []string {
+	var keys []string
+	if importer != "." {
+		keys = append(keys, path.Join(importer, "node_modules", name))
+	}
+	return append(keys, "node_modules/"+name)
+}
+
+func (l *packageLock) resolve(importer, name, _ string) string {
+	for _, key := range l.packageKeys(importer, name) {
+		if pkg, ok := l.Packages[key]; ok && isNPMVersion(pkg.Version) {
+			return pkg.Version
+		}
+	}
+	// v1：顶层 dependencies
+	if dep, ok := l.Dependencies[name]; ok && isNPMVersion(dep.Version) {
+		return dep.Version
+	}
+	return ""
+}
+
+// license 返回 v2/v3 packages 中记录的许可证，v1 不包含许可证信息
+func (l *packageLock) license(importer, name string) string {
+	for _, key := range l.packageKeys(importer, name) {
+		if pkg, ok := l.Packages[key]; ok && isNPMVersion(pkg.Version) {
+			return parseLicense(pkg.License)
+		}
+	}
+	return ""
+}
+
+// isNPMVersion 判断锁文件中的版本是否为实际版本（排除 file:、git 链接等）
+func isNPMVersion(version string) bool {
+	return version != "" && version[0] >= '0' && version[0] <= '9'
+}
+
+// --- pnpm-lock.yaml ---
+
+// pnpmLock pnpm-lock.yaml，v5 单项目时依赖位于顶层，多项目和 v6+ 位于 importers
+type pnpmLock struct {
+	Importers            map[string]pnpmImporter `yaml:"importers"`
+	Dependencies         map[string]yaml.Node    `yaml:"dependencies"`
+	DevDependencies      map[string]yaml.Node    `yaml:"devDependencies"`
+	OptionalDependencies map[string]yaml.Node    `yaml:"optionalDependencies"`
+}
+
+type pnpmImporter struct {
+	Dependencies         map[string]yaml.Node `yaml:"dependencies"`
+	DevDependencies      map[string]yaml.Node `yaml:"devDependencies"`
+	OptionalDependencies map[string]yaml.Node `yaml:"optionalDependencies"`
+}
+
+func parsePNPMLock(content []byte) (*pnpmLock, error) {
+	var lock pnpmLock
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	if lock.Importers == nil {
+		lock.Importers = map[string]pnpmImporter{".": {
+			Dependencies:         lock.Dependencies,
+			DevDependencies:      lock.DevDependencies,
+			OptionalDependencies: lock.OptionalDependencies,
+		}}
+	}
+	return &lock, nil
+}
+
+func (l *pnpmLock) resolve(importer, name, _ string) string {
+	entry, ok := l.Importers[importer]
+	if !ok {
+		return ""
+	}
+	for _, deps := range []map[string]yaml.Node{entry.Dependencies, entry.DevDependencies, entry.OptionalDependencies} {
+		node, ok := deps[name]
+		if !ok {
+			continue
+		}
+		// v6+ 为 { specifier, version }，v5 为版本字符串
+		version := node.Value
+		if node.Kind == yaml.MappingNode {
+			var value struct {
+				Version string `yaml:"version"`
+			}
+			if node.Decode(&value) == nil {
+				version = value.Version
+			}
+		}
+		// 去除 peer 依赖后缀，例如 "18.2.0(react@18.2.0)"、"1.0.0_react@18.2.0"
+		version = strings.SplitN(strings.SplitN(version, "(", 2)[0], "_", 2)[0]
+		if isNPMVersion(version) {
+			return version
+		}
+	}
+	return ""
+}
+
+// --- yarn.lock ---
+
+// yarnLock yarn.lock，classic 与 berry 格式共用：条目头为逗号分隔的 name@range 列表，条目内包含 version
+type yarnLock struct {
+	versions map[string]string   // name@range -> 版本
+	byName   map[string][]string // name -> 所有已解析版本
+}
+
+func parseYarnLock(content []byte) *yarnLock {
+	lock := &yarnLock{versions: make(map[string]string), byName: make(map[string][]string)}
+	var specs []string
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		// 条目头：不缩进且以冒号结尾
+		if line[0] != ' ' && strings.HasSuffix(trimmed, ":") {
+			specs = specs[:0]
+			for _, spec := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				spec = strings.Trim(strings.TrimSpace(spec), `"`)
+				specs = append(specs, strings.Replace(spec, "@npm:", "@", 1))
+			}
+			continue
+		}
+		// 条目内的版本：classic 为 `version "1.2.3"`，berry 为 `version: 1.2.3`
+		if rest, ok := strings.CutPrefix(trimmed, "version"); ok && len(specs) > 0 {
+			version := strings.Trim(strings.TrimSpace(strings.TrimPrefix(rest, ":")), `"`)
+			if !isNPMVersion(version) || strings.HasPrefix(line, "    ") {
+				continue
+			}
+			for _, spec := range specs {
+				lock.versions[spec] = version
+				if name := yarnSpecName(spec); name != "" && !slices.Contains(lock.byName[name], version) {
+					lock.byName[name] = append(lock.byName[name], version)
+				}
+			}
+		}
+	}
+	return lock
+}
+
+// yarnSpecName 从 name@range 中取出包名，兼容 @scope/name@range
+func yarnSpecName(spec string) string {
+	idx := strings.LastIndex(spec, "@")
+	if idx <= 0 {
+		return ""
+	}
+	return spec[:idx]
+}
+
+func (l *yarnLock) resolve(_, name, spec string) string {
+	if version, ok := l.versions[name+"@"+strings.TrimPrefix(spec, "npm:")]; ok {
+		return version
+	}
+	// 条目头与声明不完全一致时（例如工作区），包名只对应一个版本时直接使用
+	if versions := l.byName[name]; len(versions) == 1 {
+		return versions[0]
+	}
+	return ""
+}
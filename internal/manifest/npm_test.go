@@ -0,0 +1,135 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestParseNPMProject tests resolved versions from package-lock (v1-v3), yarn (classic and berry), pnpm and node_modules
+func TestParseNPMProject(t *testing.T) {
+	files := map[string]string{
+		// npm 工作区，package-lock v3
+		"npm/package.json":              `{"workspaces": ["packages/*"], "dependencies": {"react": "^18.2.0"}, "devDependencies": {"vite": "~4.4.0"}}`,
+		"npm/packages/web/package.json": `{"dependencies": {"react": "^17.0.0", "local": "file:../local"}}`,
+		"npm/package-lock.json": `{"lockfileVersion": 3, "packages": {
+			"": {"name": "root"},
+			"node_modules/react": {"version": "18.3.1", "license": "MIT"},
+			"node_modules/vite": {"version": "4.4.9"},
+			"node_modules/local": {"resolved": "packages/local", "link": true},
+			"packages/web/node_modules/react": {"version": "17.0.2"}}}`,
+		// package-lock v1
+		"v1/package.json":      `{"dependencies": {"express": "^4.17.1"}}`,
+		"v1/package-lock.json": `{"lockfileVersion": 1, "dependencies": {"express": {"version": "4.18.2"}}}`,
+		// yarn classic
+		"classic/package.json": `{"dependencies": {"@angular/core": "^16.0.0", "lodash": "4.17.21"}}`,
+		"classic/yarn.lock": `# yarn lockfile v1
+
+"@angular/core@^16.0.0", "@angular/core@^16.1.0":
+  version "16.2.12"
+  resolved "https://registry.yarnpkg.com/@angular/core/-/core-16.2.12.tgz"
+  dependencies:
+    tslib "^2.3.0"
+
+lodash@4.17.21:
+  version "4.17.21"
+`,
+		// yarn berry
+		"berry/package.json": `{"dependencies": {"vue": "^3.3.0"}}`,
+		"berry/yarn.lock": `__metadata:
+  version: 6
+
+"vue@npm:^3.3.0":
+  version: 3.3.4
+  resolution: "vue@npm:3.3.4"
+`,
+		// pnpm v6+，工作区 importers
+		"pnpm/package.json":           `{"dependencies": {"next": "^13.4.0"}}`,
+		"pnpm/apps/site/package.json": `{"dependencies": {"axios": "^1.4.0"}}`,
+		"pnpm/pnpm-lock.yaml": `lockfileVersion: '6.0'
+importers:
+  .:
+    dependencies:
+      next:
+        specifier: ^13.4.0
+        version: 13.4.19(react-dom@18.2.0)(react@18.2.0)
+  apps/site:
+    dependencies:
+      axios:
+        specifier: ^1.4.0
+        version: 1.5.0
+`,
+		// pnpm v5 单项目
+		"pnpm5/package.json":   `{"dependencies": {"svelte": "^3.59.0"}}`,
+		"pnpm5/pnpm-lock.yaml": "lockfileVersion: 5.4\ndependencies:\n  svelte: 3.59.2_typescript@5.0.0\n",
+		// 无锁文件，回退到 node_modules
+		"plain/package.json":                       `{"dependencies": {"electron": "^25.0.0", "nuxt": "latest"}}`,
+		"plain/node_modules/electron/package.json": `{"name": "electron", "version": "25.9.0", "license": {"type": "MIT"}, "dependencies": {"got": "^11.8.5"}}`,
+	}
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	dependencies := ParseNPMProject(paths, mapReader(files))
+
+	testCases := []struct {
+		name, file, version, raw, source, scope string
+	}{
+		{"react", "npm/package.json", "18.3.1", "^18.2.0", camodels.VersionSourceResolved, "prod"},
+		{"vite", "npm/package.json", "4.4.9", "~4.4.0", camodels.VersionSourceResolved, "dev"},
+		{"react", "npm/packages/web/package.json", "17.0.2", "^17.0.0", camodels.VersionSourceResolved, "prod"},
+		{"local", "npm/packages/web/package.json", "", "file:../local", "", "prod"},
+		{"express", "v1/package.json", "4.18.2", "^4.17.1", camodels.VersionSourceResolved, "prod"},
+		{"@angular/core", "classic/package.json", "16.2.12", "^16.0.0", camodels.VersionSourceResolved, "prod"},
+		{"lodash", "classic/package.json", "4.17.21", "4.17.21", camodels.VersionSourceResolved, "prod"},
+		{"vue", "berry/package.json", "3.3.4", "^3.3.0", camodels.VersionSourceResolved, "prod"},
+		{"next", "pnpm/package.json", "13.4.19", "^13.4.0", camodels.VersionSourceResolved, "prod"},
+		{"axios", "pnpm/apps/site/package.json", "1.5.0", "^1.4.0", camodels.VersionSourceResolved, "prod"},
+		{"svelte", "pnpm5/package.json", "3.59.2", "^3.59.0", camodels.VersionSourceResolved, "prod"},
+		{"electron", "plain/package.json", "25.9.0", "^25.0.0", camodels.VersionSourceResolved, "prod"},
+		{"nuxt", "plain/package.json", "", "latest", "", "prod"},
+	}
+	for _, tc := range testCases {
+		dependency := findDependency(dependencies, tc.name, tc.file)
+		if dependency == nil {
+			t.Errorf("dependency %s in %s not found", tc.name, tc.file)
+			continue
+		}
+		if dependency.Version != tc.version || dependency.Raw != tc.raw || dependency.Source != tc.source ||
+			dependency.Scope != tc.scope || dependency.Ecosystem != camodels.EcosystemNPM {
+			t.Errorf("dependency %s = %+v, want version=%q raw=%q source=%q scope=%q", tc.name, *dependency, tc.version, tc.raw, tc.source, tc.scope)
+		}
+	}
+	for file, license := range map[string]string{"npm/package.json": "MIT", "npm/packages/web/package.json": "", "plain/package.json": "MIT"} {
+		name := "react"
+		if file == "plain/package.json" {
+			name = "electron"
+		}
+		if dependency := findDependency(dependencies, name, file); dependency == nil || dependency.License != license {
+			t.Errorf("dependency %s in %s license = %+v, want %q", name, file, dependency, license)
+		}
+	}
+	if dependency := findDependency(dependencies, "got", "plain/node_modules/electron/package.json"); dependency != nil {
+		t.Errorf("node_modules package reported: %+v", *dependency)
+	}
+}
+
+// TestClassifyNPMSpec tests declared versions without a lockfile
+func TestClassifyNPMSpec(t *testing.T) {
+	testCases := []struct {
+		spec, version, source string
+	}{
+		{"1.2.3", "1.2.3", camodels.VersionSourcePinned},
+		{"=v2.0.0-beta.1", "2.0.0-beta.1", camodels.VersionSourcePinned},
+		{"^4.17.0", "4.17.0", camodels.VersionSourceRange},
+		{">=1.0.0 <2.0.0", "1.0.0", camodels.VersionSourceRange},
+		{"workspace:^1.0.0", "", ""},
+		{"github:user/repo#v1.0.0", "", ""},
+		{"*", "", ""},
+	}
+	for _, tc := range testCases {
+		if version, source := classifyNPMSpec(tc.spec); version != tc.version || source != tc.source {
+			t.Errorf("classifyNPMSpec(%q) = %q, %q, want %q, %q", tc.spec, version, source, tc.version, tc.source)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package manifest
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/winezer0/slogs"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+var (
+	// pythonNameSepRe PEP 503 名称规范化时视为等价的分隔符
+	pythonNameSepRe = regexp.MustCompile(`[-_.]+`)
+	// pep508Re PEP 508 依赖声明：名称、可选的 extras、其余部分（版本约束或 @ URL）
+	pep508Re = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+	// pythonPinnedRe 精确版本约束，例如 "==2.0.1"、"===1.0"，以及 Poetry 中不带运算符的 "2.0.1"
+	pythonPinnedRe = regexp.MustCompile(`^(?:===?)?\s*v?(\d+(?:\.\d+)*(?:[-_.]?[A-Za-z]+\d*)*(?:\+[\w.]+)?)$`)
+	// pythonLowerBoundRe 可作为下界的约束，例如 ">=1.0"、"~=2.2"、"^1.2"、"==1.4.*"
+	pythonLowerBoundRe = regexp.MustCompile(`(?:>=|~=|==|\^|~|>)\s*v?(\d+(?:\.\d+)*)`)
+	// setupRequiresRe setup.py 中 install_requires 列表的起始位置
+	setupRequiresRe = regexp.MustCompile(`install_requires\s*=\s*\[`)
+	// pythonStringRe 引号包围的字符串
+	pythonStringRe = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+)
+
+// NormalizePythonName 按 PEP 503 规范化包名：转为小写，连续的 - _ . 替换为单个 -
+func NormalizePythonName(name string) string {
+	return strings.ToLower(pythonNameSepRe.ReplaceAllString(strings.TrimSpace(name), "-"))
+}
+
+// IsPythonManifest 判断文件是否为支持的 Python 依赖清单（不含锁文件）
+func IsPythonManifest(file string) bool {
+	name := strings.ToLower(path.Base(file))
+	switch name {
+	case "pyproject.toml", "setup.cfg", "setup.py", "pipfile":
+		return true
+	}
+	if !strings.HasSuffix(name, ".txt") {
+		return false
+	}
+	// requirements.txt、requirements-dev.txt 以及 requirements/ 目录下的文件
+	return strings.HasPrefix(name, "requirements") || strings.ToLower(path.Base(path.Dir(file))) == "requirements"
+}
+
+// IsPythonLock 判断文件是否为支持的 Python 锁文件（Pipfile.lock、poetry.lock）
+func IsPythonLock(file string) bool {
+	name := strings.ToLower(path.Base(file))
+	return name == "pipfile.lock" || name == "poetry.lock"
+}
+
+// ParsePythonProject 解析 requirements*.txt、pyproject.toml（PEP 621、PEP 735 与 Poetry）、setup.cfg、
+// setup.py 的 install_requires 和 Pipfile 中声明的依赖，包名按 PEP 503 规范化；
+// 同目录存在 Pipfile.lock 或 poetry.lock 时使用锁定的版本，锁文件中未被同目录清单声明的包也会输出
+func ParsePythonProject(files []string, read ReadFunc) []camodels.Dependency {
+	locks := make(map[string]map[string]string)
+	var lockFiles []string
+	for _, file := range files {
+		if !IsPythonLock(file) {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		versions := parsePythonLock(file, content)
+		if len(versions) == 0 {
+			continue
+		}
+		dir := path.Dir(file)
+		if locks[dir] == nil {
+			locks[dir] = make(map[string]string)
+		}
+		for name, version := range versions {
+			locks[dir][name] = version
+		}
+		lockFiles = append(lockFiles, file)
+	}
+
+	var result []camodels.Dependency
+	declared := make(map[string]bool) // dir/name
+	for _, file := range files {
+		if !IsPythonManifest(file) {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		dir := path.Dir(file)
+		for _, dependency := range parsePythonManifest(file, content) {
+			dependency.Ecosystem = camodels.EcosystemPyPI
+			dependency.File = file
+			if version := locks[dir][dependency.Name]; version != "" {
+				dependency.Version = version
+				dependency.Source = camodels.VersionSourceResolved
+			} else {
+				dependency.Version, dependency.Source = classifyPythonSpec(dependency.Raw)
+			}
+			declared[dir+"/"+dependency.Name] = true
+			result = append(result, dependency)
+		}
+	}
+
+	// 只有锁文件时（或锁文件中的间接依赖），以锁文件作为来源输出
+	for _, file := range lockFiles {
+		dir := path.Dir(file)
+		versions := locks[dir]
+		for _, name := range sortedKeys(versions) {
+			if declared[dir+"/"+name] {
+				continue
+			}
+			declared[dir+"/"+name] = true
+			result = append(result, camodels.Dependency{
+				Ecosystem: camodels.EcosystemPyPI,
+				Name:      name,
+				Version:   versions[name],
+				Source:    camodels.VersionSourceResolved,
+				File:      file,
+			})
+		}
+	}
+	return result
+}
+
+// parsePythonManifest 根据文件名解析单个清单，返回的依赖只填充 Name、Raw、Scope
+func parsePythonManifest(file string, content []byte) []camodels.Dependency {
+	name := strings.ToLower(path.Base(file))
+	switch name {
+	case "pyproject.toml":
+		return parsePyProject(content)
+	case "setup.cfg":
+		return parseSetupCfg(content)
+	case "setup.py":
+		return parseSetupPy(content)
+	case "pipfile":
+		return parsePipfile(content)
+	}
+	scope := "prod"
+	if strings.Contains(name, "dev") || strings.Contains(name, "test") {
+		scope = "dev"
+	}
+	return parseRequirements(content, scope)
+}
+
+// parsePEP508 解析 PEP 508 依赖声明，返回规范化的名称和版本约束；
+// 环境标记被忽略，直接引用（name @ url）的约束为空
+func parsePEP508(requirement string) (string, string, bool) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+	match := pep508Re.FindStringSubmatch(strings.TrimSpace(requirement))
+	if match == nil {
+		return "", "", false
+	}
+	spec := strings.TrimSpace(match[2])
+	if strings.HasPrefix(spec, "@") {
+		spec = ""
+	}
+	spec = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(spec, "("), ")"))
+	return NormalizePythonName(match[1]), strings.ReplaceAll(spec, " ", ""), true
+}
+
+// classifyPythonSpec 根据版本约束判断来源：精确版本为 pinned，存在下界的约束取下界视为 range，
+// "*"、只有上界或排除的约束返回空版本
+func classifyPythonSpec(spec string) (string, string) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "*" {
+		return "", ""
+	}
+	if match := pythonPinnedRe.FindStringSubmatch(spec); match != nil {
+		return match[1], camodels.VersionSourcePinned
+	}
+	if match := pythonLowerBoundRe.FindStringSubmatch(spec); match != nil {
+		return match[1], camodels.VersionSourceRange
+	}
+	return "", ""
+}
+
+// parseRequirements 解析 requirements 文件，忽略注释、选项行（-r、-e、--hash 等）和续行符
+func parseRequirements(content []byte, scope string) []camodels.Dependency {
+	text := strings.ReplaceAll(strings.ReplaceAll(string(content), "\r\n", "\n"), "\\\n", " ")
+	var result []camodels.Dependency
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		// 去除行内选项，例如 --hash=sha256:...
+		if idx := strings.Index(line, " --"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if name, spec, ok := parsePEP508(line); ok {
+			result = append(result, camodels.Dependency{Name: name, Raw: spec, Scope: scope})
+		}
+	}
+	return result
+}
+
+// parsePyProject 解析 pyproject.toml 中 PEP 621（[project]）、PEP 735（[dependency-groups]）和 Poetry 声明的依赖
+func parsePyProject(content []byte) []camodels.Dependency {
+	var result []camodels.Dependency
+	addRequirements := func(value, scope string) {
+		for _, requirement := range parseTOMLArray(value) {
+			if name, spec, ok := parsePEP508(requirement); ok {
+				result = append(result, camodels.Dependency{Name: name, Raw: spec, Scope: scope})
+			}
+		}
+	}
+	for _, table := range parseTOMLTables(content) {
+		switch {
+		case table.name == "project":
+			addRequirements(table.get("dependencies"), "prod")
+		case table.name == "project.optional-dependencies":
+			for _, e := range table.entries {
+				addRequirements(e.value, "optional")
+			}
+		case table.name == "dependency-groups":
+			for _, e := range table.entries {
+				addRequirements(e.value, e.key)
+			}
+		case table.name == "tool.poetry.dependencies":
+			result = append(result, poetryDependencies(table, "prod")...)
+		case table.name == "tool.poetry.dev-dependencies":
+			result = append(result, poetryDependencies(table, "dev")...)
+		case strings.HasPrefix(table.name, "tool.poetry.group.") && strings.HasSuffix(table.name, ".dependencies"):
+			group := strings.TrimSuffix(strings.TrimPrefix(table.name, "tool.poetry.group."), ".dependencies")
+			result = append(result, poetryDependencies(table, group)...)
+		}
+	}
+	return result
+}
+
+// poetryDependencies 解析 Poetry 和 Pipfile 风格的依赖表：值为版本约束字符串或包含 version 的内联表，忽略 python 本身
+func poetryDependencies(table tomlTable, scope string) []camodels.Dependency {
+	var result []camodels.Dependency
+	for _, e := range table.entries {
+		name := NormalizePythonName(e.key)
+		if name == "python" {
+			continue
+		}
+		value := e.value
+		// 多约束写法 [{ version = "..." }, ...] 取第一个
+		if strings.HasPrefix(value, "[") {
+			if items := splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]"), ','); len(items) > 0 {
+				value = strings.TrimSpace(items[0])
+			}
+		}
+		spec := unquote(value)
+		if strings.HasPrefix(value, "{") {
+			spec = parseInlineTable(value)["version"]
+		}
+		result = append(result, camodels.Dependency{Name: name, Raw: strings.ReplaceAll(spec, " ", ""), Scope: scope})
+	}
+	return result
+}
+
+// parsePipfile 解析 Pipfile 的 [packages] 和 [dev-packages]
+func parsePipfile(content []byte) []camodels.Dependency {
+	var result []camodels.Dependency
+	for _, table := range parseTOMLTables(content) {
+		switch table.name {
+		case "packages":
+			result = append(result, poetryDependencies(table, "prod")...)
+		case "dev-packages":
+			result = append(result, poetryDependencies(table, "dev")...)
+		}
+	}
+	return result
+}
+
+// parseSetupCfg 解析 setup.cfg 中 [options] 的 install_requires 和 [options.extras_require]
+func parseSetupCfg(content []byte) []camodels.Dependency {
+	var result []camodels.Dependency
+	section, key := "", ""
+	add := func(value string) {
+		scope := ""
+		switch {
+		case section == "options" && key == "install_requires":
+			scope = "prod"
+		case section == "options.extras_require":
+			scope = "optional"
+		default:
+			return
+		}
+		if name, spec, ok := parsePEP508(value); ok {
+			result = append(result, camodels.Dependency{Name: name, Raw: spec, Scope: scope})
+		}
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section, key = strings.ToLower(strings.Trim(trimmed, "[] ")), ""
+			continue
+		}
+		// 缩进行为上一个键的续行，每行一个依赖
+		if line[0] == ' ' || line[0] == '\t' {
+			if key != "" {
+				add(trimmed)
+			}
+			continue
+		}
+		k, v, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			key = ""
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(k))
+		if v = strings.TrimSpace(v); v != "" {
+			add(v)
+		}
+	}
+	return result
+}
+
+// parseSetupPy 解析 setup.py 中 install_requires 列表的字符串字面量
+func parseSetupPy(content []byte) []camodels.Dependency {
+	text := string(content)
+	loc := setupRequiresRe.FindStringIndex(text)
+	if loc == nil {
+		return nil
+	}
+	// 找到与 [ 匹配的 ]，跳过字符串中的括号（例如 "uvicorn[standard]"）
+	body := text[loc[1]-1:]
+	for i := range body {
+		if bracketDepth(body[:i+1]) == 0 {
+			body = body[:i+1]
+			break
+		}
+	}
+	var result []camodels.Dependency
+	for _, match := range pythonStringRe.FindAllStringSubmatch(body, -1) {
+		requirement := match[1] + match[2]
+		if name, spec, ok := parsePEP508(requirement); ok {
+			result = append(result, camodels.Dependency{Name: name, Raw: spec, Scope: "prod"})
+		}
+	}
+	return result
+}
+
+// parsePythonLock 解析 Pipfile.lock（JSON）或 poetry.lock（TOML），返回规范化包名到锁定版本的映射
+func parsePythonLock(file string, content []byte) map[string]string {
+	versions := make(map[string]string)
+	if strings.ToLower(path.Base(file)) == "pipfile.lock" {
+		var lock map[string]json.RawMessage
+		if err := json.Unmarshal(content, &lock); err != nil {
+			slogs.Debugf("parse Pipfile.lock (%s) error: %v", file, err)
+			return nil
+		}
+		for _, section := range []string{"default", "develop"} {
+			var packages map[string]struct {
+				Version string `json:"version"`
+			}
+			if json.Unmarshal(lock[section], &packages) != nil {
+				continue
+			}
+			for name, pkg := range packages {
+				if version := strings.TrimLeft(pkg.Version, "="); version != "" {
+					if _, ok := versions[NormalizePythonName(name)]; !ok {
+						versions[NormalizePythonName(name)] = version
+					}
+				}
+			}
+		}
+		return versions
+	}
+	for _, table := range parseTOMLTables(content) {
+		if table.name != "package" || !table.array {
+			continue
+		}
+		name, version := unquote(table.get("name")), unquote(table.get("version"))
+		if name != "" && version != "" {
+			versions[NormalizePythonName(name)] = version
+		}
+	}
+	return versions
+}
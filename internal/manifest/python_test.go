@@ -0,0 +1,152 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestParsePythonProject tests requirements, pyproject (PEP 621 and Poetry), setup.cfg, setup.py, Pipfile and lock files
+func TestParsePythonProject(t *testing.T) {
+	files := map[string]string{
+		"req/requirements.txt": `# 注释
+-r requirements/base.txt
+Django>=4.2,<5.0  # web
+Flask_Login == 0.6.3 ; python_version >= "3.8"
+uvicorn[standard]>=0.23 \
+    --hash=sha256:abc
+mypkg @ https://example.com/mypkg.whl
+-e git+https://github.com/x/y.git#egg=y
+requests`,
+		"req/requirements-dev.txt":  `pytest~=7.4`,
+		"req/requirements/base.txt": `zope.interface==6.0`,
+		"pep621/pyproject.toml": `[project]
+name = "demo"
+description = """
+[not a table]
+"""
+dependencies = [
+    "fastapi[all]>=0.100",
+    "SQLAlchemy (>=2.0)",
+]
+
+[project.optional-dependencies]
+docs = ["sphinx>=7"]
+
+[dependency-groups]
+test = ["pytest>=8"]`,
+		"poetry/pyproject.toml": `[tool.poetry.dependencies]
+python = "^3.10"
+django = "^4.2"
+celery = { version = "5.3.4", extras = ["redis"] }
+numpy = [
+    { version = "1.24", python = "<3.12" },
+    { version = "1.26", python = ">=3.12" },
+]
+
+[tool.poetry.group.dev.dependencies]
+black = "*"`,
+		"poetry/poetry.lock": `[[package]]
+name = "Django"
+version = "4.2.7"
+
+[package.dependencies]
+asgiref = ">=3.6.0,<4"
+
+[[package]]
+name = "asgiref"
+version = "3.7.2"`,
+		"cfg/setup.cfg": `[metadata]
+name = demo
+
+[options]
+install_requires =
+    tornado>=6.3
+    PyYAML==6.0.1; python_version>"3"
+python_requires = >=3.8
+
+[options.extras_require]
+dev =
+    flake8`,
+		"cfg/setup.py": `from setuptools import setup
+setup(
+    name="demo",
+    install_requires=["sanic[ext]>=23.6", 'click'],
+)`,
+		"pipenv/Pipfile": `[packages]
+flask = "==2.3.3"
+requests = {version = "*", extras = ["socks"]}
+
+[dev-packages]
+pytest = "*"`,
+		"pipenv/Pipfile.lock": `{"default": {"flask": {"version": "==2.3.3"}, "requests": {"version": "==2.31.0"}}, "develop": {"pytest": {"version": "==7.4.3"}}}`,
+	}
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	dependencies := ParsePythonProject(paths, mapReader(files))
+
+	testCases := []struct {
+		name, file, version, raw, source, scope string
+	}{
+		{"django", "req/requirements.txt", "4.2", ">=4.2,<5.0", camodels.VersionSourceRange, "prod"},
+		{"flask-login", "req/requirements.txt", "0.6.3", "==0.6.3", camodels.VersionSourcePinned, "prod"},
+		{"uvicorn", "req/requirements.txt", "0.23", ">=0.23", camodels.VersionSourceRange, "prod"},
+		{"mypkg", "req/requirements.txt", "", "", "", "prod"},
+		{"requests", "req/requirements.txt", "", "", "", "prod"},
+		{"pytest", "req/requirements-dev.txt", "7.4", "~=7.4", camodels.VersionSourceRange, "dev"},
+		{"zope-interface", "req/requirements/base.txt", "6.0", "==6.0", camodels.VersionSourcePinned, "prod"},
+		{"fastapi", "pep621/pyproject.toml", "0.100", ">=0.100", camodels.VersionSourceRange, "prod"},
+		{"sqlalchemy", "pep621/pyproject.toml", "2.0", ">=2.0", camodels.VersionSourceRange, "prod"},
+		{"sphinx", "pep621/pyproject.toml", "7", ">=7", camodels.VersionSourceRange, "optional"},
+		{"pytest", "pep621/pyproject.toml", "8", ">=8", camodels.VersionSourceRange, "test"},
+		{"django", "poetry/pyproject.toml", "4.2.7", "^4.2", camodels.VersionSourceResolved, "prod"},
+		{"celery", "poetry/pyproject.toml", "5.3.4", "5.3.4", camodels.VersionSourcePinned, "prod"},
+		{"numpy", "poetry/pyproject.toml", "1.24", "1.24", camodels.VersionSourcePinned, "prod"},
+		{"black", "poetry/pyproject.toml", "", "*", "", "dev"},
+		{"asgiref", "poetry/poetry.lock", "3.7.2", "", camodels.VersionSourceResolved, ""},
+		{"tornado", "cfg/setup.cfg", "6.3", ">=6.3", camodels.VersionSourceRange, "prod"},
+		{"pyyaml", "cfg/setup.cfg", "6.0.1", "==6.0.1", camodels.VersionSourcePinned, "prod"},
+		{"flake8", "cfg/setup.cfg", "", "", "", "optional"},
+		{"sanic", "cfg/setup.py", "23.6", ">=23.6", camodels.VersionSourceRange, "prod"},
+		{"click", "cfg/setup.py", "", "", "", "prod"},
+		{"flask", "pipenv/Pipfile", "2.3.3", "==2.3.3", camodels.VersionSourceResolved, "prod"},
+		{"requests", "pipenv/Pipfile", "2.31.0", "*", camodels.VersionSourceResolved, "prod"},
+		{"pytest", "pipenv/Pipfile", "7.4.3", "*", camodels.VersionSourceResolved, "dev"},
+	}
+	for _, tc := range testCases {
+		dependency := findDependency(dependencies, tc.name, tc.file)
+		if dependency == nil {
+			t.Errorf("dependency %s in %s not found", tc.name, tc.file)
+			continue
+		}
+		if dependency.Version != tc.version || dependency.Raw != tc.raw || dependency.Source != tc.source ||
+			dependency.Scope != tc.scope || dependency.Ecosystem != camodels.EcosystemPyPI {
+			t.Errorf("dependency %s = %+v, want version=%q raw=%q source=%q scope=%q", tc.name, *dependency, tc.version, tc.raw, tc.source, tc.scope)
+		}
+	}
+
+	for _, dependency := range dependencies {
+		switch dependency.Name {
+		case "python", "y", "python-requires":
+			t.Errorf("unexpected dependency reported: %+v", dependency)
+		}
+		if dependency.Name == "django" && dependency.File == "poetry/poetry.lock" {
+			t.Errorf("declared dependency reported again from lock file: %+v", dependency)
+		}
+	}
+}
+
+func TestNormalizePythonName(t *testing.T) {
+	for input, want := range map[string]string{
+		"Django":         "django",
+		"Flask_Login":    "flask-login",
+		"zope.interface": "zope-interface",
+		"a-_.b":          "a-b",
+	} {
+		if got := NormalizePythonName(input); got != want {
+			t.Errorf("NormalizePythonName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
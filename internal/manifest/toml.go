@@ -0,0 +1,186 @@
+package manifest
+
+import "strings"
+
+// tomlEntry TOML 键值对，value 为未解析的原始值
+type tomlEntry struct{ key, value string }
+
+// tomlTable TOML 表，[[name]] 表数组的每个元素各为一个表
+type tomlTable struct {
+	name    string
+	array   bool
+	entries []tomlEntry
+}
+
+// get 返回键对应的原始值，不存在时返回空字符串
+func (t tomlTable) get(key string) string {
+	for _, e := range t.entries {
+		if e.key == key {
+			return e.value
+		}
+	}
+	return ""
+}
+
+// parseTOMLTables 按出现顺序解析 TOML 表，只支持依赖清单中用到的子集：
+// 表头、键值对、字符串、单行内联表和可跨行的数组，跨行字符串会被跳过
+func parseTOMLTables(content []byte) []tomlTable {
+	tables := []tomlTable{{}}
+	var pending *tomlEntry
+	multiline := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		// 跳过跨行字符串，直到遇到结束引号
+		if multiline != "" {
+			if strings.Contains(line, multiline) {
+				multiline = ""
+			}
+			continue
+		}
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if line == "" {
+			continue
+		}
+		current := &tables[len(tables)-1]
+		// 跨行数组，持续拼接直到括号闭合
+		if pending != nil {
+			pending.value += " " + line
+			if bracketDepth(pending.value) <= 0 {
+				current.entries = append(current.entries, *pending)
+				pending = nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") && !strings.Contains(line, "=") {
+			tables = append(tables, tomlTable{name: strings.Trim(line, "[] "), array: strings.HasPrefix(line, "[[")})
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		e := tomlEntry{key: unquote(key), value: strings.TrimSpace(value)}
+		for _, quote := range []string{`"""`, `'''`} {
+			if strings.HasPrefix(e.value, quote) && !strings.Contains(e.value[len(quote):], quote) {
+				multiline = quote
+			}
+		}
+		if multiline != "" {
+			continue
+		}
+		if strings.HasPrefix(e.value, "[") && bracketDepth(e.value) > 0 {
+			pending = &e
+			continue
+		}
+		current.entries = append(current.entries, e)
+	}
+	return tables
+}
+
+// parseTOMLArray 解析字符串数组，返回去除引号后的非空元素
+func parseTOMLArray(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") {
+		return nil
+	}
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var result []string
+	for _, item := range splitTopLevel(value, ',') {
+		if item = unquote(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// bracketDepth 返回引号外未闭合的方括号层数
+func bracketDepth(s string) int {
+	depth := 0
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+// parseInlineTable 解析单行内联表，嵌套表展开为点分键，例如 version = { prefer = "1" } -> version.prefer
+func parseInlineTable(value string) map[string]string {
+	result := make(map[string]string)
+	body := strings.TrimSpace(value)
+	body = strings.TrimSuffix(strings.TrimPrefix(body, "{"), "}")
+	for _, item := range splitTopLevel(body, ',') {
+		key, val, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		val = strings.TrimSpace(val)
+		if strings.HasPrefix(val, "{") {
+			for k, v := range parseInlineTable(val) {
+				result[key+"."+k] = v
+			}
+			continue
+		}
+		result[key] = unquote(val)
+	}
+	return result
+}
+
+// splitTopLevel 按分隔符切分字符串，忽略引号和括号内部的分隔符
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '{' || r == '[' || r == '(':
+			depth++
+		case r == '}' || r == ']' || r == ')':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// stripTOMLComment 去除引号外的 # 注释
+func stripTOMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquote 去除两端空白和引号
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), `"'`)
+}
@@ -0,0 +1,156 @@
+// Package policy 加载 YAML 策略文件，并按 deny / warn 规则评估分析结果中的框架、组件、版本、语言和分类。
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// Load 读取并校验策略文件：动作只能为 deny 或 warn（默认 deny），每条规则至少设置一个条件，
+// 名称通配符和版本范围必须能够解析
+func Load(file string) (*camodels.Policy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file %s error: %w", file, err)
+	}
+	policy := &camodels.Policy{}
+	if err := yaml.Unmarshal(content, policy); err != nil {
+		return nil, fmt.Errorf("parse policy file %s error: %w", file, err)
+	}
+	for i := range policy.Rules {
+		if err := normalizeRule(&policy.Rules[i], i); err != nil {
+			return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+		}
+	}
+	return policy, nil
+}
+
+// normalizeRule 填充默认值并校验规则
+func normalizeRule(rule *camodels.PolicyRule, index int) error {
+	if rule.ID == "" {
+		rule.ID = "rule-" + strconv.Itoa(index+1)
+	}
+	rule.Action = strings.ToLower(strings.TrimSpace(rule.Action))
+	switch rule.Action {
+	case "":
+		rule.Action = camodels.PolicyActionDeny
+	case camodels.PolicyActionDeny, camodels.PolicyActionWarn:
+	default:
+		return fmt.Errorf("rule %s: unknown action %q (deny or warn)", rule.ID, rule.Action)
+	}
+	if rule.Name == "" && rule.Type == "" && rule.Language == "" && rule.Category == "" && rule.Version == "" && rule.EOL == nil {
+		return fmt.Errorf("rule %s: no condition", rule.ID)
+	}
+	if _, err := path.Match(strings.ToLower(rule.Name), ""); err != nil {
+		return fmt.Errorf("rule %s: invalid name pattern %q: %w", rule.ID, rule.Name, err)
+	}
+	if rule.Version != "" {
+		if _, err := camodels.ParseVersionRange(rule.Version, ""); err != nil {
+			return fmt.Errorf("rule %s: invalid version range %q: %w", rule.ID, rule.Version, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate 按规则顺序评估报告，返回全部违规结果；规则内的条件为 AND 关系
+func Evaluate(policy *camodels.Policy, report *camodels.CanvasReport) []camodels.Violation {
+	var violations []camodels.Violation
+	for _, rule := range policy.Rules {
+		if languageOnly(rule) {
+			for _, language := range report.CodeProfile.Languages {
+				if strings.EqualFold(language, rule.Language) {
+					violations = append(violations, newViolation(rule, language, "language"))
+				}
+			}
+			continue
+		}
+		for _, items := range [][]camodels.DetectedItem{report.Detection.Frameworks, report.Detection.Components} {
+			for i := range items {
+				item := &items[i]
+				version, ok := matchItem(rule, item)
+				if !ok {
+					continue
+				}
+				violation := newViolation(rule, item.Name, item.Type)
+				violation.Version = version
+				violation.Language = item.Language
+				violation.Category = item.Category
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations
+}
+
+// HasAction 判断违规结果中是否包含指定动作
+func HasAction(violations []camodels.Violation, action string) bool {
+	for _, violation := range violations {
+		if violation.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// languageOnly 规则是否只包含语言条件，此时对项目检测到的语言评估
+func languageOnly(rule camodels.PolicyRule) bool {
+	return rule.Language != "" && rule.Name == "" && rule.Type == "" && rule.Category == "" && rule.Version == "" && rule.EOL == nil
+}
+
+// matchItem 判断检测项是否满足规则的全部条件，满足时返回命中的版本（规则没有版本条件时为主版本）
+func matchItem(rule camodels.PolicyRule, item *camodels.DetectedItem) (string, bool) {
+	if rule.Name != "" {
+		if matched, _ := path.Match(strings.ToLower(rule.Name), strings.ToLower(item.Name)); !matched {
+			return "", false
+		}
+	}
+	if rule.Type != "" && !strings.EqualFold(rule.Type, item.Type) {
+		return "", false
+	}
+	if rule.Language != "" && !strings.EqualFold(rule.Language, item.Language) {
+		return "", false
+	}
+	if rule.Category != "" && !strings.EqualFold(rule.Category, item.Category) {
+		return "", false
+	}
+	if rule.EOL != nil && *rule.EOL != item.EOL {
+		return "", false
+	}
+	if rule.Version == "" {
+		return item.Version, true
+	}
+	return matchVersion(rule.Version, item)
+}
+
+// matchVersion 返回检测项中第一个位于范围内的版本，范围使用 npm 语法，按检测项语言对应生态的排序规则比较；
+// 没有版本的检测项不满足版本条件
+func matchVersion(raw string, item *camodels.DetectedItem) (string, bool) {
+	versionRange, err := camodels.ParseVersionRange(raw, "")
+	if err != nil {
+		return "", false
+	}
+	versionRange.Ecosystem = camodels.EcosystemForLanguage(item.Language)
+	for _, version := range item.AllVersions() {
+		if versionRange.Contains(strings.TrimPrefix(version, "v")) {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+func newViolation(rule camodels.PolicyRule, target, targetType string) camodels.Violation {
+	return camodels.Violation{
+		RuleID:  rule.ID,
+		Action:  rule.Action,
+		Message: rule.Message,
+		Target:  target,
+		Type:    targetType,
+	}
+}
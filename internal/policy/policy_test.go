@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+const testPolicy = `
+rules:
+  - id: fastjson-vulnerable
+    name: fastjson
+    version: "<1.2.83"
+    message: upgrade fastjson to 1.2.83 or later
+  - id: no-eol-frameworks
+    type: framework
+    eol: true
+  - id: log4j-family
+    action: warn
+    name: "log4j*"
+  - id: no-php
+    action: warn
+    language: php
+  - id: frontend-vue
+    category: frontend
+    name: vue.js
+    action: WARN
+`
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "policy.yml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func testReport() *camodels.CanvasReport {
+	return &camodels.CanvasReport{
+		CodeProfile: camodels.CodeProfile{Languages: []string{"Java", "PHP", "JavaScript"}},
+		Detection: camodels.DetectionInfo{
+			Frameworks: []camodels.DetectedItem{
+				{Name: "Spring Boot", Type: "framework", Language: "Java", Category: "backend", Version: "1.5.9.RELEASE", EOL: true},
+				{Name: "Vue.js", Type: "framework", Language: "JavaScript", Category: "frontend", Version: "3.4.0"},
+			},
+			Components: []camodels.DetectedItem{
+				{Name: "fastjson", Type: "component", Language: "Java", Category: "backend", Version: "1.2.83",
+					Versions: []*camodels.VersionInfo{{Version: "1.2.83"}, {Version: "1.2.47"}}},
+				{Name: "log4j-core", Type: "component", Language: "Java", Category: "backend", Version: "2.17.1"},
+				{Name: "Apache Struts", Type: "component", Language: "Java", Category: "backend", EOL: true},
+			},
+		},
+	}
+}
+
+// TestEvaluate tests rule conditions, defaults and the targets of language-only rules
+func TestEvaluate(t *testing.T) {
+	policy, err := Load(writePolicy(t, testPolicy))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	violations := Evaluate(policy, testReport())
+
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.RuleID+"/"+v.Action+"/"+v.Target+"@"+v.Version)
+	}
+	want := []string{
+		// 主版本不在范围内，但检测到的其他版本在范围内
+		"fastjson-vulnerable/deny/fastjson@1.2.47",
+		// 组件即使已停止支持也不满足 type 条件
+		"no-eol-frameworks/deny/Spring Boot@1.5.9.RELEASE",
+		"log4j-family/warn/log4j-core@2.17.1",
+		"no-php/warn/PHP@",
+		"frontend-vue/warn/Vue.js@3.4.0",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Evaluate() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if violations[0].Message != "upgrade fastjson to 1.2.83 or later" || violations[0].Language != "Java" {
+		t.Errorf("violation = %+v", violations[0])
+	}
+	if !HasAction(violations, camodels.PolicyActionDeny) || HasAction(violations[2:], camodels.PolicyActionDeny) {
+		t.Errorf("HasAction() mismatch")
+	}
+}
+
+// TestEvaluateMavenOrdering tests that versions are compared with the ordering of the item's ecosystem
+func TestEvaluateMavenOrdering(t *testing.T) {
+	policy := &camodels.Policy{Rules: []camodels.PolicyRule{{ID: "r", Action: camodels.PolicyActionDeny, Name: "spring boot", Version: "<2.0.0"}}}
+	report := testReport()
+	if violations := Evaluate(policy, report); len(violations) != 1 || violations[0].Version != "1.5.9.RELEASE" {
+		t.Errorf("Evaluate() = %+v, want Spring Boot 1.5.9.RELEASE", violations)
+	}
+	// 没有版本的检测项不满足版本条件
+	policy.Rules[0].Name = "apache struts"
+	if violations := Evaluate(policy, report); len(violations) != 0 {
+		t.Errorf("Evaluate() = %+v, want none", violations)
+	}
+}
+
+// TestLoadInvalid tests validation of policy files
+func TestLoadInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"action":    "rules:\n  - name: gin\n    action: block\n",
+		"condition": "rules:\n  - id: empty\n    message: nothing\n",
+		"version":   "rules:\n  - name: gin\n    version: \">=1.0 <<2\"\n",
+		"pattern":   "rules:\n  - name: \"[gin\"\n",
+		"yaml":      "rules: [",
+	}
+	for name, content := range testCases {
+		if _, err := Load(writePolicy(t, content)); err == nil {
+			t.Errorf("%s: Load() error = nil, want error", name)
+		}
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("Load() of missing file error = nil, want error")
+	}
+}
@@ -0,0 +1,163 @@
+// Package reportdiff 将两次分析结果的差异输出为文本、JSON 或 Markdown。
+package reportdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// JSON 返回缩进的 JSON 格式差异
+func JSON(diff *camodels.ReportDiff) ([]byte, error) {
+	return json.MarshalIndent(diff, "", "  ")
+}
+
+// Text 返回适合终端阅读的文本格式差异
+func Text(diff *camodels.ReportDiff) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Old: %s\n", sideSummary(diff.Old))
+	fmt.Fprintf(&buf, "New: %s\n", sideSummary(diff.New))
+	buf.WriteString("\n")
+	if diff.IsEmpty() {
+		buf.WriteString("No differences\n")
+		return buf.Bytes()
+	}
+
+	if len(diff.AddedLanguages) > 0 || len(diff.RemovedLanguages) > 0 || len(diff.LanguageChanges) > 0 {
+		buf.WriteString("Languages:\n")
+		for _, name := range diff.AddedLanguages {
+			fmt.Fprintf(&buf, "  + %s\n", name)
+		}
+		for _, name := range diff.RemovedLanguages {
+			fmt.Fprintf(&buf, "  - %s\n", name)
+		}
+		for _, change := range diff.LanguageChanges {
+			fmt.Fprintf(&buf, "  ~ %s: %s\n", change.Name, lineChange(change))
+		}
+		buf.WriteString("\n")
+	}
+	writeItemsText(&buf, "Frameworks", diff.Frameworks)
+	writeItemsText(&buf, "Components", diff.Components)
+	return buf.Bytes()
+}
+
+func writeItemsText(buf *bytes.Buffer, title string, items camodels.ItemsDiff) {
+	if items.IsEmpty() {
+		return
+	}
+	buf.WriteString(title + ":\n")
+	for _, item := range items.Added {
+		fmt.Fprintf(buf, "  + %s\n", itemSummary(item))
+	}
+	for _, item := range items.Removed {
+		fmt.Fprintf(buf, "  - %s\n", itemSummary(item))
+	}
+	for _, change := range items.Changed {
+		fmt.Fprintf(buf, "  ~ %s (%s): %s -> %s [%s]\n", change.Name, change.Language,
+			versionOrNone(change.OldVersion), versionOrNone(change.NewVersion), change.Direction)
+	}
+	buf.WriteString("\n")
+}
+
+// Markdown 返回 Markdown 格式差异，便于贴到发布说明或合并请求中
+func Markdown(diff *camodels.ReportDiff) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Tech Stack Diff\n\n")
+	buf.WriteString("| | Report |\n|---|---|\n")
+	fmt.Fprintf(&buf, "| Old | %s |\n", escapeCell(sideSummary(diff.Old)))
+	fmt.Fprintf(&buf, "| New | %s |\n\n", escapeCell(sideSummary(diff.New)))
+	if diff.IsEmpty() {
+		buf.WriteString("No differences.\n")
+		return buf.Bytes()
+	}
+
+	if len(diff.AddedLanguages) > 0 || len(diff.RemovedLanguages) > 0 || len(diff.LanguageChanges) > 0 {
+		buf.WriteString("## Languages\n\n")
+		for _, name := range diff.AddedLanguages {
+			fmt.Fprintf(&buf, "- Added: %s\n", name)
+		}
+		for _, name := range diff.RemovedLanguages {
+			fmt.Fprintf(&buf, "- Removed: %s\n", name)
+		}
+		if len(diff.LanguageChanges) > 0 {
+			if len(diff.AddedLanguages) > 0 || len(diff.RemovedLanguages) > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("| Language | Files | Code Lines | Change |\n|---|---|---|---|\n")
+			for _, change := range diff.LanguageChanges {
+				fmt.Fprintf(&buf, "| %s | %d → %d | %d → %d | %+d (%+.1f%%) |\n", escapeCell(change.Name),
+					change.OldFiles, change.NewFiles, change.OldCodeLines, change.NewCodeLines, change.CodeLineDelta, change.Percent)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	writeItemsMarkdown(&buf, "Frameworks", diff.Frameworks)
+	writeItemsMarkdown(&buf, "Components", diff.Components)
+	return buf.Bytes()
+}
+
+func writeItemsMarkdown(buf *bytes.Buffer, title string, items camodels.ItemsDiff) {
+	if items.IsEmpty() {
+		return
+	}
+	fmt.Fprintf(buf, "## %s\n\n| Change | Name | Language | Old Version | New Version |\n|---|---|---|---|---|\n", title)
+	for _, item := range items.Added {
+		writeRow(buf, "added", item.Name, item.Language, "", item.Version)
+	}
+	for _, item := range items.Removed {
+		writeRow(buf, "removed", item.Name, item.Language, item.Version, "")
+	}
+	for _, change := range items.Changed {
+		writeRow(buf, change.Direction, change.Name, change.Language, change.OldVersion, change.NewVersion)
+	}
+	buf.WriteString("\n")
+}
+
+// sideSummary 返回报告的概要，例如 "/src/app (1200 files, 98000 lines, 2026-01-02 15:04:05 UTC, xcanvas 0.2.3)"
+func sideSummary(side camodels.DiffSide) string {
+	parts := []string{fmt.Sprintf("%d files", side.TotalFiles), fmt.Sprintf("%d lines", side.TotalLines)}
+	if !side.Timestamp.IsZero() {
+		parts = append(parts, side.Timestamp.UTC().Format(time.DateTime+" MST"))
+	}
+	if side.Version != "" {
+		parts = append(parts, "xcanvas "+side.Version)
+	}
+	return fmt.Sprintf("%s (%s)", side.Path, strings.Join(parts, ", "))
+}
+
+func itemSummary(item camodels.DetectedItem) string {
+	summary := item.Name
+	if item.Version != "" {
+		summary += " " + item.Version
+	}
+	return fmt.Sprintf("%s (%s)", summary, item.Language)
+}
+
+func lineChange(change camodels.LanguageChange) string {
+	return fmt.Sprintf("%d -> %d code lines (%+d, %+.1f%%), %d -> %d files",
+		change.OldCodeLines, change.NewCodeLines, change.CodeLineDelta, change.Percent, change.OldFiles, change.NewFiles)
+}
+
+func versionOrNone(version string) string {
+	if version == "" {
+		return "(none)"
+	}
+	return version
+}
+
+// writeRow 输出一行 Markdown 表格
+func writeRow(buf *bytes.Buffer, cells ...string) {
+	for i, cell := range cells {
+		cells[i] = escapeCell(cell)
+	}
+	buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+// escapeCell 转义 Markdown 表格单元格中的竖线
+func escapeCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,81 @@
+package reportdiff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+func testDiff() *camodels.ReportDiff {
+	return &camodels.ReportDiff{
+		Old:              camodels.DiffSide{Path: "/src/app", TotalFiles: 10, TotalLines: 1000, Version: "0.2.2"},
+		New:              camodels.DiffSide{Path: "/src/app", TotalFiles: 12, TotalLines: 1500, Version: "0.2.3"},
+		AddedLanguages:   []string{"TypeScript"},
+		RemovedLanguages: []string{"PHP"},
+		LanguageChanges: []camodels.LanguageChange{
+			{Name: "JavaScript", OldFiles: 2, NewFiles: 4, OldCodeLines: 200, NewCodeLines: 600, CodeLineDelta: 400, Percent: 200},
+		},
+		Frameworks: camodels.ItemsDiff{
+			Added:   []camodels.DetectedItem{{Name: "Gin", Language: "Go", Version: "1.9.1"}},
+			Changed: []camodels.VersionChange{{Name: "Spring Boot", Language: "Java", OldVersion: "2.7.18", NewVersion: "3.2.5", Direction: camodels.VersionUpgrade}},
+		},
+		Components: camodels.ItemsDiff{
+			Removed: []camodels.DetectedItem{{Name: "jquery", Language: "JavaScript"}},
+			Changed: []camodels.VersionChange{{Name: "a|b", Language: "Java", OldVersion: "1.0", Direction: camodels.VersionChanged}},
+		},
+	}
+}
+
+// TestText tests the terminal output
+func TestText(t *testing.T) {
+	text := string(Text(testDiff()))
+	for _, want := range []string{
+		"Old: /src/app (10 files, 1000 lines, xcanvas 0.2.2)",
+		"Languages:\n  + TypeScript\n  - PHP\n  ~ JavaScript: 200 -> 600 code lines (+400, +200.0%), 2 -> 4 files\n",
+		"Frameworks:\n  + Gin 1.9.1 (Go)\n  ~ Spring Boot (Java): 2.7.18 -> 3.2.5 [upgrade]\n",
+		"Components:\n  - jquery (JavaScript)\n  ~ a|b (Java): 1.0 -> (none) [changed]\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q in\n%s", want, text)
+		}
+	}
+	if empty := string(Text(&camodels.ReportDiff{})); !strings.Contains(empty, "No differences") {
+		t.Errorf("Text(empty) = %s", empty)
+	}
+}
+
+// TestMarkdown tests the markdown tables and cell escaping
+func TestMarkdown(t *testing.T) {
+	markdown := string(Markdown(testDiff()))
+	for _, want := range []string{
+		"# Tech Stack Diff\n",
+		"- Added: TypeScript\n- Removed: PHP\n",
+		"| JavaScript | 2 → 4 | 200 → 600 | +400 (+200.0%) |\n",
+		"## Frameworks\n",
+		"| added | Gin | Go |  | 1.9.1 |\n",
+		"| upgrade | Spring Boot | Java | 2.7.18 | 3.2.5 |\n",
+		"| removed | jquery | JavaScript |  |  |\n",
+		`| changed | a\|b | Java | 1.0 |  |`,
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown() missing %q in\n%s", want, markdown)
+		}
+	}
+}
+
+// TestJSON tests that the JSON output round-trips
+func TestJSON(t *testing.T) {
+	data, err := JSON(testDiff())
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var diff camodels.ReportDiff
+	if err := json.Unmarshal(data, &diff); err != nil {
+		t.Fatalf("unmarshal error = %v", err)
+	}
+	if len(diff.Frameworks.Changed) != 1 || diff.Frameworks.Changed[0].Direction != camodels.VersionUpgrade {
+		t.Errorf("round-trip Frameworks.Changed = %+v", diff.Frameworks.Changed)
+	}
+}
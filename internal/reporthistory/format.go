@@ -0,0 +1,201 @@
+// Package reporthistory 将多个修订版本的技术栈时间线输出为文本、JSON 或 Markdown。
+package reporthistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// dateLayout 时间线中的日期格式
+const dateLayout = "2006-01-02"
+
+// maxLanguageColumns 语言占比表格最多显示的语言数量，其余语言合并为 Other
+const maxLanguageColumns = 6
+
+// JSON 返回缩进的 JSON 格式时间线
+func JSON(history *camodels.History) ([]byte, error) {
+	return json.MarshalIndent(history, "", "  ")
+}
+
+// Text 返回适合终端阅读的文本格式时间线
+func Text(history *camodels.History) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Technology Timeline: %s\n", history.Path)
+	buf.WriteString("=========================\n")
+	fmt.Fprintf(&buf, "Revisions: %d\n", len(history.Snapshots))
+	for _, snapshot := range history.Snapshots {
+		fmt.Fprintf(&buf, "  - %s  %s  %s  (%d files, %d lines)\n", snapshot.Revision.Ref, shortCommit(snapshot.Revision.Commit),
+			snapshot.Revision.CommitTime.Format(dateLayout), snapshot.TotalFiles, snapshot.TotalLines)
+	}
+	buf.WriteString("\n")
+
+	writeTimelinesText(&buf, "Frameworks", history.Frameworks)
+	writeTimelinesText(&buf, "Components", history.Components)
+
+	if len(history.Snapshots) > 0 {
+		buf.WriteString("Language Share (% of code lines):\n")
+		writer := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		for _, row := range languageTable(history) {
+			fmt.Fprintln(writer, "  "+strings.Join(row, "\t"))
+		}
+		writer.Flush()
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+func writeTimelinesText(buf *bytes.Buffer, title string, timelines []camodels.ItemTimeline) {
+	if len(timelines) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "%s: %d\n", title, len(timelines))
+	for _, timeline := range timelines {
+		fmt.Fprintf(buf, "  - %s (%s): %s\n", timeline.Name, timeline.Language, seenSummary(timeline))
+		for _, event := range timeline.Versions {
+			direction := ""
+			if event.Direction != "" {
+				direction = " [" + event.Direction + "]"
+			}
+			fmt.Fprintf(buf, "      %s  %s  %s%s\n", event.Revision.Ref, event.Revision.CommitTime.Format(dateLayout), event.Version, direction)
+		}
+	}
+	buf.WriteString("\n")
+}
+
+// Markdown 返回 Markdown 格式时间线
+func Markdown(history *camodels.History) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Technology Timeline\n\n`%s`\n\n", history.Path)
+
+	buf.WriteString("## Revisions\n\n")
+	writeRow(&buf, "Revision", "Commit", "Date", "Files", "Lines")
+	writeRow(&buf, "---", "---", "---", "---", "---")
+	for _, snapshot := range history.Snapshots {
+		writeRow(&buf, snapshot.Revision.Ref, shortCommit(snapshot.Revision.Commit), snapshot.Revision.CommitTime.Format(dateLayout),
+			fmt.Sprint(snapshot.TotalFiles), fmt.Sprint(snapshot.TotalLines))
+	}
+	buf.WriteString("\n")
+
+	writeTimelinesMarkdown(&buf, "Frameworks", history.Frameworks)
+	writeTimelinesMarkdown(&buf, "Components", history.Components)
+
+	if len(history.Snapshots) > 0 {
+		buf.WriteString("## Language Share\n\nPercentage of code lines per revision.\n\n")
+		for i, row := range languageTable(history) {
+			writeRow(&buf, row...)
+			if i == 0 {
+				separator := make([]string, len(row))
+				for j := range separator {
+					separator[j] = "---"
+				}
+				writeRow(&buf, separator...)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+func writeTimelinesMarkdown(buf *bytes.Buffer, title string, timelines []camodels.ItemTimeline) {
+	if len(timelines) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "## %s\n\n", title)
+	writeRow(buf, "Name", "Language", "First Seen", "Last Seen", "Versions")
+	writeRow(buf, "---", "---", "---", "---", "---")
+	for _, timeline := range timelines {
+		lastSeen := revisionLabel(timeline.LastSeen)
+		if timeline.Present {
+			lastSeen += " (present)"
+		}
+		var versions []string
+		for _, event := range timeline.Versions {
+			version := event.Version + " @ " + event.Revision.Ref
+			if event.Direction != "" {
+				version += " (" + event.Direction + ")"
+			}
+			versions = append(versions, version)
+		}
+		writeRow(buf, timeline.Name, timeline.Language, revisionLabel(timeline.FirstSeen), lastSeen, strings.Join(versions, " → "))
+	}
+	buf.WriteString("\n")
+}
+
+// languageTable 返回语言占比表格：第一行为表头，之后每个修订版本一行；
+// 列为最新修订版本中代码行数最多的语言（最多 maxLanguageColumns 个，历史上出现过的语言依次补充），其余合并为 Other
+func languageTable(history *camodels.History) [][]string {
+	var columns []string
+	seen := make(map[string]bool)
+	for i := len(history.Snapshots) - 1; i >= 0; i-- {
+		for _, share := range history.Snapshots[i].Languages {
+			if !seen[share.Name] {
+				seen[share.Name] = true
+				columns = append(columns, share.Name)
+			}
+		}
+	}
+	other := len(columns) > maxLanguageColumns
+	if other {
+		columns = columns[:maxLanguageColumns]
+	}
+
+	header := append([]string{"Revision"}, columns...)
+	if other {
+		header = append(header, "Other")
+	}
+	rows := [][]string{header}
+	for _, snapshot := range history.Snapshots {
+		percents := make(map[string]float64)
+		for _, share := range snapshot.Languages {
+			percents[share.Name] = share.Percent
+		}
+		row := []string{snapshot.Revision.Ref}
+		rest := 100.0
+		for _, name := range columns {
+			row = append(row, fmt.Sprintf("%.1f", percents[name]))
+			rest -= percents[name]
+		}
+		if other {
+			if len(snapshot.Languages) == 0 || rest < 0 {
+				rest = 0
+			}
+			row = append(row, fmt.Sprintf("%.1f", rest))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// seenSummary 返回检测项首次和最后出现的修订版本
+func seenSummary(timeline camodels.ItemTimeline) string {
+	summary := "first seen " + revisionLabel(timeline.FirstSeen)
+	if timeline.Present {
+		return summary + ", still present"
+	}
+	return summary + ", last seen " + revisionLabel(timeline.LastSeen)
+}
+
+func revisionLabel(revision camodels.Revision) string {
+	return fmt.Sprintf("%s (%s)", revision.Ref, revision.CommitTime.Format(dateLayout))
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+// writeRow 输出一行 Markdown 表格
+func writeRow(buf *bytes.Buffer, cells ...string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	buf.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+}
@@ -0,0 +1,91 @@
+package reporthistory
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+func testHistory() *camodels.History {
+	v1 := camodels.Revision{Ref: "v1", Commit: "1111111111111111111111111111111111111111", CommitTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	v2 := camodels.Revision{Ref: "v2", Commit: "2222222222222222222222222222222222222222", CommitTime: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)}
+	return &camodels.History{
+		Path: "/src/app",
+		Snapshots: []camodels.HistorySnapshot{
+			{Revision: v1, TotalFiles: 2, TotalLines: 100, Languages: []camodels.LanguageShare{{Name: "Go", CodeLines: 80, Percent: 100}}},
+			{Revision: v2, TotalFiles: 4, TotalLines: 200, Languages: []camodels.LanguageShare{
+				{Name: "Go", CodeLines: 120, Percent: 75}, {Name: "JavaScript", CodeLines: 40, Percent: 25}}},
+		},
+		Frameworks: []camodels.ItemTimeline{
+			{Name: "Gin", Language: "Go", FirstSeen: v1, LastSeen: v2, Present: true, Versions: []camodels.VersionEvent{
+				{Revision: v1, Version: "1.9.1"}, {Revision: v2, Version: "1.10.0", Direction: camodels.VersionUpgrade}}},
+		},
+		Components: []camodels.ItemTimeline{
+			{Name: "jquery", Language: "JavaScript", FirstSeen: v1, LastSeen: v1, Versions: []camodels.VersionEvent{}},
+		},
+	}
+}
+
+// TestText tests the terminal timeline
+func TestText(t *testing.T) {
+	text := string(Text(testHistory()))
+	for _, want := range []string{
+		"Revisions: 2\n  - v1  111111111111  2023-01-01  (2 files, 100 lines)\n",
+		"Frameworks: 1\n  - Gin (Go): first seen v1 (2023-01-01), still present\n      v1  2023-01-01  1.9.1\n      v2  2023-06-01  1.10.0 [upgrade]\n",
+		"  - jquery (JavaScript): first seen v1 (2023-01-01), last seen v1 (2023-01-01)\n",
+		"  Revision  Go     JavaScript\n  v1        100.0  0.0\n  v2        75.0   25.0\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q in\n%s", want, text)
+		}
+	}
+}
+
+// TestMarkdown tests the markdown tables
+func TestMarkdown(t *testing.T) {
+	markdown := string(Markdown(testHistory()))
+	for _, want := range []string{
+		"| v2 | 222222222222 | 2023-06-01 | 4 | 200 |\n",
+		"| Gin | Go | v1 (2023-01-01) | v2 (2023-06-01) (present) | 1.9.1 @ v1 → 1.10.0 @ v2 (upgrade) |\n",
+		"| jquery | JavaScript | v1 (2023-01-01) | v1 (2023-01-01) |  |\n",
+		"| Revision | Go | JavaScript |\n| --- | --- | --- |\n| v1 | 100.0 | 0.0 |\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown() missing %q in\n%s", want, markdown)
+		}
+	}
+}
+
+// TestLanguageTableOther tests that languages beyond the column limit are merged into Other
+func TestLanguageTableOther(t *testing.T) {
+	var shares []camodels.LanguageShare
+	for _, name := range []string{"A", "B", "C", "D", "E", "F", "G", "H"} {
+		shares = append(shares, camodels.LanguageShare{Name: name, Percent: 12.5})
+	}
+	history := &camodels.History{Snapshots: []camodels.HistorySnapshot{{Revision: camodels.Revision{Ref: "v1"}, Languages: shares}}}
+	rows := languageTable(history)
+	if got := strings.Join(rows[0], ","); got != "Revision,A,B,C,D,E,F,Other" {
+		t.Errorf("header = %s", got)
+	}
+	if got := rows[1][len(rows[1])-1]; got != "25.0" {
+		t.Errorf("Other = %s, want 25.0", got)
+	}
+}
+
+// TestJSON tests that the JSON output round-trips
+func TestJSON(t *testing.T) {
+	data, err := JSON(testHistory())
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var history camodels.History
+	if err := json.Unmarshal(data, &history); err != nil {
+		t.Fatalf("unmarshal error = %v", err)
+	}
+	if len(history.Frameworks) != 1 || history.Frameworks[0].Versions[1].Direction != camodels.VersionUpgrade {
+		t.Errorf("round-trip Frameworks = %+v", history.Frameworks)
+	}
+}
@@ -0,0 +1,233 @@
+package sbom
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// CycloneDX 规范版本和 XML 命名空间
+const (
+	cycloneDXSpecVersion = "1.5"
+	cycloneDXNamespace   = "http://cyclonedx.org/schema/bom/1.5"
+)
+
+// 置信度：依赖清单解析的结果比源码特征匹配更可靠
+const (
+	confidenceManifest = 1.0
+	confidenceSource   = 0.7
+)
+
+type cdxBOM struct {
+	XMLName      xml.Name       `json:"-" xml:"bom"`
+	XMLNS        string         `json:"-" xml:"xmlns,attr"`
+	BOMFormat    string         `json:"bomFormat" xml:"-"`
+	SpecVersion  string         `json:"specVersion" xml:"-"`
+	SerialNumber string         `json:"serialNumber" xml:"serialNumber,attr"`
+	Version      int            `json:"version" xml:"version,attr"`
+	Metadata     cdxMetadata    `json:"metadata" xml:"metadata"`
+	Components   []cdxComponent `json:"components" xml:"components>component"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp" xml:"timestamp"`
+	Tools     []cdxTool    `json:"tools" xml:"tools>tool"`
+	Component cdxComponent `json:"component" xml:"component"`
+}
+
+type cdxTool struct {
+	Vendor  string `json:"vendor" xml:"vendor"`
+	Name    string `json:"name" xml:"name"`
+	Version string `json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// cdxComponent 组件；XML 元素顺序必须与 CycloneDX 1.5 XSD 的 sequence 一致（group、name、version、scope、licenses、purl、properties、evidence）
+type cdxComponent struct {
+	Type       string        `json:"type" xml:"type,attr"`
+	BOMRef     string        `json:"bom-ref" xml:"bom-ref,attr"`
+	Group      string        `json:"group,omitempty" xml:"group,omitempty"`
+	Name       string        `json:"name" xml:"name"`
+	Version    string        `json:"version,omitempty" xml:"version,omitempty"`
+	Scope      string        `json:"scope,omitempty" xml:"scope,omitempty"`
+	Licenses   cdxLicenses   `json:"licenses,omitempty" xml:"licenses,omitempty"`
+	PURL       string        `json:"purl,omitempty" xml:"purl,omitempty"`
+	Properties cdxProperties `json:"properties,omitempty" xml:"properties,omitempty"`
+	Evidence   *cdxEvidence  `json:"evidence,omitempty" xml:"evidence,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value" xml:",chardata"`
+}
+
+type cdxLicense struct {
+	Expression string `json:"expression" xml:",chardata"`
+}
+
+// cdxLicenses 许可证列表，XML 中 expression 直接位于 licenses 元素内
+type cdxLicenses []cdxLicense
+
+func (l cdxLicenses) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Items []cdxLicense `xml:"expression"`
+	}{l}, start)
+}
+
+// cdxProperties 属性列表；encoding/xml 对 "a>b" 形式的空切片仍会输出父元素，因此自定义 XML 序列化
+type cdxProperties []cdxProperty
+
+func (p cdxProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Items []cdxProperty `xml:"property"`
+	}{p}, start)
+}
+
+type cdxEvidence struct {
+	Identity    *cdxIdentity   `json:"identity,omitempty" xml:"identity,omitempty"`
+	Occurrences cdxOccurrences `json:"occurrences,omitempty" xml:"occurrences,omitempty"`
+}
+
+type cdxIdentity struct {
+	Field      string      `json:"field" xml:"field"`
+	Confidence float64     `json:"confidence" xml:"confidence"`
+	Methods    []cdxMethod `json:"methods,omitempty" xml:"methods>method,omitempty"`
+}
+
+type cdxMethod struct {
+	Technique  string  `json:"technique" xml:"technique"`
+	Confidence float64 `json:"confidence" xml:"confidence"`
+	Value      string  `json:"value,omitempty" xml:"value,omitempty"`
+}
+
+type cdxOccurrence struct {
+	Location string `json:"location" xml:"location"`
+}
+
+// cdxOccurrences 出现位置列表，XML 序列化方式同 cdxProperties
+type cdxOccurrences []cdxOccurrence
+
+func (o cdxOccurrences) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Items []cdxOccurrence `xml:"occurrence"`
+	}{o}, start)
+}
+
+// CycloneDXJSON 将分析报告转换为 CycloneDX 1.5 JSON：项目作为 metadata 组件，
+// 依赖清单中的依赖和检测到的框架/组件作为组件（相同 purl 合并），附带版本、purl 和检测依据
+func CycloneDXJSON(report *camodels.CanvasReport) ([]byte, error) {
+	return json.MarshalIndent(newCycloneDX(report), "", "  ")
+}
+
+// CycloneDXXML 将分析报告转换为 CycloneDX 1.5 XML，内容与 CycloneDXJSON 相同
+func CycloneDXXML(report *camodels.CanvasReport) ([]byte, error) {
+	data, err := xml.MarshalIndent(newCycloneDX(report), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// newCycloneDX 构建 CycloneDX 文档
+func newCycloneDX(report *camodels.CanvasReport) *cdxBOM {
+	timestamp := report.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	bom := &cdxBOM{
+		XMLNS:        cycloneDXNamespace,
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Tools:     []cdxTool{{Vendor: "winezer0", Name: "xcanvas", Version: report.Version}},
+			Component: cdxComponent{Type: "application", BOMRef: "project", Name: projectName(report)},
+		},
+		Components: []cdxComponent{},
+	}
+	for _, pkg := range collectPackages(report) {
+		bom.Components = append(bom.Components, cdxComponentOf(pkg))
+	}
+	return bom
+}
+
+// cdxComponentOf 将软件包转换为 CycloneDX 组件
+func cdxComponentOf(pkg *sbomPackage) cdxComponent {
+	component := cdxComponent{
+		Type:    pkg.kind,
+		BOMRef:  pkg.ref,
+		Name:    pkg.name,
+		Version: pkg.version,
+		PURL:    pkg.purl,
+		Scope:   cdxScope(pkg.scopes),
+	}
+	if pkg.license != "" {
+		component.Licenses = cdxLicenses{{Expression: pkg.license}}
+	}
+	// Maven 坐标拆分为 group 和 name
+	if pkg.ecosystem == camodels.EcosystemMaven {
+		if group, artifact, found := strings.Cut(pkg.name, ":"); found {
+			component.Group, component.Name = group, artifact
+		}
+	}
+	addProperty := func(name, value string) {
+		if value != "" {
+			component.Properties = append(component.Properties, cdxProperty{Name: "xcanvas:" + name, Value: value})
+		}
+	}
+	addProperty("ecosystem", pkg.ecosystem)
+	for _, scope := range pkg.scopes {
+		addProperty("scope", scope)
+	}
+	addProperty("versionSource", pkg.source)
+	addProperty("versionRange", pkg.declared)
+	addProperty("detected", pkg.detected)
+	addProperty("category", pkg.category)
+	addProperty("language", pkg.language)
+
+	evidence := &cdxEvidence{}
+	for _, file := range pkg.files {
+		evidence.Occurrences = append(evidence.Occurrences, cdxOccurrence{Location: file})
+	}
+	if pkg.purl != "" || pkg.evidence != "" {
+		method := cdxMethod{Technique: "manifest-analysis", Confidence: confidenceManifest, Value: pkg.evidence}
+		if pkg.purl == "" {
+			method.Technique, method.Confidence = "source-code-analysis", confidenceSource
+		}
+		field := "purl"
+		if pkg.purl == "" {
+			field = "name"
+		}
+		evidence.Identity = &cdxIdentity{Field: field, Confidence: method.Confidence, Methods: []cdxMethod{method}}
+	}
+	if evidence.Identity != nil || len(evidence.Occurrences) > 0 {
+		component.Evidence = evidence
+	}
+	return component
+}
+
+// cdxScope 根据依赖范围推断 CycloneDX scope：只在开发、测试等范围中使用的依赖为 optional
+func cdxScope(scopes []string) string {
+	switch {
+	case len(scopes) == 0:
+		return ""
+	case devOnly(scopes):
+		return "optional"
+	}
+	return "required"
+}
+
+// newUUID 生成随机的 UUID v4
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,254 @@
+package sbom
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// testReport 构建包含依赖清单和检测结果的测试报告
+func testReport() *camodels.CanvasReport {
+	return &camodels.CanvasReport{
+		CodeProfile: camodels.CodeProfile{Path: "/tmp/demo"},
+		Dependencies: []camodels.Dependency{
+			{Ecosystem: camodels.EcosystemGo, Name: "github.com/gin-gonic/gin", Version: "1.9.1", Raw: "v1.9.1", Source: camodels.VersionSourcePinned, Scope: "direct", File: "go.mod"},
+			{Ecosystem: camodels.EcosystemGo, Name: "golang.org/x/net", Version: "0.10.0", Source: camodels.VersionSourcePinned, Scope: "indirect", File: "go.mod"},
+			{Ecosystem: camodels.EcosystemMaven, Name: "junit:junit", Version: "4.13.2", Source: camodels.VersionSourcePinned, Scope: "test", File: "pom.xml"},
+			{Ecosystem: camodels.EcosystemComposer, Name: "monolog/monolog", Version: "3.5.0", Source: camodels.VersionSourceResolved, Scope: "prod", File: "composer.json", License: "MIT"},
+		},
+		Detection: camodels.DetectionInfo{
+			Frameworks: []camodels.DetectedItem{{
+				Name: "Gin", Type: camodels.RuleTypeFramework, Language: "Go", Version: "1.9.1", Category: "backend",
+				Evidence: "FrameRule matched for Gin",
+				Versions: []*camodels.VersionInfo{{Version: "1.9.1", Name: "github.com/gin-gonic/gin", File: "go.mod"}},
+			}},
+			Components: []camodels.DetectedItem{{
+				Name: "jquery", Type: camodels.RuleTypeComponent, Language: "HTML", Version: "3.6.0", Category: "frontend",
+				Evidence: "FrameRule matched for jquery",
+				Versions: []*camodels.VersionInfo{{Version: "3.6.0", File: "static/jquery.min.js"}},
+			}},
+		},
+		Version: "test",
+	}
+}
+
+// TestCycloneDXJSON tests that manifest dependencies and detected items are merged by purl
+func TestCycloneDXJSON(t *testing.T) {
+	data, err := CycloneDXJSON(testReport())
+	if err != nil {
+		t.Fatalf("CycloneDXJSON error: %v", err)
+	}
+	var bom cdxBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		t.Fatalf("unmarshal bom error: %v", err)
+	}
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != cycloneDXSpecVersion || bom.Metadata.Component.Name != "demo" {
+		t.Errorf("unexpected bom header: %+v", bom.Metadata)
+	}
+	if len(bom.Components) != 5 {
+		t.Fatalf("components = %d, want 5: %+v", len(bom.Components), bom.Components)
+	}
+	components := make(map[string]cdxComponent)
+	for _, component := range bom.Components {
+		components[component.BOMRef] = component
+	}
+
+	gin, ok := components["pkg:golang/github.com/gin-gonic/gin@v1.9.1"]
+	if !ok {
+		t.Fatalf("gin component not found: %+v", bom.Components)
+	}
+	if gin.Type != kindFramework || gin.Name != "github.com/gin-gonic/gin" || gin.Scope != "required" {
+		t.Errorf("gin component = %+v", gin)
+	}
+	if gin.Evidence == nil || gin.Evidence.Identity == nil || gin.Evidence.Identity.Methods[0].Technique != "manifest-analysis" {
+		t.Errorf("gin evidence = %+v", gin.Evidence)
+	}
+	if !hasProperty(gin.Properties, "xcanvas:detected", "Gin") {
+		t.Errorf("gin properties = %+v", gin.Properties)
+	}
+
+	junit := components["pkg:maven/junit/junit@4.13.2"]
+	if junit.Group != "junit" || junit.Name != "junit" || junit.Scope != "optional" {
+		t.Errorf("junit component = %+v", junit)
+	}
+
+	monolog := components["pkg:composer/monolog/monolog@3.5.0"]
+	if len(monolog.Licenses) != 1 || monolog.Licenses[0].Expression != "MIT" {
+		t.Errorf("monolog licenses = %+v", monolog.Licenses)
+	}
+
+	jquery, ok := components["xcanvas:component/jquery@3.6.0"]
+	if !ok {
+		t.Fatalf("jquery component not found: %+v", bom.Components)
+	}
+	if jquery.PURL != "" || jquery.Evidence == nil || jquery.Evidence.Identity.Methods[0].Technique != "source-code-analysis" {
+		t.Errorf("jquery component = %+v", jquery)
+	}
+}
+
+// TestCycloneDXXML tests that the XML output is well-formed and has no empty list elements
+func TestCycloneDXXML(t *testing.T) {
+	data, err := CycloneDXXML(testReport())
+	if err != nil {
+		t.Fatalf("CycloneDXXML error: %v", err)
+	}
+	var bom struct {
+		XMLName    xml.Name `xml:"bom"`
+		Components []struct {
+			Name       string `xml:"name"`
+			PURL       string `xml:"purl"`
+			Properties []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"properties>property"`
+		} `xml:"components>component"`
+	}
+	if err := xml.Unmarshal(data, &bom); err != nil {
+		t.Fatalf("unmarshal xml error: %v", err)
+	}
+	if bom.XMLName.Space != cycloneDXNamespace || len(bom.Components) != 5 {
+		t.Fatalf("unexpected xml bom: %+v", bom)
+	}
+	if bom.Components[0].PURL != "pkg:golang/github.com/gin-gonic/gin@v1.9.1" || len(bom.Components[0].Properties) == 0 {
+		t.Errorf("first component = %+v", bom.Components[0])
+	}
+	if containsEmptyList(string(data)) {
+		t.Errorf("xml contains empty list elements:\n%s", data)
+	}
+}
+
+// TestCycloneDXXMLElementOrder tests that component child elements follow the CycloneDX 1.5 XSD sequence
+func TestCycloneDXXMLElementOrder(t *testing.T) {
+	schemaOrder := []string{"supplier", "author", "publisher", "group", "name", "version", "description", "scope",
+		"hashes", "licenses", "copyright", "cpe", "purl", "swid", "modified", "pedigree", "externalReferences",
+		"properties", "components", "evidence"}
+	data, err := CycloneDXXML(testReport())
+	if err != nil {
+		t.Fatalf("CycloneDXXML error: %v", err)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	var stack []string
+	var children [][]string
+	var checked, withLicenses int
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if len(stack) > 0 && stack[len(stack)-1] == "component" {
+				children[len(children)-1] = append(children[len(children)-1], tok.Name.Local)
+			}
+			stack = append(stack, tok.Name.Local)
+			if tok.Name.Local == "component" {
+				children = append(children, nil)
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			if tok.Name.Local != "component" {
+				continue
+			}
+			elements := children[len(children)-1]
+			children = children[:len(children)-1]
+			checked++
+			if slices.Contains(elements, "licenses") {
+				withLicenses++
+			}
+			last := -1
+			for _, element := range elements {
+				index := slices.Index(schemaOrder, element)
+				if index < 0 {
+					t.Errorf("unexpected component element %q", element)
+					continue
+				}
+				if index < last {
+					t.Errorf("component elements out of schema order: %v", elements)
+					break
+				}
+				last = index
+			}
+		}
+	}
+	if checked == 0 || withLicenses == 0 {
+		t.Fatalf("expected components with licenses, checked %d components, %d with licenses", checked, withLicenses)
+	}
+}
+
+// rangeReport 构建只知道声明版本范围的测试报告：没有锁文件的 Composer 依赖和按范围下界提取版本的检测项
+func rangeReport() *camodels.CanvasReport {
+	return &camodels.CanvasReport{
+		CodeProfile: camodels.CodeProfile{Path: "/tmp/demo"},
+		Dependencies: []camodels.Dependency{
+			{Ecosystem: camodels.EcosystemComposer, Name: "laravel/framework", Version: "10.0", Raw: "^10.0", Source: camodels.VersionSourceRange, Scope: "prod", File: "composer.json"},
+			{Ecosystem: camodels.EcosystemComposer, Name: "monolog/monolog", Version: "3.5.0", Raw: "^3.0", Source: camodels.VersionSourceResolved, Scope: "prod", File: "composer.json"},
+		},
+		Detection: camodels.DetectionInfo{
+			Frameworks: []camodels.DetectedItem{{
+				Name: "Laravel", Type: camodels.RuleTypeFramework, Language: "PHP", Version: "10.0", Category: "backend",
+				Versions: []*camodels.VersionInfo{{Version: "10.0", Raw: "^10.0", Source: camodels.VersionSourceRange, Name: "laravel/framework", File: "composer.json"}},
+			}},
+			Components: []camodels.DetectedItem{{
+				Name: "jquery", Type: camodels.RuleTypeComponent, Language: "HTML", Version: "3.0", Category: "frontend",
+				Versions: []*camodels.VersionInfo{{Version: "3.0", Raw: "~3.0", Source: camodels.VersionSourceRange, File: "bower.json"}},
+			}},
+		},
+	}
+}
+
+// TestCycloneDXRangeVersions tests that range lower bounds are not published as component versions
+func TestCycloneDXRangeVersions(t *testing.T) {
+	data, err := CycloneDXJSON(rangeReport())
+	if err != nil {
+		t.Fatalf("CycloneDXJSON error: %v", err)
+	}
+	var bom cdxBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		t.Fatalf("unmarshal bom error: %v", err)
+	}
+	components := make(map[string]cdxComponent)
+	for _, component := range bom.Components {
+		components[component.BOMRef] = component
+	}
+	if len(components) != 3 {
+		t.Fatalf("components = %+v, want laravel, monolog and jquery", bom.Components)
+	}
+
+	laravel, ok := components["pkg:composer/laravel/framework"]
+	if !ok {
+		t.Fatalf("laravel component without version not found: %+v", bom.Components)
+	}
+	if laravel.Version != "" || laravel.PURL != "pkg:composer/laravel/framework" ||
+		!hasProperty(laravel.Properties, "xcanvas:versionRange", "^10.0") || !hasProperty(laravel.Properties, "xcanvas:detected", "Laravel") {
+		t.Errorf("laravel component = %+v", laravel)
+	}
+	if monolog := components["pkg:composer/monolog/monolog@3.5.0"]; monolog.Version != "3.5.0" {
+		t.Errorf("resolved monolog component = %+v", monolog)
+	}
+	if jquery, ok := components["xcanvas:component/jquery"]; !ok || jquery.Version != "" {
+		t.Errorf("jquery component = %+v, want no version", jquery)
+	}
+}
+
+func hasProperty(properties []cdxProperty, name, value string) bool {
+	for _, property := range properties {
+		if property.Name == name && property.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEmptyList(data string) bool {
+	for _, tag := range []string{"properties", "occurrences"} {
+		if strings.Contains(data, "<"+tag+"></"+tag+">") {
+			return true
+		}
+	}
+	return false
+}
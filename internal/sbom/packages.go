@@ -0,0 +1,214 @@
+package sbom
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// 包类型
+const (
+	kindFramework = "framework"
+	kindLibrary   = "library"
+)
+
+// lockFileNames 锁文件及安装记录，只出现在这些文件中的依赖视为间接依赖
+var lockFileNames = map[string]bool{
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"poetry.lock":         true,
+	"Pipfile.lock":        true,
+	"composer.lock":       true,
+	"installed.json":      true,
+	"modules.txt":         true,
+}
+
+// sbomPackage 报告中的一个软件包：依赖清单中的依赖，或规则检测到的框架/组件，相同 purl 的条目会合并
+type sbomPackage struct {
+	ref       string   // 文档内唯一标识，有 purl 时为 purl
+	name      string   // 依赖名称或检测项名称
+	version   string   // 固定或已解析的版本号，只知道声明范围时为空
+	declared  string   // 声明的版本范围，例如 "^10.0"，只知道声明范围时填充
+	purl      string   // package url，生态未知时为空
+	ecosystem string   // 依赖生态
+	kind      string   // framework | library
+	scopes    []string // 依赖范围
+	files     []string // 声明或检测到该包的文件
+	direct    bool     // 是否为项目直接声明的依赖
+	license   string   // 许可证（SPDX 表达式），没有许可证信息时为空
+
+	detected string // 检测项名称，未被规则检测到时为空
+	source   string // 版本来源
+	category string // 检测项分类
+	language string // 检测项语言
+	evidence string // 检测依据
+}
+
+// projectName 返回报告对应的项目名称（项目目录名）
+func projectName(report *camodels.CanvasReport) string {
+	name := filepath.Base(filepath.Clean(report.CodeProfile.Path))
+	if name == "." || name == string(filepath.Separator) || name == "" {
+		return "project"
+	}
+	return name
+}
+
+// collectPackages 汇总依赖清单和检测结果，按依赖清单、框架、组件的顺序返回
+func collectPackages(report *camodels.CanvasReport) []*sbomPackage {
+	var packages []*sbomPackage
+	byRef := make(map[string]*sbomPackage)
+	add := func(pkg *sbomPackage) *sbomPackage {
+		if existing, ok := byRef[pkg.ref]; ok {
+			return existing
+		}
+		byRef[pkg.ref] = pkg
+		packages = append(packages, pkg)
+		return pkg
+	}
+
+	for _, dependency := range report.Dependencies {
+		version := exactVersion(dependency.Version, dependency.Source)
+		purl := PackageURL(dependency.Ecosystem, dependency.Name, version)
+		ref := purl
+		if ref == "" {
+			ref = versionedRef(dependency.Ecosystem+":"+dependency.Name, version)
+		}
+		pkg := add(&sbomPackage{
+			ref:       ref,
+			name:      dependency.Name,
+			version:   version,
+			purl:      purl,
+			ecosystem: dependency.Ecosystem,
+			kind:      kindLibrary,
+			source:    dependency.Source,
+		})
+		if pkg.license == "" {
+			pkg.license = dependency.License
+		}
+		if pkg.declared == "" {
+			pkg.declared = declaredRange(dependency.Raw, dependency.Source)
+		}
+		pkg.scopes = appendUnique(pkg.scopes, dependency.Scope)
+		pkg.files = appendUnique(pkg.files, dependency.File)
+		if isDirectDependency(dependency) {
+			pkg.direct = true
+		}
+	}
+
+	items := append(append([]camodels.DetectedItem{}, report.Detection.Frameworks...), report.Detection.Components...)
+	for _, item := range items {
+		ecosystem := itemEcosystem(item)
+		versions := item.Versions
+		if len(versions) == 0 {
+			versions = []*camodels.VersionInfo{{Version: item.Version}}
+		}
+		for _, info := range versions {
+			if info == nil {
+				continue
+			}
+			version := exactVersion(info.Version, info.Source)
+			purl := ""
+			if info.Name != "" {
+				purl = PackageURL(ecosystem, info.Name, version)
+			}
+			ref := purl
+			if ref == "" {
+				ref = versionedRef("xcanvas:"+item.Type+"/"+item.Name, version)
+			}
+			kind := kindLibrary
+			if item.Type == camodels.RuleTypeFramework {
+				kind = kindFramework
+			}
+			pkg := add(&sbomPackage{
+				ref:       ref,
+				name:      item.Name,
+				version:   version,
+				purl:      purl,
+				ecosystem: ecosystem,
+				source:    info.Source,
+			})
+			if pkg.declared == "" {
+				pkg.declared = declaredRange(info.Raw, info.Source)
+			}
+			// 与依赖清单中的条目合并时，保留依赖名称，补充检测信息
+			pkg.kind = kind
+			pkg.detected = item.Name
+			pkg.category = item.Category
+			pkg.language = item.Language
+			pkg.evidence = item.Evidence
+			pkg.files = appendUnique(pkg.files, info.Files...)
+			pkg.files = appendUnique(pkg.files, info.File)
+		}
+	}
+	return packages
+}
+
+// exactVersion 返回可以作为软件包版本输出的版本：来源为 range 的版本只是声明范围的下界，
+// 并不代表项目中实际存在该版本，返回空
+func exactVersion(version, source string) string {
+	if source == camodels.VersionSourceRange {
+		return ""
+	}
+	return version
+}
+
+// declaredRange 返回来源为 range 时声明的原始版本范围，其他来源返回空
+func declaredRange(raw, source string) string {
+	if source != camodels.VersionSourceRange {
+		return ""
+	}
+	return raw
+}
+
+// versionedRef 在标识后追加版本号，版本为空时不追加
+func versionedRef(ref, version string) string {
+	if version == "" {
+		return ref
+	}
+	return ref + "@" + version
+}
+
+// isDirectDependency 判断依赖是否由项目直接声明：Go 的 indirect 依赖以及只出现在锁文件中的依赖为间接依赖
+func isDirectDependency(dependency camodels.Dependency) bool {
+	return dependency.Scope != "indirect" && !lockFileNames[path.Base(dependency.File)]
+}
+
+// devOnly 判断依赖是否只在开发、测试等非运行时范围中使用，没有范围信息时返回 false
+func devOnly(scopes []string) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, scope := range scopes {
+		switch scope {
+		case "dev", "test", "optional", "peer", "provided", "testImplementation", "testRuntimeOnly", "testCompileOnly", "compileOnly":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// appendUnique 追加非空且不重复的字符串
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		exists := false
+		for _, existing := range list {
+			if existing == value {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, value)
+		}
+	}
+	return list
+}
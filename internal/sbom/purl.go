@@ -0,0 +1,77 @@
+// Package sbom 将分析报告转换为 CycloneDX、SPDX 等软件物料清单格式。
+package sbom
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// purlTypes 依赖生态对应的 purl 类型
+var purlTypes = map[string]string{
+	camodels.EcosystemMaven:    "maven",
+	camodels.EcosystemNPM:      "npm",
+	camodels.EcosystemPyPI:     "pypi",
+	camodels.EcosystemComposer: "composer",
+	camodels.EcosystemGo:       "golang",
+}
+
+// PackageURL 根据依赖生态、名称和版本生成 purl（https://github.com/package-url/purl-spec），
+// 生态未知或名称为空时返回空字符串。名称格式与依赖清单一致：Maven 为 groupId:artifactId，
+// npm 可带 @scope/ 前缀，Composer 为 vendor/name，Go 为模块路径
+func PackageURL(ecosystem, name, version string) string {
+	purlType, ok := purlTypes[ecosystem]
+	if !ok || name == "" {
+		return ""
+	}
+	var namespace string
+	switch ecosystem {
+	case camodels.EcosystemMaven:
+		group, artifact, found := strings.Cut(name, ":")
+		if !found {
+			return ""
+		}
+		namespace, name = group, artifact
+	case camodels.EcosystemPyPI:
+		name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	case camodels.EcosystemGo:
+		if idx := strings.LastIndex(name, "/"); idx > 0 {
+			namespace, name = name[:idx], name[idx+1:]
+		}
+		if version != "" && !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+	default:
+		if idx := strings.LastIndex(name, "/"); idx > 0 {
+			namespace, name = name[:idx], name[idx+1:]
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(purlType)
+	b.WriteString("/")
+	if namespace != "" {
+		for _, segment := range strings.Split(namespace, "/") {
+			b.WriteString(escapePURL(segment))
+			b.WriteString("/")
+		}
+	}
+	b.WriteString(escapePURL(name))
+	if version != "" {
+		b.WriteString("@")
+		b.WriteString(escapePURL(version))
+	}
+	return b.String()
+}
+
+// escapePURL 按 purl 规范对路径片段进行百分号编码（@ 编码为 %40）
+func escapePURL(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+}
+
+// itemEcosystem 返回检测项对应的依赖生态，规则语言无法推断时返回空字符串
+func itemEcosystem(item camodels.DetectedItem) string {
+	return camodels.EcosystemForLanguage(item.Language)
+}
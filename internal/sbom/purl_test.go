@@ -0,0 +1,29 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// TestPackageURL tests purl generation for each supported ecosystem
+func TestPackageURL(t *testing.T) {
+	testCases := []struct {
+		ecosystem, name, version, want string
+	}{
+		{camodels.EcosystemMaven, "org.apache.logging.log4j:log4j-core", "2.14.1", "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		{camodels.EcosystemMaven, "log4j-core", "2.14.1", ""},
+		{camodels.EcosystemNPM, "react", "18.3.1", "pkg:npm/react@18.3.1"},
+		{camodels.EcosystemNPM, "@angular/core", "17.0.0", "pkg:npm/%40angular/core@17.0.0"},
+		{camodels.EcosystemPyPI, "Django_REST", "3.14.0", "pkg:pypi/django-rest@3.14.0"},
+		{camodels.EcosystemComposer, "laravel/framework", "10.48.4", "pkg:composer/laravel/framework@10.48.4"},
+		{camodels.EcosystemGo, "github.com/gin-gonic/gin", "1.9.1", "pkg:golang/github.com/gin-gonic/gin@v1.9.1"},
+		{camodels.EcosystemGo, "github.com/gin-gonic/gin", "", "pkg:golang/github.com/gin-gonic/gin"},
+		{"", "gin", "1.9.1", ""},
+	}
+	for _, tc := range testCases {
+		if got := PackageURL(tc.ecosystem, tc.name, tc.version); got != tc.want {
+			t.Errorf("PackageURL(%q, %q, %q) = %q, want %q", tc.ecosystem, tc.name, tc.version, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/winezer0/xcanvas/camodels"
+)
+
+// SPDX 规范版本和文档固定字段
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdxDataLicense = "CC0-1.0"
+	spdxDocumentID  = "SPDXRef-DOCUMENT"
+	spdxProjectID   = "SPDXRef-Project"
+	spdxNoAssertion = "NOASSERTION"
+	spdxNamespace   = "https://github.com/winezer0/xcanvas/spdxdocs/"
+)
+
+// SPDX 关系类型
+const (
+	spdxDescribes       = "DESCRIBES"
+	spdxDependsOn       = "DEPENDS_ON"
+	spdxDevDependencyOf = "DEV_DEPENDENCY_OF"
+	spdxContains        = "CONTAINS"
+)
+
+// spdxIDInvalidRe SPDXID 中不允许出现的字符（只允许字母、数字、"." 和 "-"）
+var spdxIDInvalidRe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// spdxLicenseRe 合法的 SPDX 许可证表达式：许可证标识符与 AND / OR / WITH 和括号的组合
+var spdxLicenseRe = regexp.MustCompile(`^[()]*[A-Za-z0-9.+-]+[()]*(?:\s+(?:AND|OR|WITH)\s+[()]*[A-Za-z0-9.+-]+[()]*)*$`)
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	SourceInfo            string            `json:"sourceInfo,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// SPDXJSON 将分析报告转换为 SPDX 2.3 JSON：项目和每个依赖/检测项各为一个包，
+// 项目直接声明的依赖通过 DEPENDS_ON（开发依赖为 DEV_DEPENDENCY_OF）关联，
+// 只由源码特征检测到的组件通过 CONTAINS 关联；有许可证信息时填充 licenseDeclared
+func SPDXJSON(report *camodels.CanvasReport) ([]byte, error) {
+	return json.MarshalIndent(newSPDX(report), "", "  ")
+}
+
+// SPDXTagValue 将分析报告转换为 SPDX 2.3 tag-value 格式，内容与 SPDXJSON 相同
+func SPDXTagValue(report *camodels.CanvasReport) ([]byte, error) {
+	doc := newSPDX(report)
+	var b bytes.Buffer
+	tag := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", name, value)
+		}
+	}
+	tag("SPDXVersion", doc.SPDXVersion)
+	tag("DataLicense", doc.DataLicense)
+	tag("SPDXID", doc.SPDXID)
+	tag("DocumentName", doc.Name)
+	tag("DocumentNamespace", doc.DocumentNamespace)
+	for _, creator := range doc.CreationInfo.Creators {
+		tag("Creator", creator)
+	}
+	tag("Created", doc.CreationInfo.Created)
+
+	for _, pkg := range doc.Packages {
+		fmt.Fprintf(&b, "\n##### Package: %s\n\n", pkg.Name)
+		tag("PackageName", pkg.Name)
+		tag("SPDXID", pkg.SPDXID)
+		tag("PackageVersion", pkg.VersionInfo)
+		tag("PackageDownloadLocation", pkg.DownloadLocation)
+		tag("FilesAnalyzed", fmt.Sprint(pkg.FilesAnalyzed))
+		tag("PackageLicenseConcluded", pkg.LicenseConcluded)
+		tag("PackageLicenseDeclared", pkg.LicenseDeclared)
+		tag("PackageCopyrightText", pkg.CopyrightText)
+		tag("PrimaryPackagePurpose", pkg.PrimaryPackagePurpose)
+		for _, ref := range pkg.ExternalRefs {
+			tag("ExternalRef", ref.ReferenceCategory+" "+ref.ReferenceType+" "+ref.ReferenceLocator)
+		}
+		if pkg.SourceInfo != "" {
+			tag("PackageSourceInfo", "<text>"+pkg.SourceInfo+"</text>")
+		}
+		if pkg.Comment != "" {
+			tag("PackageComment", "<text>"+pkg.Comment+"</text>")
+		}
+	}
+
+	b.WriteString("\n")
+	for _, relationship := range doc.Relationships {
+		tag("Relationship", relationship.SPDXElementID+" "+relationship.RelationshipType+" "+relationship.RelatedSPDXElement)
+	}
+	return b.Bytes(), nil
+}
+
+// newSPDX 构建 SPDX 文档
+func newSPDX(report *camodels.CanvasReport) *spdxDocument {
+	timestamp := report.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	creator := "Tool: xcanvas"
+	if report.Version != "" {
+		creator += "-" + report.Version
+	}
+	name := projectName(report)
+	doc := &spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       spdxDataLicense,
+		SPDXID:            spdxDocumentID,
+		Name:              name,
+		DocumentNamespace: spdxNamespace + spdxIDInvalidRe.ReplaceAllString(name, "-") + "-" + newUUID(),
+		CreationInfo: spdxCreationInfo{
+			Created:  timestamp.UTC().Format(time.RFC3339),
+			Creators: []string{creator},
+		},
+		Packages: []spdxPackage{{
+			Name:                  name,
+			SPDXID:                spdxProjectID,
+			DownloadLocation:      spdxNoAssertion,
+			LicenseConcluded:      spdxNoAssertion,
+			LicenseDeclared:       spdxNoAssertion,
+			CopyrightText:         spdxNoAssertion,
+			PrimaryPackagePurpose: "APPLICATION",
+		}},
+		Relationships: []spdxRelationship{{SPDXElementID: spdxDocumentID, RelationshipType: spdxDescribes, RelatedSPDXElement: spdxProjectID}},
+	}
+
+	used := map[string]bool{spdxProjectID: true}
+	for _, pkg := range collectPackages(report) {
+		id := spdxPackageID(pkg, used)
+		doc.Packages = append(doc.Packages, spdxPackageOf(pkg, id))
+		switch {
+		case pkg.direct && devOnly(pkg.scopes):
+			doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: id, RelationshipType: spdxDevDependencyOf, RelatedSPDXElement: spdxProjectID})
+		case pkg.direct:
+			doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: spdxProjectID, RelationshipType: spdxDependsOn, RelatedSPDXElement: id})
+		case len(pkg.scopes) == 0:
+			// 不在依赖清单中、只由源码特征检测到的组件（例如引入的 jquery.min.js）位于项目内
+			doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: spdxProjectID, RelationshipType: spdxContains, RelatedSPDXElement: id})
+		}
+	}
+	return doc
+}
+
+// spdxPackageOf 将软件包转换为 SPDX 包
+func spdxPackageOf(pkg *sbomPackage, id string) spdxPackage {
+	license := spdxNoAssertion
+	if pkg.license != "" && spdxLicenseRe.MatchString(pkg.license) {
+		license = pkg.license
+	}
+	result := spdxPackage{
+		Name:                  pkg.name,
+		SPDXID:                id,
+		VersionInfo:           pkg.version,
+		DownloadLocation:      spdxNoAssertion,
+		LicenseConcluded:      spdxNoAssertion,
+		LicenseDeclared:       license,
+		CopyrightText:         spdxNoAssertion,
+		PrimaryPackagePurpose: strings.ToUpper(pkg.kind),
+		Comment:               pkg.evidence,
+	}
+	if pkg.purl != "" {
+		result.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: pkg.purl}}
+	}
+	if len(pkg.files) > 0 {
+		result.SourceInfo = "found in " + strings.Join(pkg.files, ", ")
+	}
+	// 只知道声明范围时 versionInfo 为空，范围记录在注释中
+	if pkg.declared != "" {
+		result.Comment = strings.TrimPrefix(result.Comment+"; declared version range "+pkg.declared, "; ")
+	}
+	return result
+}
+
+// spdxPackageID 根据生态、名称和版本生成文档内唯一的 SPDXID
+func spdxPackageID(pkg *sbomPackage, used map[string]bool) string {
+	parts := []string{"SPDXRef-Package"}
+	for _, part := range []string{pkg.ecosystem, pkg.name, pkg.version} {
+		if part = strings.Trim(spdxIDInvalidRe.ReplaceAllString(part, "-"), "-"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	id := strings.Join(parts, "-")
+	for i := 2; used[id]; i++ {
+		id = fmt.Sprintf("%s-%d", strings.Join(parts, "-"), i)
+	}
+	used[id] = true
+	return id
+}
@@ -0,0 +1,139 @@
+package sbom
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestSPDXJSON tests packages, purl references, licenses and relationships of the SPDX document
+func TestSPDXJSON(t *testing.T) {
+	data, err := SPDXJSON(testReport())
+	if err != nil {
+		t.Fatalf("SPDXJSON error: %v", err)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal spdx error: %v", err)
+	}
+	if doc.SPDXVersion != spdxVersion || doc.Name != "demo" || !strings.HasPrefix(doc.DocumentNamespace, spdxNamespace+"demo-") {
+		t.Errorf("unexpected document header: %+v", doc)
+	}
+	// 项目 + 5 个依赖/检测项
+	if len(doc.Packages) != 6 || doc.Packages[0].SPDXID != spdxProjectID {
+		t.Fatalf("packages = %+v", doc.Packages)
+	}
+	packages := make(map[string]spdxPackage)
+	for _, pkg := range doc.Packages {
+		packages[pkg.SPDXID] = pkg
+	}
+
+	gin, ok := packages["SPDXRef-Package-golang-github.com-gin-gonic-gin-1.9.1"]
+	if !ok {
+		t.Fatalf("gin package not found: %+v", doc.Packages)
+	}
+	if gin.PrimaryPackagePurpose != "FRAMEWORK" || gin.LicenseDeclared != spdxNoAssertion ||
+		len(gin.ExternalRefs) != 1 || gin.ExternalRefs[0].ReferenceLocator != "pkg:golang/github.com/gin-gonic/gin@v1.9.1" {
+		t.Errorf("gin package = %+v", gin)
+	}
+	if monolog := packages["SPDXRef-Package-composer-monolog-monolog-3.5.0"]; monolog.LicenseDeclared != "MIT" {
+		t.Errorf("monolog package = %+v", monolog)
+	}
+
+	relationships := make(map[string]bool)
+	for _, relationship := range doc.Relationships {
+		relationships[relationship.SPDXElementID+" "+relationship.RelationshipType+" "+relationship.RelatedSPDXElement] = true
+	}
+	for _, want := range []string{
+		"SPDXRef-DOCUMENT DESCRIBES SPDXRef-Project",
+		"SPDXRef-Project DEPENDS_ON SPDXRef-Package-golang-github.com-gin-gonic-gin-1.9.1",
+		"SPDXRef-Package-maven-junit-junit-4.13.2 DEV_DEPENDENCY_OF SPDXRef-Project",
+		"SPDXRef-Project CONTAINS SPDXRef-Package-jquery-3.6.0",
+	} {
+		if !relationships[want] {
+			t.Errorf("relationship %q not found: %+v", want, doc.Relationships)
+		}
+	}
+	// 间接依赖的依赖关系未知，不输出关系
+	for relationship := range relationships {
+		if strings.Contains(relationship, "golang.org-x-net") {
+			t.Errorf("unexpected relationship for indirect dependency: %s", relationship)
+		}
+	}
+}
+
+// TestSPDXTagValue tests the tag-value rendering of the SPDX document
+func TestSPDXTagValue(t *testing.T) {
+	data, err := SPDXTagValue(testReport())
+	if err != nil {
+		t.Fatalf("SPDXTagValue error: %v", err)
+	}
+	text := string(data)
+	for _, want := range []string{
+		"SPDXVersion: SPDX-2.3\n",
+		"DataLicense: CC0-1.0\n",
+		"Creator: Tool: xcanvas-test\n",
+		"PackageName: github.com/gin-gonic/gin\n",
+		"ExternalRef: PACKAGE-MANAGER purl pkg:golang/github.com/gin-gonic/gin@v1.9.1\n",
+		"PackageLicenseDeclared: MIT\n",
+		"Relationship: SPDXRef-Project DEPENDS_ON SPDXRef-Package-golang-github.com-gin-gonic-gin-1.9.1\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("tag-value output missing %q:\n%s", want, text)
+		}
+	}
+}
+
+// TestSPDXRangeVersions tests that range lower bounds are not published as versionInfo or purl versions
+func TestSPDXRangeVersions(t *testing.T) {
+	data, err := SPDXJSON(rangeReport())
+	if err != nil {
+		t.Fatalf("SPDXJSON error: %v", err)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal spdx error: %v", err)
+	}
+	packages := make(map[string]spdxPackage)
+	for _, pkg := range doc.Packages {
+		packages[pkg.SPDXID] = pkg
+	}
+
+	laravel, ok := packages["SPDXRef-Package-composer-laravel-framework"]
+	if !ok {
+		t.Fatalf("laravel package without version not found: %+v", doc.Packages)
+	}
+	if laravel.VersionInfo != "" || len(laravel.ExternalRefs) != 1 || laravel.ExternalRefs[0].ReferenceLocator != "pkg:composer/laravel/framework" ||
+		!strings.Contains(laravel.Comment, "declared version range ^10.0") {
+		t.Errorf("laravel package = %+v", laravel)
+	}
+	if monolog := packages["SPDXRef-Package-composer-monolog-monolog-3.5.0"]; monolog.VersionInfo != "3.5.0" {
+		t.Errorf("resolved monolog package = %+v", monolog)
+	}
+
+	text, err := SPDXTagValue(rangeReport())
+	if err != nil {
+		t.Fatalf("SPDXTagValue error: %v", err)
+	}
+	for _, unwanted := range []string{"PackageVersion: 10.0\n", "PackageVersion: 3.0\n", "laravel/framework@"} {
+		if strings.Contains(string(text), unwanted) {
+			t.Errorf("tag-value output contains %q:\n%s", unwanted, text)
+		}
+	}
+}
+
+// TestSPDXLicense tests that invalid license expressions are replaced with NOASSERTION
+func TestSPDXLicense(t *testing.T) {
+	testCases := map[string]string{
+		"MIT":                 "MIT",
+		"(MIT OR Apache-2.0)": "(MIT OR Apache-2.0)",
+		"GPL-2.0-only WITH Classpath-exception-2.0": "GPL-2.0-only WITH Classpath-exception-2.0",
+		"Apache License 2.0":                        spdxNoAssertion,
+		"":                                          spdxNoAssertion,
+	}
+	for license, want := range testCases {
+		if got := spdxPackageOf(&sbomPackage{name: "demo", license: license}, "SPDXRef-demo").LicenseDeclared; got != want {
+			t.Errorf("license %q = %q, want %q", license, got, want)
+		}
+	}
+}